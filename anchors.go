@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Anchors are named points where marks attach, in cells from the bottom-left
+// corner of the glyph grid, which sits on the descender line. As in UFO
+// sources, a base glyph has top, bottom or ogonek anchors and a mark the
+// matching _top, _bottom or _ogonek one; the first underscore anchor of a
+// glyph makes it a mark. Fonts get them as mark and mkmk positioning, and
+// /api/compose places marks on bases with them.
+
+const markAnchorPrefix = "_"
+
+var anchorNamePattern = regexp.MustCompile(`^_?[A-Za-z][A-Za-z0-9_.]*$`)
+
+type glyphAnchor struct {
+	Name string  `json:"name"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+}
+
+// markAnchor returns the anchor a mark attaches with, without its prefix.
+func (glyph glyphDocument) markAnchor() (glyphAnchor, bool) {
+	for _, anchor := range glyph.Anchors {
+		if strings.HasPrefix(anchor.Name, markAnchorPrefix) {
+			return glyphAnchor{Name: strings.TrimPrefix(anchor.Name, markAnchorPrefix), X: anchor.X, Y: anchor.Y}, true
+		}
+	}
+	return glyphAnchor{}, false
+}
+
+// anchor returns the base anchor of the given name.
+func (glyph glyphDocument) anchor(name string) (glyphAnchor, bool) {
+	for _, anchor := range glyph.Anchors {
+		if anchor.Name == name {
+			return anchor, true
+		}
+	}
+	return glyphAnchor{}, false
+}
+
+// validateGlyphAnchors checks that anchors are named points with unique
+// names.
+func validateGlyphAnchors(problems *validationError, raw json.RawMessage) {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(raw, &fields) != nil || isJSONNull(fields["anchors"]) {
+		return
+	}
+	var anchors []map[string]json.RawMessage
+	if err := json.Unmarshal(fields["anchors"], &anchors); err != nil {
+		problems.add("anchors", "must be an array of {name, x, y} objects")
+		return
+	}
+	seen := map[string]struct{}{}
+	for i, anchor := range anchors {
+		path := fmt.Sprintf("anchors[%d]", i)
+		var name string
+		if err := json.Unmarshal(anchor["name"], &name); err != nil || !anchorNamePattern.MatchString(name) {
+			problems.add(path+".name", "must be an anchor name such as top, bottom, ogonek or _top")
+		} else if _, duplicate := seen[name]; duplicate {
+			problems.add(path+".name", "duplicate anchor %q", name)
+		} else {
+			seen[name] = struct{}{}
+		}
+		for _, key := range []string{"x", "y"} {
+			var cells float64
+			if err := json.Unmarshal(anchor[key], &cells); err != nil || math.Abs(cells) > math.MaxInt16 {
+				problems.add(path+"."+key, "must be a number of grid cells")
+			}
+		}
+	}
+}
+
+// fontAnchor is an anchor in font units, from the glyph origin and the
+// baseline.
+type fontAnchor struct {
+	Name string
+	X    int
+	Y    int
+}
+
+// fontAnchors converts the anchors of a glyph drawn offset cells from the
+// origin.
+func fontAnchors(anchors []glyphAnchor, offset int, metrics fontMetrics) []fontAnchor {
+	out := make([]fontAnchor, 0, len(anchors))
+	for _, anchor := range anchors {
+		out = append(out, fontAnchor{
+			Name: anchor.Name,
+			X:    metrics.cellsToUnits(anchor.X + float64(offset)),
+			Y:    metrics.cellsToUnits(anchor.Y - float64(metrics.Descender)),
+		})
+	}
+	return out
+}
+
+// fontMarks holds mark attachment by glyph index. Each class is an anchor
+// name some mark attaches with.
+type fontMarks struct {
+	Classes []string
+	// Marks maps a mark to the anchor it attaches with, named after its
+	// class.
+	Marks map[int]fontAnchor
+	// Bases and MarkBases map other glyphs and marks to the anchors marks
+	// attach to, for the mark and mkmk features.
+	Bases     map[int][]fontAnchor
+	MarkBases map[int][]fontAnchor
+}
+
+func collectFontMarks(glyphs []fontGlyph) fontMarks {
+	marks := fontMarks{Marks: map[int]fontAnchor{}, Bases: map[int][]fontAnchor{}, MarkBases: map[int][]fontAnchor{}}
+	classes := map[string]struct{}{}
+	for i, glyph := range glyphs[1:] {
+		for _, anchor := range glyph.Anchors {
+			if name, ok := strings.CutPrefix(anchor.Name, markAnchorPrefix); ok {
+				marks.Marks[i+1] = fontAnchor{Name: name, X: anchor.X, Y: anchor.Y}
+				classes[name] = struct{}{}
+				break
+			}
+		}
+	}
+	for name := range classes {
+		marks.Classes = append(marks.Classes, name)
+	}
+	sort.Strings(marks.Classes)
+
+	for i, glyph := range glyphs[1:] {
+		targets := marks.Bases
+		if _, isMark := marks.Marks[i+1]; isMark {
+			targets = marks.MarkBases
+		}
+		for _, anchor := range glyph.Anchors {
+			if _, ok := classes[anchor.Name]; ok {
+				targets[i+1] = append(targets[i+1], anchor)
+			}
+		}
+	}
+	return marks
+}
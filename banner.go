@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// handleBanner renders text as ASCII art with the FIGlet version of the
+// project glyphs. Without a syntax the first one of the project is used.
+func (s *server) handleBanner(w http.ResponseWriter, r *http.Request) {
+	s.writeCORS(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	projectID := sanitizeProjectID(query.Get("project"))
+	text := query.Get("text")
+	if text == "" {
+		http.Error(w, "text is required", http.StatusBadRequest)
+		return
+	}
+	if len([]rune(text)) > maxBannerRunes {
+		http.Error(w, "text is too long", http.StatusBadRequest)
+		return
+	}
+	fill, err := parseFigletFill(query.Get("fill"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	doc, ok, err := s.hub.getProject(projectID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Error(w, "project not found", http.StatusNotFound)
+		return
+	}
+
+	syntaxKey := strings.TrimSpace(query.Get("syntax"))
+	if syntaxKey == "" {
+		syntaxes, err := decodeSyntaxDocuments(doc.Syntaxes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		if len(syntaxes) == 0 {
+			http.Error(w, "project has no syntaxes", http.StatusNotFound)
+			return
+		}
+		syntaxKey = syntaxes[0].ID
+	}
+
+	font, err := compileProjectFigletFont(doc.projectSnapshot, syntaxKey, projectFontMetadata(doc, doc.Metadata), fill)
+	if err != nil {
+		if errors.Is(err, errSyntaxNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(font.render(text)))
+}
@@ -0,0 +1,448 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// BDF (Glyph Bitmap Distribution Format 2.1) and its binary X11 sibling PCF.
+// Both carry the same properties; glyphs without a code point are kept in
+// BDF with ENCODING -1 and dropped from the PCF encoding table.
+
+const (
+	bdfResolution  = 75
+	bdfNoCodepoint = -1
+)
+
+type bdfProperty struct {
+	Name   string
+	Value  string
+	Number int
+	IsText bool
+}
+
+func xlfdField(value string) string {
+	return strings.NewReplacer("-", " ", "*", "", "?", "", ",", " ", `"`, "").Replace(value)
+}
+
+func (f *bitmapFont) pixelSize() int {
+	return max(1, f.Ascent+f.Descent)
+}
+
+func (f *bitmapFont) pointSize() int {
+	// decipoints at bdfResolution dpi
+	return int(jsRound(float64(f.pixelSize()) * 720 / bdfResolution))
+}
+
+func (f *bitmapFont) monospaced() bool {
+	for _, glyph := range f.Glyphs {
+		if glyph.Advance != f.Glyphs[0].Advance {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *bitmapFont) averageWidth() int {
+	if len(f.Glyphs) == 0 {
+		return 0
+	}
+	total := 0
+	for _, glyph := range f.Glyphs {
+		total += glyph.Advance
+	}
+	return int(jsRound(float64(total) * 10 / float64(len(f.Glyphs))))
+}
+
+func (f *bitmapFont) spacing() string {
+	if f.monospaced() {
+		return "C"
+	}
+	return "P"
+}
+
+func (f *bitmapFont) xlfdName() string {
+	foundry := f.Names.Manufacturer
+	if foundry == "" {
+		foundry = "Chirone"
+	}
+	return fmt.Sprintf("-%s-%s-%s-R-Normal--%d-%d-%d-%d-%s-%d-ISO10646-1",
+		xlfdField(foundry), xlfdField(f.Names.FamilyName), xlfdField(f.Names.StyleName),
+		f.pixelSize(), f.pointSize(), bdfResolution, bdfResolution, f.spacing(), f.averageWidth())
+}
+
+func (f *bitmapFont) properties() []bdfProperty {
+	properties := []bdfProperty{
+		{Name: "FONT", Value: f.xlfdName(), IsText: true},
+		{Name: "FAMILY_NAME", Value: f.Names.FamilyName, IsText: true},
+		{Name: "WEIGHT_NAME", Value: f.Names.StyleName, IsText: true},
+		{Name: "SLANT", Value: "R", IsText: true},
+		{Name: "SETWIDTH_NAME", Value: "Normal", IsText: true},
+		{Name: "PIXEL_SIZE", Number: f.pixelSize()},
+		{Name: "POINT_SIZE", Number: f.pointSize()},
+		{Name: "RESOLUTION_X", Number: bdfResolution},
+		{Name: "RESOLUTION_Y", Number: bdfResolution},
+		{Name: "SPACING", Value: f.spacing(), IsText: true},
+		{Name: "AVERAGE_WIDTH", Number: f.averageWidth()},
+		{Name: "CHARSET_REGISTRY", Value: "ISO10646", IsText: true},
+		{Name: "CHARSET_ENCODING", Value: "1", IsText: true},
+		{Name: "FONT_ASCENT", Number: f.Ascent},
+		{Name: "FONT_DESCENT", Number: f.Descent},
+	}
+	if f.Names.Manufacturer != "" {
+		properties = append(properties, bdfProperty{Name: "FOUNDRY", Value: f.Names.Manufacturer, IsText: true})
+	}
+	if f.Names.License != "" {
+		properties = append(properties, bdfProperty{Name: "COPYRIGHT", Value: f.Names.License, IsText: true})
+	}
+	if defaultChar, ok := f.defaultChar(); ok {
+		properties = append(properties, bdfProperty{Name: "DEFAULT_CHAR", Number: int(defaultChar)})
+	}
+	return properties
+}
+
+// defaultChar is the code point X11 draws for characters the font lacks:
+// space when present, otherwise the first encoded glyph.
+func (f *bitmapFont) defaultChar() (rune, bool) {
+	first, found := rune(0), false
+	for _, glyph := range f.Glyphs {
+		if !glyph.HasUnicode {
+			continue
+		}
+		if glyph.Unicode == ' ' {
+			return ' ', true
+		}
+		if !found {
+			first, found = glyph.Unicode, true
+		}
+	}
+	return first, found
+}
+
+func (f *bitmapFont) boundingBox() (width, height, offsetX, offsetY int) {
+	minX, minY, maxX, maxY := 0, 0, 0, 0
+	empty := true
+	for _, glyph := range f.Glyphs {
+		if glyph.Width == 0 || glyph.Height == 0 {
+			continue
+		}
+		if empty {
+			minY, maxY, empty = glyph.OffsetY, glyph.OffsetY+glyph.Height, false
+		}
+		minX = min(minX, glyph.OffsetX)
+		minY = min(minY, glyph.OffsetY)
+		maxX = max(maxX, glyph.OffsetX+glyph.Width)
+		maxY = max(maxY, glyph.OffsetY+glyph.Height)
+	}
+	if empty {
+		return 0, f.pixelSize(), 0, -f.Descent
+	}
+	return maxX - minX, maxY - minY, minX, minY
+}
+
+// scalableWidth is SWIDTH: the advance in 1/1000 of the point size.
+func (f *bitmapFont) scalableWidth(advance int) int {
+	return int(jsRound(float64(advance) * 1000 / float64(f.pixelSize())))
+}
+
+func quoteBDFString(value string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(value, "\n", " "), `"`, `""`) + `"`
+}
+
+func bdfGlyphName(glyph bitmapGlyph, index int) string {
+	name := strings.Join(strings.Fields(glyph.Name), "_")
+	if name == "" {
+		return fmt.Sprintf("glyph%d", index)
+	}
+	return name
+}
+
+func (f *bitmapFont) encodeBDF() []byte {
+	var out bytes.Buffer
+	width, height, offsetX, offsetY := f.boundingBox()
+	fmt.Fprintf(&out, "STARTFONT 2.1\n")
+	fmt.Fprintf(&out, "FONT %s\n", f.xlfdName())
+	fmt.Fprintf(&out, "SIZE %d %d %d\n", f.pixelSize(), bdfResolution, bdfResolution)
+	fmt.Fprintf(&out, "FONTBOUNDINGBOX %d %d %d %d\n", width, height, offsetX, offsetY)
+
+	properties := f.properties()
+	fmt.Fprintf(&out, "STARTPROPERTIES %d\n", len(properties))
+	for _, property := range properties {
+		if property.IsText {
+			fmt.Fprintf(&out, "%s %s\n", property.Name, quoteBDFString(property.Value))
+		} else {
+			fmt.Fprintf(&out, "%s %d\n", property.Name, property.Number)
+		}
+	}
+	fmt.Fprintf(&out, "ENDPROPERTIES\n")
+
+	fmt.Fprintf(&out, "CHARS %d\n", len(f.Glyphs))
+	for i, glyph := range f.Glyphs {
+		encoding := bdfNoCodepoint
+		if glyph.HasUnicode {
+			encoding = int(glyph.Unicode)
+		}
+		fmt.Fprintf(&out, "STARTCHAR %s\n", bdfGlyphName(glyph, i))
+		fmt.Fprintf(&out, "ENCODING %d\n", encoding)
+		fmt.Fprintf(&out, "SWIDTH %d 0\n", f.scalableWidth(glyph.Advance))
+		fmt.Fprintf(&out, "DWIDTH %d 0\n", glyph.Advance)
+		fmt.Fprintf(&out, "BBX %d %d %d %d\n", glyph.Width, glyph.Height, glyph.OffsetX, glyph.OffsetY)
+		fmt.Fprintf(&out, "BITMAP\n")
+		rowBytes := (glyph.Width + 7) / 8
+		for row := 0; row < glyph.Height; row++ {
+			fmt.Fprintf(&out, "%X\n", packBitmapRow(glyph, row, rowBytes))
+		}
+		fmt.Fprintf(&out, "ENDCHAR\n")
+	}
+	fmt.Fprintf(&out, "ENDFONT\n")
+	return out.Bytes()
+}
+
+// packBitmapRow packs one row most significant bit first into rowBytes bytes.
+func packBitmapRow(glyph bitmapGlyph, row, rowBytes int) []byte {
+	packed := make([]byte, rowBytes)
+	for x := 0; x < glyph.Width; x++ {
+		if glyph.pixel(x, row) {
+			packed[x/8] |= 0x80 >> (x % 8)
+		}
+	}
+	return packed
+}
+
+// PCF table types and format bits from the X11 pcf.h.
+const (
+	pcfProperties      = 1 << 0
+	pcfAccelerators    = 1 << 1
+	pcfMetrics         = 1 << 2
+	pcfBitmaps         = 1 << 3
+	pcfBDFEncodings    = 1 << 5
+	pcfSWidths         = 1 << 6
+	pcfGlyphNames      = 1 << 7
+	pcfBDFAccelerators = 1 << 8
+
+	pcfByteMSB     = 1 << 2
+	pcfBitMSB      = 1 << 3
+	pcfGlyphPad4   = 2 // row padding of 1 << 2 bytes
+	pcfFormat      = pcfByteMSB | pcfBitMSB | pcfGlyphPad4
+	pcfNoGlyph     = 0xffff
+	pcfHeaderMagic = "\x01fcp"
+)
+
+type pcfMetric struct {
+	LeftBearing, RightBearing, Width, Ascent, Descent int
+}
+
+func (m pcfMetric) append(out []byte) []byte {
+	for _, value := range []int{m.LeftBearing, m.RightBearing, m.Width, m.Ascent, m.Descent} {
+		out = be.AppendUint16(out, uint16(int16(value)))
+	}
+	return be.AppendUint16(out, 0) // attributes
+}
+
+func (f *bitmapFont) pcfMetrics() []pcfMetric {
+	metrics := make([]pcfMetric, len(f.Glyphs))
+	for i, glyph := range f.Glyphs {
+		metrics[i] = pcfMetric{
+			LeftBearing:  glyph.OffsetX,
+			RightBearing: glyph.OffsetX + glyph.Width,
+			Width:        glyph.Advance,
+			Ascent:       glyph.OffsetY + glyph.Height,
+			Descent:      -glyph.OffsetY,
+		}
+	}
+	return metrics
+}
+
+func (f *bitmapFont) pcfAccelerators(metrics []pcfMetric) []byte {
+	minBounds := pcfMetric{LeftBearing: math.MaxInt16, RightBearing: math.MaxInt16, Width: math.MaxInt16, Ascent: math.MaxInt16, Descent: math.MaxInt16}
+	maxBounds := pcfMetric{LeftBearing: math.MinInt16, RightBearing: math.MinInt16, Width: math.MinInt16, Ascent: math.MinInt16, Descent: math.MinInt16}
+	if len(metrics) == 0 {
+		minBounds, maxBounds = pcfMetric{}, pcfMetric{}
+	}
+	constantMetrics := true
+	maxOverlap := math.MinInt16
+	for _, m := range metrics {
+		minBounds = pcfMetric{min(minBounds.LeftBearing, m.LeftBearing), min(minBounds.RightBearing, m.RightBearing), min(minBounds.Width, m.Width), min(minBounds.Ascent, m.Ascent), min(minBounds.Descent, m.Descent)}
+		maxBounds = pcfMetric{max(maxBounds.LeftBearing, m.LeftBearing), max(maxBounds.RightBearing, m.RightBearing), max(maxBounds.Width, m.Width), max(maxBounds.Ascent, m.Ascent), max(maxBounds.Descent, m.Descent)}
+		maxOverlap = max(maxOverlap, m.RightBearing-m.Width)
+		if m != metrics[0] {
+			constantMetrics = false
+		}
+	}
+	if len(metrics) == 0 {
+		maxOverlap = 0
+	}
+	constantWidth := minBounds.Width == maxBounds.Width
+	terminalFont := constantMetrics && minBounds.LeftBearing >= 0 && maxBounds.RightBearing <= maxBounds.Width &&
+		minBounds.Ascent == f.Ascent && minBounds.Descent == f.Descent
+	inkInside := minBounds.LeftBearing >= 0 && maxOverlap <= 0 && maxBounds.Ascent <= f.Ascent && maxBounds.Descent <= f.Descent
+	noOverlap := maxOverlap <= minBounds.LeftBearing
+
+	flag := func(value bool) byte {
+		if value {
+			return 1
+		}
+		return 0
+	}
+	out := []byte{flag(noOverlap), flag(constantMetrics), flag(terminalFont), flag(constantWidth), flag(inkInside), 0, 0, 0}
+	out = be.AppendUint32(out, uint32(int32(f.Ascent)))
+	out = be.AppendUint32(out, uint32(int32(f.Descent)))
+	out = be.AppendUint32(out, uint32(int32(maxOverlap)))
+	out = minBounds.append(out)
+	return maxBounds.append(out)
+}
+
+func (f *bitmapFont) pcfPropertiesTable() []byte {
+	properties := f.properties()
+	pool := []byte{}
+	addString := func(value string) uint32 {
+		offset := uint32(len(pool))
+		pool = append(append(pool, value...), 0)
+		return offset
+	}
+	out := be.AppendUint32(nil, uint32(len(properties)))
+	for _, property := range properties {
+		out = be.AppendUint32(out, addString(property.Name))
+		if property.IsText {
+			out = append(out, 1)
+			out = be.AppendUint32(out, addString(property.Value))
+		} else {
+			out = append(out, 0)
+			out = be.AppendUint32(out, uint32(int32(property.Number)))
+		}
+	}
+	for len(out)%4 != 0 {
+		out = append(out, 0)
+	}
+	out = be.AppendUint32(out, uint32(len(pool)))
+	return append(out, pool...)
+}
+
+func (f *bitmapFont) pcfBitmapsTable() []byte {
+	offsets := []byte{}
+	data := []byte{}
+	sizes := [4]int{}
+	for _, glyph := range f.Glyphs {
+		offsets = be.AppendUint32(offsets, uint32(len(data)))
+		rowBytes := (glyph.Width + 7) / 8
+		padded := (rowBytes + 3) &^ 3
+		for row := 0; row < glyph.Height; row++ {
+			data = append(data, packBitmapRow(glyph, row, padded)...)
+		}
+		for pad := range sizes {
+			sizes[pad] += glyph.Height * ((rowBytes + (1 << pad) - 1) &^ ((1 << pad) - 1))
+		}
+	}
+	out := be.AppendUint32(nil, uint32(len(f.Glyphs)))
+	out = append(out, offsets...)
+	for _, size := range sizes {
+		out = be.AppendUint32(out, uint32(size))
+	}
+	return append(out, data...)
+}
+
+func (f *bitmapFont) pcfEncodingsTable() []byte {
+	indexByCode := map[rune]int{}
+	codes := []rune{}
+	for i, glyph := range f.Glyphs {
+		// PCF encodings address two bytes, so only the BMP fits.
+		if glyph.HasUnicode && glyph.Unicode <= 0xffff {
+			indexByCode[glyph.Unicode] = i
+			codes = append(codes, glyph.Unicode)
+		}
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	minByte1, maxByte1, minByte2, maxByte2 := 0, 0, 0, 0
+	if len(codes) > 0 {
+		minByte1, maxByte1 = int(codes[0]>>8), int(codes[len(codes)-1]>>8)
+		minByte2, maxByte2 = 0xff, 0
+		for _, code := range codes {
+			minByte2 = min(minByte2, int(code&0xff))
+			maxByte2 = max(maxByte2, int(code&0xff))
+		}
+	}
+	defaultChar, ok := f.defaultChar()
+	if !ok || defaultChar > 0xffff {
+		defaultChar = 0
+	}
+
+	out := be.AppendUint16(nil, uint16(minByte2))
+	out = be.AppendUint16(out, uint16(maxByte2))
+	out = be.AppendUint16(out, uint16(minByte1))
+	out = be.AppendUint16(out, uint16(maxByte1))
+	out = be.AppendUint16(out, uint16(defaultChar))
+	for byte1 := minByte1; byte1 <= maxByte1; byte1++ {
+		for byte2 := minByte2; byte2 <= maxByte2; byte2++ {
+			index, ok := indexByCode[rune(byte1<<8|byte2)]
+			if !ok {
+				out = be.AppendUint16(out, pcfNoGlyph)
+				continue
+			}
+			out = be.AppendUint16(out, uint16(index))
+		}
+	}
+	return out
+}
+
+func (f *bitmapFont) pcfGlyphNamesTable() []byte {
+	offsets := []byte{}
+	names := []byte{}
+	for i, glyph := range f.Glyphs {
+		offsets = be.AppendUint32(offsets, uint32(len(names)))
+		names = append(append(names, bdfGlyphName(glyph, i)...), 0)
+	}
+	out := be.AppendUint32(nil, uint32(len(f.Glyphs)))
+	out = append(out, offsets...)
+	out = be.AppendUint32(out, uint32(len(names)))
+	return append(out, names...)
+}
+
+func (f *bitmapFont) encodePCF() []byte {
+	metrics := f.pcfMetrics()
+	metricsTable := be.AppendUint32(nil, uint32(len(metrics)))
+	swidths := be.AppendUint32(nil, uint32(len(f.Glyphs)))
+	for i, metric := range metrics {
+		metricsTable = metric.append(metricsTable)
+		swidths = be.AppendUint32(swidths, uint32(f.scalableWidth(f.Glyphs[i].Advance)))
+	}
+	accelerators := f.pcfAccelerators(metrics)
+
+	tables := []struct {
+		Type uint32
+		Data []byte
+	}{
+		{pcfProperties, f.pcfPropertiesTable()},
+		{pcfAccelerators, accelerators},
+		{pcfMetrics, metricsTable},
+		{pcfBitmaps, f.pcfBitmapsTable()},
+		{pcfBDFEncodings, f.pcfEncodingsTable()},
+		{pcfSWidths, swidths},
+		{pcfGlyphNames, f.pcfGlyphNamesTable()},
+		{pcfBDFAccelerators, accelerators},
+	}
+
+	// The header and table of contents are always little endian; each table
+	// starts with its own format word, stored little endian as well.
+	le := binary.LittleEndian
+	out := append([]byte(pcfHeaderMagic), le.AppendUint32(nil, uint32(len(tables)))...)
+	offset := len(out) + 16*len(tables)
+	body := []byte{}
+	for _, table := range tables {
+		size := 4 + len(table.Data)
+		out = le.AppendUint32(out, table.Type)
+		out = le.AppendUint32(out, pcfFormat)
+		out = le.AppendUint32(out, uint32(size))
+		out = le.AppendUint32(out, uint32(offset+len(body)))
+		body = le.AppendUint32(body, pcfFormat)
+		body = append(body, table.Data...)
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+	}
+	return append(out, body...)
+}
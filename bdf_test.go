@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+)
+
+// testBitmapFont rasterises A, a period and an unencoded alternate at one
+// pixel per cell: 4 pixels above the baseline and 1 below.
+func testBitmapFont(t *testing.T) *bitmapFont {
+	t.Helper()
+	snapshot := testSnapshot(t,
+		glyphDocument{ID: "1", Name: "A", Structure: "###\n# #\n###\n# #"},
+		glyphDocument{ID: "2", Name: ".", Structure: "#"},
+		glyphDocument{ID: "3", Name: "x.ss01", Structure: "# #\n # \n# #"},
+	)
+	font, err := compileProjectBitmapFont(snapshot, "regular", json.RawMessage(`{"familyName": "Test"}`), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return font
+}
+
+const testBDF = `STARTFONT 2.1
+FONT -Chirone-Test-Regular-R-Normal--5-48-75-75-P-23-ISO10646-1
+SIZE 5 75 75
+FONTBOUNDINGBOX 3 4 0 -1
+STARTPROPERTIES 16
+FONT "-Chirone-Test-Regular-R-Normal--5-48-75-75-P-23-ISO10646-1"
+FAMILY_NAME "Test"
+WEIGHT_NAME "Regular"
+SLANT "R"
+SETWIDTH_NAME "Normal"
+PIXEL_SIZE 5
+POINT_SIZE 48
+RESOLUTION_X 75
+RESOLUTION_Y 75
+SPACING "P"
+AVERAGE_WIDTH 23
+CHARSET_REGISTRY "ISO10646"
+CHARSET_ENCODING "1"
+FONT_ASCENT 4
+FONT_DESCENT 1
+DEFAULT_CHAR 65
+ENDPROPERTIES
+CHARS 3
+STARTCHAR A
+ENCODING 65
+SWIDTH 600 0
+DWIDTH 3 0
+BBX 3 4 0 -1
+BITMAP
+E0
+A0
+E0
+A0
+ENDCHAR
+STARTCHAR .
+ENCODING 46
+SWIDTH 200 0
+DWIDTH 1 0
+BBX 1 1 0 -1
+BITMAP
+80
+ENDCHAR
+STARTCHAR x.ss01
+ENCODING -1
+SWIDTH 600 0
+DWIDTH 3 0
+BBX 3 3 0 -1
+BITMAP
+A0
+40
+A0
+ENDCHAR
+ENDFONT
+`
+
+func TestEncodeBDF(t *testing.T) {
+	if got := string(testBitmapFont(t).encodeBDF()); got != testBDF {
+		t.Errorf("BDF output differs:\n%s\nwant:\n%s", got, testBDF)
+	}
+}
+
+// pcfTable is a table of contents entry of a PCF file with its contents,
+// past the format word.
+type pcfTable struct {
+	Type, Format, Size, Offset uint32
+	Data                       []byte
+}
+
+func readPCFTables(t *testing.T, data []byte) map[uint32]pcfTable {
+	t.Helper()
+	le := binary.LittleEndian
+	if string(data[:4]) != pcfHeaderMagic {
+		t.Fatalf("magic = %q", data[:4])
+	}
+	count := int(le.Uint32(data[4:]))
+	next := uint32(8 + 16*count)
+	tables := map[uint32]pcfTable{}
+	previousType := uint32(0)
+	for i := 0; i < count; i++ {
+		entry := data[8+16*i:]
+		table := pcfTable{Type: le.Uint32(entry), Format: le.Uint32(entry[4:]), Size: le.Uint32(entry[8:]), Offset: le.Uint32(entry[12:])}
+		if table.Type <= previousType {
+			t.Errorf("table %#x follows %#x", table.Type, previousType)
+		}
+		previousType = table.Type
+		if table.Offset != next {
+			t.Errorf("table %#x at %d, want %d", table.Type, table.Offset, next)
+		}
+		if int(table.Offset+table.Size) > len(data) {
+			t.Fatalf("table %#x ends at %d, past the %d bytes of the file", table.Type, table.Offset+table.Size, len(data))
+		}
+		if format := le.Uint32(data[table.Offset:]); format != table.Format || format != pcfFormat {
+			t.Errorf("table %#x starts with format %#x, TOC says %#x, want %#x", table.Type, format, table.Format, pcfFormat)
+		}
+		table.Data = data[table.Offset+4 : table.Offset+table.Size]
+		tables[table.Type] = table
+		next = (table.Offset + table.Size + 3) &^ 3
+	}
+	if int(next) != len(data) {
+		t.Errorf("file is %d bytes, tables end at %d", len(data), next)
+	}
+	return tables
+}
+
+func TestEncodePCF(t *testing.T) {
+	tables := readPCFTables(t, testBitmapFont(t).encodePCF())
+	for _, kind := range []uint32{pcfProperties, pcfAccelerators, pcfMetrics, pcfBitmaps, pcfBDFEncodings, pcfSWidths, pcfGlyphNames, pcfBDFAccelerators} {
+		if _, ok := tables[kind]; !ok {
+			t.Errorf("no table %#x", kind)
+		}
+	}
+
+	metrics := tables[pcfMetrics].Data
+	if count := be.Uint32(metrics); count != 3 {
+		t.Fatalf("%d metrics, want 3", count)
+	}
+	wantMetrics := []pcfMetric{
+		{LeftBearing: 0, RightBearing: 3, Width: 3, Ascent: 3, Descent: 1},
+		{LeftBearing: 0, RightBearing: 1, Width: 1, Ascent: 0, Descent: 1},
+		{LeftBearing: 0, RightBearing: 3, Width: 3, Ascent: 2, Descent: 1},
+	}
+	for i, want := range wantMetrics {
+		record := metrics[4+12*i:]
+		got := pcfMetric{}
+		for j, field := range []*int{&got.LeftBearing, &got.RightBearing, &got.Width, &got.Ascent, &got.Descent} {
+			*field = int(int16(be.Uint16(record[2*j:])))
+		}
+		if got != want {
+			t.Errorf("metrics of glyph %d = %+v, want %+v", i, got, want)
+		}
+	}
+
+	// Rows are padded to four bytes, most significant bit first.
+	bitmaps := tables[pcfBitmaps].Data
+	if count := be.Uint32(bitmaps); count != 3 {
+		t.Fatalf("%d bitmaps, want 3", count)
+	}
+	for i, want := range []uint32{0, 16, 20} {
+		if got := be.Uint32(bitmaps[4+4*i:]); got != want {
+			t.Errorf("bitmap %d at %d, want %d", i, got, want)
+		}
+	}
+	for pad, want := range []uint32{8, 16, 32, 64} {
+		if got := be.Uint32(bitmaps[16+4*pad:]); got != want {
+			t.Errorf("bitmap size with %d-byte rows = %d, want %d", 1<<pad, got, want)
+		}
+	}
+	wantData := []byte{
+		0xe0, 0, 0, 0, 0xa0, 0, 0, 0, 0xe0, 0, 0, 0, 0xa0, 0, 0, 0,
+		0x80, 0, 0, 0,
+		0xa0, 0, 0, 0, 0x40, 0, 0, 0, 0xa0, 0, 0, 0,
+	}
+	if got := bitmaps[32:]; !bytes.Equal(got, wantData) {
+		t.Errorf("bitmap data = %x, want %x", got, wantData)
+	}
+
+	// The encoding table spans . (0x2E) to A (0x41) and leaves the
+	// alternate out.
+	encodings := tables[pcfBDFEncodings].Data
+	header := []uint16{}
+	for i := 0; i < 5; i++ {
+		header = append(header, be.Uint16(encodings[2*i:]))
+	}
+	if want := []uint16{0x2e, 0x41, 0, 0, 0x41}; !equalUint16s(header, want) {
+		t.Errorf("encoding header = %x, want %x", header, want)
+	}
+	for code := 0x2e; code <= 0x41; code++ {
+		want := uint16(pcfNoGlyph)
+		switch code {
+		case 'A':
+			want = 0
+		case '.':
+			want = 1
+		}
+		if got := be.Uint16(encodings[10+2*(code-0x2e):]); got != want {
+			t.Errorf("glyph of %#x = %#x, want %#x", code, got, want)
+		}
+	}
+}
+
+func equalUint16s(a, b []uint16) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
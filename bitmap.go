@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Bitmap fonts rasterise the same outlines as the vector compiler at a fixed
+// number of pixels per grid cell. A pixel is set when at least half of it is
+// covered, so at one pixel per cell every mostly-filled cell becomes a dot.
+
+const (
+	defaultPixelsPerCell = 1
+	maxPixelsPerCell     = 64
+	bitmapSubsamples     = 4
+	// bitmapSampleNudge moves samples off cell edges and diagonals, so shapes
+	// that touch a sample line are classified the same way every time.
+	bitmapSampleNudge = 1e-6
+)
+
+type bitmapGlyph struct {
+	Name       string
+	Unicode    rune
+	HasUnicode bool
+	// Advance and Width are in pixels; the bitmap is Width x Height with its
+	// left column OffsetX pixels from the origin and its bottom row OffsetY
+	// pixels from the baseline (negative below it).
+	Advance int
+	Width   int
+	Height  int
+	OffsetX int
+	OffsetY int
+	// Pixels is row-major, top row first.
+	Pixels []bool
+}
+
+func (g bitmapGlyph) pixel(x, row int) bool {
+	return g.Pixels[row*g.Width+x]
+}
+
+type bitmapFont struct {
+	Names         fontNames
+	PixelsPerCell int
+	// Ascent and Descent are the metrics ascender and descender in pixels.
+	Ascent  int
+	Descent int
+	Glyphs  []bitmapGlyph
+}
+
+func (f *bitmapFont) encode(format fontFormat, options fontExportOptions) ([]byte, error) {
+	switch format {
+	case fontFormatBDF:
+		return f.encodeBDF(), nil
+	case fontFormatPCF:
+		return f.encodePCF(), nil
+	case fontFormatPSF:
+		return f.encodePSF2(), nil
+	case fontFormatPSFGZ:
+		return f.encodePSF2Gzip()
+	case fontFormatGFX, fontFormatGo:
+		layout, err := f.gfxLayout()
+		if err != nil {
+			return nil, err
+		}
+		if format == fontFormatGFX {
+			return layout.encodeC(), nil
+		}
+		pkg := options.GoPackage
+		if pkg == "" {
+			pkg = defaultGoPackage
+		}
+		return layout.encodeGo(pkg)
+	default:
+		return nil, fmt.Errorf("%s is not a bitmap font format", format)
+	}
+}
+
+func parsePixelsPerCell(value int) (int, error) {
+	if value == 0 {
+		return defaultPixelsPerCell, nil
+	}
+	if value < 1 || value > maxPixelsPerCell {
+		return 0, fmt.Errorf("pixels per cell must be between 1 and %d", maxPixelsPerCell)
+	}
+	return value, nil
+}
+
+// compileProjectBitmapFont rasterises one syntax of a snapshot at
+// pixelsPerCell pixels per grid cell.
+func compileProjectBitmapFont(snapshot projectSnapshot, syntaxKey string, metadata json.RawMessage, pixelsPerCell int) (*bitmapFont, error) {
+	syntax, err := findSyntaxInSnapshot(snapshot.Syntaxes, syntaxKey)
+	if err != nil {
+		return nil, err
+	}
+	glyphs, err := decodeGlyphDocuments(snapshot.Glyphs)
+	if err != nil {
+		return nil, err
+	}
+	return compileBitmapFont(syntax, glyphs, normalizeFontMetrics(snapshot.Metrics), normalizeFontMetadata(metadata), pixelsPerCell)
+}
+
+func compileBitmapFont(syntax syntaxDocument, glyphs []glyphDocument, metrics fontMetrics, metadata fontMetadata, pixelsPerCell int) (*bitmapFont, error) {
+	pixelsPerCell, err := parsePixelsPerCell(pixelsPerCell)
+	if err != nil {
+		return nil, err
+	}
+	props := newPropEvaluator(syntaxPropSeed(syntax))
+	font := &bitmapFont{
+		Names:         newFontNames(syntax, metadata),
+		PixelsPerCell: pixelsPerCell,
+		Ascent:        metrics.Ascender * pixelsPerCell,
+		Descent:       metrics.Descender * pixelsPerCell,
+	}
+	for _, glyph := range resolveFontGlyphs(syntax, glyphs, metadata.GlyphOrder) {
+		contours, err := drawGlyphContours(glyph.Body, syntax, float64(pixelsPerCell), float64(metrics.Descender), props)
+		if err != nil {
+			return nil, fmt.Errorf("glyph %q: %w", glyph.Name, err)
+		}
+		rows := 0
+		if glyph.Body != "" {
+			rows = len(splitStructureRows(glyph.Body))
+		}
+		width := structureColumnsMax(glyph.Body) * pixelsPerCell
+		height := rows * pixelsPerCell
+		offsetY := -metrics.Descender * pixelsPerCell
+		font.Glyphs = append(font.Glyphs, bitmapGlyph{
+			Name:       glyph.Name,
+			Unicode:    glyph.Unicode,
+			HasUnicode: glyph.HasUnicode,
+			Advance:    glyph.Spacing.Advance * pixelsPerCell,
+			Width:      width,
+			Height:     height,
+			OffsetX:    glyph.Spacing.Offset * pixelsPerCell,
+			OffsetY:    offsetY,
+			Pixels:     rasterizeContours(contours, width, height, offsetY),
+		})
+	}
+	return font, nil
+}
+
+// structureColumnsMax is the widest row of a body, which bounds the ink even
+// when later rows are longer than the first one.
+func structureColumnsMax(body string) int {
+	if body == "" {
+		return 0
+	}
+	return structureRowsWidth(splitStructureRows(body))
+}
+
+type rasterCrossing struct {
+	X         float64
+	Direction int
+}
+
+// rasterizeContours fills a width x height bitmap whose bottom-left corner
+// sits at (0, offsetY) in contour space, using the non-zero winding rule.
+func rasterizeContours(contours []outlineContour, width, height, offsetY int) []bool {
+	pixels := make([]bool, width*height)
+	if width == 0 || height == 0 || len(contours) == 0 {
+		return pixels
+	}
+	polygons := make([][]outlinePoint, len(contours))
+	for i, contour := range contours {
+		polygons[i] = contour.flatten()
+	}
+
+	coverage := make([]int, width*height)
+	crossings := []rasterCrossing{}
+	for sampleRow := 0; sampleRow < height*bitmapSubsamples; sampleRow++ {
+		y := float64(offsetY) + (float64(sampleRow)+0.5)/bitmapSubsamples + bitmapSampleNudge
+		crossings = crossings[:0]
+		for _, polygon := range polygons {
+			for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+				a, b := polygon[j], polygon[i]
+				if (a.Y > y) == (b.Y > y) {
+					continue
+				}
+				direction := 1
+				if b.Y < a.Y {
+					direction = -1
+				}
+				crossings = append(crossings, rasterCrossing{
+					X:         a.X + (y-a.Y)*(b.X-a.X)/(b.Y-a.Y),
+					Direction: direction,
+				})
+			}
+		}
+		if len(crossings) == 0 {
+			continue
+		}
+		sort.Slice(crossings, func(i, j int) bool { return crossings[i].X < crossings[j].X })
+
+		row := height - 1 - sampleRow/bitmapSubsamples
+		winding, next := 0, 0
+		for sampleColumn := 0; sampleColumn < width*bitmapSubsamples; sampleColumn++ {
+			x := (float64(sampleColumn)+0.5)/bitmapSubsamples + bitmapSampleNudge*0.5
+			for next < len(crossings) && crossings[next].X < x {
+				winding += crossings[next].Direction
+				next++
+			}
+			if winding != 0 {
+				coverage[row*width+sampleColumn/bitmapSubsamples]++
+			}
+		}
+	}
+	for i, samples := range coverage {
+		pixels[i] = 2*samples >= bitmapSubsamples*bitmapSubsamples
+	}
+	return pixels
+}
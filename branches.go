@@ -0,0 +1,557 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A branch is a project of its own, <project>--<name>, so it has its live
+// state, event stream and revisions like any other; every /api endpoint works
+// on it through ?project=. The parent keeps the branch record in
+// data/<project>/branches with the snapshot the branch started from, which
+// is the base of three-way merges back into the parent and moves to the
+// merged branch state after each merge.
+
+const branchProjectSeparator = "--"
+
+var (
+	errBranchExists = errors.New("branch already exists")
+	// errBranchProjectExists refuses a branch whose project id is taken by
+	// a project that is not one of its branches.
+	errBranchProjectExists = errors.New("a project with the branch id already exists")
+)
+
+type branchDocument struct {
+	Name string `json:"name"`
+	// Project is the id of the branch project.
+	Project string `json:"project"`
+	Parent  string `json:"parent"`
+	// From is the revision the branch was forked from, or "current".
+	From         string `json:"from"`
+	CreatedAt    string `json:"createdAt"`
+	ClientID     string `json:"clientId,omitempty"`
+	LastMergedAt string `json:"lastMergedAt,omitempty"`
+	// Base is the snapshot merges compare both sides against.
+	Base projectSnapshot `json:"base"`
+}
+
+type branchMeta struct {
+	Name         string `json:"name"`
+	Project      string `json:"project"`
+	Parent       string `json:"parent"`
+	From         string `json:"from"`
+	CreatedAt    string `json:"createdAt"`
+	ClientID     string `json:"clientId,omitempty"`
+	LastMergedAt string `json:"lastMergedAt,omitempty"`
+}
+
+type branchesResponse struct {
+	Project  string       `json:"project"`
+	Branches []branchMeta `json:"branches"`
+}
+
+type createBranchRequest struct {
+	ClientID string `json:"clientId,omitempty"`
+	Name     string `json:"name"`
+	// From is a revision id, or empty or "current" for the project as it is.
+	From string `json:"from,omitempty"`
+}
+
+type mergeBranchRequest struct {
+	ClientID string `json:"clientId,omitempty"`
+	Branch   string `json:"branch"`
+	// Resolutions settle conflicts by taking one side of an entity.
+	Resolutions []mergeResolution `json:"resolutions,omitempty"`
+}
+
+type mergeResolution struct {
+	Entity string `json:"entity"`
+	ID     string `json:"id"`
+	// Take is ours, the parent, or theirs, the branch.
+	Take string `json:"take"`
+}
+
+type mergedEntity struct {
+	Entity  string `json:"entity"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// mergeConflict is an entity both sides changed since the base, each in its
+// own way. Fields list what each side changed, or are empty when it deleted
+// the entity.
+type mergeConflict struct {
+	Entity        string          `json:"entity"`
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	OursFields    []string        `json:"oursFields"`
+	TheirsFields  []string        `json:"theirsFields"`
+	OursDeleted   bool            `json:"oursDeleted,omitempty"`
+	TheirsDeleted bool            `json:"theirsDeleted,omitempty"`
+	Ours          json.RawMessage `json:"ours,omitempty"`
+	Theirs        json.RawMessage `json:"theirs,omitempty"`
+}
+
+type mergeBranchResponse struct {
+	Project string `json:"project"`
+	Branch  string `json:"branch"`
+	DryRun  bool   `json:"dryRun,omitempty"`
+	// Merged lists the branch changes written to the project; conflicts
+	// leave the project untouched.
+	Merged         []mergedEntity  `json:"merged"`
+	Conflicts      []mergeConflict `json:"conflicts"`
+	ProjectVersion int64           `json:"projectVersion"`
+}
+
+// mergeConflictError carries the report of a merge stopped by conflicts.
+type mergeConflictError struct {
+	Report mergeBranchResponse
+}
+
+func (e *mergeConflictError) Error() string {
+	return fmt.Sprintf("%d conflicting entities", len(e.Report.Conflicts))
+}
+
+func branchProjectID(parent, name string) string {
+	return parent + branchProjectSeparator + name
+}
+
+func (h *hub) projectBranchDir(projectID string) string {
+	return filepath.Join(h.projectDir(projectID), "branches")
+}
+
+func (h *hub) projectBranchFile(projectID, name string) string {
+	return filepath.Join(h.projectBranchDir(projectID), fmt.Sprintf("%s.json", name))
+}
+
+func branchMetaFromDocument(doc branchDocument) branchMeta {
+	return branchMeta{
+		Name:         doc.Name,
+		Project:      doc.Project,
+		Parent:       doc.Parent,
+		From:         doc.From,
+		CreatedAt:    doc.CreatedAt,
+		ClientID:     doc.ClientID,
+		LastMergedAt: doc.LastMergedAt,
+	}
+}
+
+func (h *hub) loadBranchDocument(projectID, name string) (*branchDocument, error) {
+	name = strings.TrimSpace(name)
+	if !projectIDPattern.MatchString(name) {
+		return nil, errors.New("invalid branch name")
+	}
+	raw, err := os.ReadFile(h.projectBranchFile(projectID, name))
+	if err != nil {
+		return nil, err
+	}
+	var doc branchDocument
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (h *hub) saveBranchDocument(doc branchDocument) error {
+	bytes, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeJSONAtomic(h.projectBranchFile(doc.Parent, doc.Name), bytes)
+}
+
+func (h *hub) listBranches(projectID string) (branchesResponse, error) {
+	projectID = sanitizeProjectID(projectID)
+	response := branchesResponse{Project: projectID, Branches: []branchMeta{}}
+	entries, err := os.ReadDir(h.projectBranchDir(projectID))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return response, nil
+		}
+		return branchesResponse{}, err
+	}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok || !projectIDPattern.MatchString(name) {
+			continue
+		}
+		doc, err := h.loadBranchDocument(projectID, name)
+		if err != nil {
+			return branchesResponse{}, err
+		}
+		response.Branches = append(response.Branches, branchMetaFromDocument(*doc))
+	}
+	sort.Slice(response.Branches, func(i, j int) bool { return response.Branches[i].Name < response.Branches[j].Name })
+	return response, nil
+}
+
+// createBranch forks the project, or one of its revisions, into a new
+// branch project. Kinds a revision predates are taken from the project.
+func (h *hub) createBranch(projectID string, req createBranchRequest) (branchMeta, error) {
+	projectID = sanitizeProjectID(projectID)
+	name := strings.TrimSpace(req.Name)
+	if !projectIDPattern.MatchString(name) {
+		return branchMeta{}, errors.New("branch name must use letters, digits, - and _ only")
+	}
+	// p--a with branch b--c and p--a--b with branch c would share a project.
+	if strings.Contains(name, branchProjectSeparator) {
+		return branchMeta{}, fmt.Errorf("branch name must not contain %q", branchProjectSeparator)
+	}
+	from := strings.TrimSpace(req.From)
+	if from == "" {
+		from = currentRevisionID
+	}
+
+	doc, ok, err := h.getProject(projectID)
+	if err != nil {
+		return branchMeta{}, err
+	}
+	if !ok {
+		return branchMeta{}, os.ErrNotExist
+	}
+	snapshot := doc.projectSnapshot
+	if from != currentRevisionID {
+		revision, err := h.loadRevisionDocument(projectID, from)
+		if err != nil {
+			return branchMeta{}, err
+		}
+		for _, kind := range entityKinds {
+			if raw := *kind.snapshotField(&revision.projectSnapshot); len(raw) > 0 {
+				*kind.snapshotField(&snapshot) = raw
+			}
+		}
+	}
+
+	branchID := branchProjectID(projectID, name)
+	state, err := newProjectStateFromDocument(projectDocument{
+		Project:         branchID,
+		Version:         1,
+		UpdatedAt:       time.Now().UTC().Format(time.RFC3339Nano),
+		projectSnapshot: cloneProjectSnapshot(snapshot),
+	})
+	if err != nil {
+		return branchMeta{}, err
+	}
+	branch := branchDocument{
+		Name:      name,
+		Project:   branchID,
+		Parent:    projectID,
+		From:      from,
+		CreatedAt: state.Doc.UpdatedAt,
+		ClientID:  strings.TrimSpace(req.ClientID),
+		Base:      state.Doc.projectSnapshot,
+	}
+
+	h.mu.Lock()
+	if _, err := os.Stat(h.projectBranchFile(projectID, name)); err == nil {
+		h.mu.Unlock()
+		return branchMeta{}, errBranchExists
+	}
+	_, loaded := h.projects[branchID]
+	if _, err := os.Stat(h.projectFile(branchID)); loaded || err == nil {
+		h.mu.Unlock()
+		return branchMeta{}, errBranchProjectExists
+	}
+	h.projects[branchID] = state
+	persistCopy := cloneProjectStateForPersist(state)
+	h.mu.Unlock()
+
+	if err := h.saveProjectStateToDisk(branchID, persistCopy); err != nil {
+		return branchMeta{}, err
+	}
+	if err := h.saveBranchDocument(branch); err != nil {
+		return branchMeta{}, err
+	}
+	return branchMetaFromDocument(branch), nil
+}
+
+// mergeBranch brings the changes of a branch since its base into the
+// project. Each entity is merged on its own: a side that left it as in the
+// base takes the other side, and an entity both sides changed differently is
+// a conflict unless a resolution picks one. Any conflict left stops the
+// merge as a whole.
+func (h *hub) mergeBranch(projectID string, req mergeBranchRequest, dryRun bool) (mergeBranchResponse, error) {
+	projectID = sanitizeProjectID(projectID)
+	branch, err := h.loadBranchDocument(projectID, req.Branch)
+	if err != nil {
+		return mergeBranchResponse{}, err
+	}
+	if _, ok, err := h.getProject(branch.Project); err != nil {
+		return mergeBranchResponse{}, err
+	} else if !ok {
+		return mergeBranchResponse{}, os.ErrNotExist
+	}
+	resolutions := map[string]string{}
+	for _, resolution := range req.Resolutions {
+		if resolution.Take != "ours" && resolution.Take != "theirs" {
+			return mergeBranchResponse{}, fmt.Errorf("resolution of %s %q must take ours or theirs", resolution.Entity, resolution.ID)
+		}
+		resolutions[resolution.Entity+"/"+resolution.ID] = resolution.Take
+	}
+
+	// The branch record, the branch state and the project are all read and
+	// the base moved under one lock, so the new base is exactly what was
+	// merged and a concurrent merge of the branch sees it.
+	h.mu.Lock()
+	if branch, err = h.loadBranchDocument(projectID, req.Branch); err != nil {
+		h.mu.Unlock()
+		return mergeBranchResponse{}, err
+	}
+	theirsState, err := h.getOrCreateProjectStateLocked(branch.Project)
+	if err != nil {
+		h.mu.Unlock()
+		return mergeBranchResponse{}, err
+	}
+	state, err := h.getOrCreateProjectStateLocked(projectID)
+	if err != nil {
+		h.mu.Unlock()
+		return mergeBranchResponse{}, err
+	}
+	base := map[string]map[string]json.RawMessage{}
+	theirs := map[string]map[string]json.RawMessage{}
+	for _, kind := range entityKinds {
+		if base[kind.Name], err = kind.parseSnapshot(*kind.snapshotField(&branch.Base)); err != nil {
+			h.mu.Unlock()
+			return mergeBranchResponse{}, err
+		}
+		if theirs[kind.Name], err = kind.parseSnapshot(*kind.snapshotField(&theirsState.Doc.projectSnapshot)); err != nil {
+			h.mu.Unlock()
+			return mergeBranchResponse{}, err
+		}
+	}
+
+	response := mergeBranchResponse{Project: projectID, Branch: branch.Name, DryRun: dryRun, Merged: []mergedEntity{}, Conflicts: []mergeConflict{}}
+	writes := []entityWrite{}
+	mergedItems := map[string]map[string]json.RawMessage{}
+	for _, kind := range entityKinds {
+		ours := state.Entities[kind.Name].Items
+		ids := map[string]json.RawMessage{}
+		for _, items := range []map[string]json.RawMessage{base[kind.Name], ours, theirs[kind.Name]} {
+			for id := range items {
+				ids[id] = nil
+			}
+		}
+		merged := cloneRawMap(ours)
+		kindWrites := []entityWrite{}
+		for _, id := range sortedEntityIDs(ids) {
+			baseRaw, inBase := base[kind.Name][id]
+			oursRaw, inOurs := ours[id]
+			theirsRaw, inTheirs := theirs[kind.Name][id]
+			sameAs := func(raw json.RawMessage, present bool, other json.RawMessage, otherPresent bool) bool {
+				return present == otherPresent && string(raw) == string(other)
+			}
+			take := ""
+			switch {
+			case sameAs(theirsRaw, inTheirs, baseRaw, inBase), sameAs(theirsRaw, inTheirs, oursRaw, inOurs):
+				continue
+			case sameAs(oursRaw, inOurs, baseRaw, inBase):
+				take = "theirs"
+			default:
+				take = resolutions[kind.Name+"/"+id]
+			}
+			switch take {
+			case "ours":
+				continue
+			case "theirs":
+				write := entityWrite{kind: kind, id: id, raw: theirsRaw, deleted: !inTheirs}
+				kindWrites = append(kindWrites, write)
+				if write.deleted {
+					delete(merged, id)
+				} else {
+					merged[id] = theirsRaw
+				}
+				continue
+			}
+			conflict := mergeConflict{
+				Entity:        kind.Name,
+				ID:            id,
+				Name:          entityDisplayName(oursRaw, id),
+				OursFields:    []string{},
+				TheirsFields:  []string{},
+				OursDeleted:   !inOurs,
+				TheirsDeleted: !inTheirs,
+				Ours:          cloneRawMessage(oursRaw),
+				Theirs:        cloneRawMessage(theirsRaw),
+			}
+			if !inOurs {
+				conflict.Name = entityDisplayName(theirsRaw, id)
+			}
+			if inOurs {
+				conflict.OursFields = changedObjectFields(baseRaw, oursRaw)
+			}
+			if inTheirs {
+				conflict.TheirsFields = changedObjectFields(baseRaw, theirsRaw)
+			}
+			response.Conflicts = append(response.Conflicts, conflict)
+		}
+		mergedItems[kind.Name] = merged
+		for _, write := range kindWrites {
+			name := entityDisplayName(write.raw, write.id)
+			if write.deleted {
+				name = entityDisplayName(ours[write.id], write.id)
+			}
+			if kind.Singleton {
+				name = kind.Name
+			}
+			response.Merged = append(response.Merged, mergedEntity{Entity: kind.Name, ID: write.id, Name: name, Deleted: write.deleted})
+		}
+		writes = append(writes, kindWrites...)
+	}
+	response.ProjectVersion = state.Doc.Version
+	if len(response.Conflicts) > 0 {
+		h.mu.Unlock()
+		return mergeBranchResponse{}, &mergeConflictError{Report: response}
+	}
+	// Changes that are fine on each side may clash together, like a branch
+	// glyph built from a component the project has since removed.
+	for _, write := range writes {
+		if write.deleted || write.kind.validate == nil {
+			continue
+		}
+		if err := write.kind.validate(write.id, write.raw, mergedItems[write.kind.Name]); err != nil {
+			h.mu.Unlock()
+			return mergeBranchResponse{}, fmt.Errorf("merged %s %q: %w", write.kind.Name, write.id, err)
+		}
+	}
+	if dryRun {
+		h.mu.Unlock()
+		return response, nil
+	}
+
+	var (
+		events      []projectEvent
+		persistCopy *projectState
+		channels    []chan projectEvent
+	)
+	if len(writes) > 0 {
+		if events, err = applyEntityWritesLocked(state, projectID, req.ClientID, writes); err != nil {
+			h.mu.Unlock()
+			return mergeBranchResponse{}, err
+		}
+		response.ProjectVersion = state.Doc.Version
+		persistCopy = cloneProjectStateForPersist(state)
+		channels = collectSubscriberChannels(state)
+	}
+	branch.Base = cloneProjectSnapshot(theirsState.Doc.projectSnapshot)
+	branch.LastMergedAt = time.Now().UTC().Format(time.RFC3339Nano)
+	err = h.saveBranchDocument(*branch)
+	h.mu.Unlock()
+	if err != nil {
+		return mergeBranchResponse{}, err
+	}
+
+	if persistCopy != nil {
+		if err := h.saveProjectStateToDisk(projectID, persistCopy); err != nil {
+			return mergeBranchResponse{}, err
+		}
+	}
+	for _, event := range events {
+		publishProjectEvent(channels, event)
+	}
+	return response, nil
+}
+
+func (s *server) handleBranches(w http.ResponseWriter, r *http.Request) {
+	s.writeCORS(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	projectID := sanitizeProjectID(r.URL.Query().Get("project"))
+	if projectID == "" {
+		projectID = "default"
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp, err := s.hub.listBranches(projectID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	case http.MethodPost:
+		defer func() {
+			_ = r.Body.Close()
+		}()
+		var req createBranchRequest
+		if err := decodeRequestBody(w, r, &req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+		resp, err := s.hub.createBranch(projectID, req)
+		if err != nil {
+			switch {
+			case errors.Is(err, os.ErrNotExist):
+				http.Error(w, "project or revision not found", http.StatusNotFound)
+			case errors.Is(err, errBranchExists), errors.Is(err, errBranchProjectExists):
+				http.Error(w, err.Error(), http.StatusConflict)
+			default:
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(resp)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleBranchMerge merges a branch back into its project. Conflicts are
+// answered with 409 and the report; ?dryRun=1 reports without writing.
+func (s *server) handleBranchMerge(w http.ResponseWriter, r *http.Request) {
+	s.writeCORS(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID := sanitizeProjectID(r.URL.Query().Get("project"))
+	if projectID == "" {
+		projectID = "default"
+	}
+
+	defer func() {
+		_ = r.Body.Close()
+	}()
+	var req mergeBranchRequest
+	if err := decodeRequestBody(w, r, &req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.hub.mergeBranch(projectID, req, isDryRun(r))
+	if err != nil {
+		var conflictErr *mergeConflictError
+		switch {
+		case errors.As(err, &conflictErr):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(conflictErr.Report)
+		case errors.Is(err, os.ErrNotExist):
+			http.Error(w, "branch not found", http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// newBranchTestHub holds a project with e, o and x, branched into b.
+func newBranchTestHub(t *testing.T) (*hub, string) {
+	t.Helper()
+	h := newHub(t.TempDir())
+	saveTestGlyphs(t, h, "p",
+		glyphDocument{ID: "1", Name: "e", Structure: "##"},
+		glyphDocument{ID: "2", Name: "o", Structure: "##"},
+		glyphDocument{ID: "3", Name: "x", Structure: "##"},
+	)
+	branch, err := h.createBranch("p", createBranchRequest{Name: "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h, branch.Project
+}
+
+func TestCreateBranchNames(t *testing.T) {
+	h, _ := newBranchTestHub(t)
+	if _, err := h.createBranch("p", createBranchRequest{Name: "b"}); !errors.Is(err, errBranchExists) {
+		t.Errorf("same branch again: err = %v", err)
+	}
+	if _, err := h.createBranch("p", createBranchRequest{Name: "b--c"}); err == nil || !strings.Contains(err.Error(), "--") {
+		t.Errorf("name with --: err = %v", err)
+	}
+	if _, ok, _ := h.getProject("p--b--c"); ok {
+		t.Error("the rejected branch was created")
+	}
+
+	// p--plain is a project of its own, not a branch of p.
+	saveTestGlyphs(t, h, "p--plain", glyphDocument{ID: "1", Name: "a", Structure: "#"})
+	if _, err := h.createBranch("p", createBranchRequest{Name: "plain"}); !errors.Is(err, errBranchProjectExists) {
+		t.Errorf("branch over a project: err = %v", err)
+	}
+	if got := projectGlyphStructures(t, h, "p--plain"); len(got) != 1 || got["1"] != "#" {
+		t.Errorf("the project was replaced by %v", got)
+	}
+	branches, err := h.listBranches("p")
+	if err != nil || len(branches.Branches) != 1 {
+		t.Errorf("branches = %+v, %v; want b only", branches, err)
+	}
+}
+
+func TestMergeBranch(t *testing.T) {
+	h, branchProject := newBranchTestHub(t)
+	// The project edits e and deletes x, the branch edits o and adds z: each
+	// side changes entities the other left alone.
+	saveTestGlyphs(t, h, "p",
+		glyphDocument{ID: "1", Name: "e", Structure: "#"},
+		glyphDocument{ID: "2", Name: "o", Structure: "##"},
+	)
+	saveTestGlyphs(t, h, branchProject,
+		glyphDocument{ID: "1", Name: "e", Structure: "##"},
+		glyphDocument{ID: "2", Name: "o", Structure: "###"},
+		glyphDocument{ID: "3", Name: "x", Structure: "##"},
+		glyphDocument{ID: "4", Name: "z", Structure: "##"},
+	)
+
+	dry, err := h.mergeBranch("p", mergeBranchRequest{Branch: "b"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dry.Merged) != 2 || projectGlyphStructures(t, h, "p")["2"] != "##" {
+		t.Fatalf("dry run merged %+v", dry.Merged)
+	}
+
+	response, err := h.mergeBranch("p", mergeBranchRequest{Branch: "b"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"1": "#", "2": "###", "4": "##"}
+	got := projectGlyphStructures(t, h, "p")
+	if len(got) != len(want) {
+		t.Fatalf("glyphs = %v, want %v", got, want)
+	}
+	for id, structure := range want {
+		if got[id] != structure {
+			t.Errorf("glyph %s = %q, want %q", id, got[id], structure)
+		}
+	}
+
+	// The base moved to the merged branch, so merging again brings nothing.
+	again, err := h.mergeBranch("p", mergeBranchRequest{Branch: "b"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Merged) != 0 || again.ProjectVersion != response.ProjectVersion {
+		t.Errorf("second merge wrote %+v at version %d", again.Merged, again.ProjectVersion)
+	}
+}
+
+func TestMergeBranchDeletes(t *testing.T) {
+	h, branchProject := newBranchTestHub(t)
+	// The branch deletes o, which the project left alone.
+	saveTestGlyphs(t, h, branchProject,
+		glyphDocument{ID: "1", Name: "e", Structure: "##"},
+		glyphDocument{ID: "3", Name: "x", Structure: "##"},
+	)
+	response, err := h.mergeBranch("p", mergeBranchRequest{Branch: "b"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Merged) != 1 || !response.Merged[0].Deleted || response.Merged[0].Name != "o" {
+		t.Fatalf("merged %+v, want o deleted", response.Merged)
+	}
+	if _, ok := projectGlyphStructures(t, h, "p")["2"]; ok {
+		t.Error("o survived the merge")
+	}
+
+	// The branch deletes x, which the project has since edited.
+	saveTestGlyphs(t, h, "p",
+		glyphDocument{ID: "1", Name: "e", Structure: "##"},
+		glyphDocument{ID: "3", Name: "x", Structure: "#"},
+	)
+	saveTestGlyphs(t, h, branchProject,
+		glyphDocument{ID: "1", Name: "e", Structure: "##"},
+	)
+	_, err = h.mergeBranch("p", mergeBranchRequest{Branch: "b"}, false)
+	var conflictErr *mergeConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("err = %v, want a conflict", err)
+	}
+	conflicts := conflictErr.Report.Conflicts
+	if len(conflicts) != 1 || conflicts[0].ID != "3" || !conflicts[0].TheirsDeleted || conflicts[0].OursDeleted {
+		t.Fatalf("conflicts = %+v, want x deleted on the branch only", conflicts)
+	}
+}
+
+func TestMergeBranchConflicts(t *testing.T) {
+	h, branchProject := newBranchTestHub(t)
+	saveTestGlyphs(t, h, "p",
+		glyphDocument{ID: "1", Name: "e", Structure: "#"},
+		glyphDocument{ID: "2", Name: "o", Structure: "##"},
+		glyphDocument{ID: "3", Name: "x", Structure: "##"},
+	)
+	saveTestGlyphs(t, h, branchProject,
+		glyphDocument{ID: "1", Name: "e", Structure: "###"},
+		glyphDocument{ID: "2", Name: "o", Structure: "#"},
+		glyphDocument{ID: "3", Name: "x", Structure: "##"},
+	)
+	before, _, err := h.getProject("p")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = h.mergeBranch("p", mergeBranchRequest{Branch: "b"}, false)
+	var conflictErr *mergeConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("err = %v, want a conflict", err)
+	}
+	conflicts := conflictErr.Report.Conflicts
+	if len(conflicts) != 1 || conflicts[0].ID != "1" || conflicts[0].Name != "e" {
+		t.Fatalf("conflicts = %+v, want e", conflicts)
+	}
+	if len(conflicts[0].OursFields) != 1 || conflicts[0].OursFields[0] != "structure" {
+		t.Errorf("ours fields = %v, want structure", conflicts[0].OursFields)
+	}
+	if after, _, _ := h.getProject("p"); after.Version != before.Version {
+		t.Errorf("a conflicting merge moved the project to version %d", after.Version)
+	}
+
+	for take, want := range map[string]int{"ours": 1, "theirs": 2} {
+		dry, err := h.mergeBranch("p", mergeBranchRequest{
+			Branch:      "b",
+			Resolutions: []mergeResolution{{Entity: glyphKind.Name, ID: "1", Take: take}},
+		}, true)
+		if err != nil {
+			t.Fatalf("take %s: %v", take, err)
+		}
+		if len(dry.Merged) != want {
+			t.Errorf("take %s merged %+v, want %d entities", take, dry.Merged, want)
+		}
+	}
+	if _, err := h.mergeBranch("p", mergeBranchRequest{
+		Branch:      "b",
+		Resolutions: []mergeResolution{{Entity: glyphKind.Name, ID: "1", Take: "theirs"}},
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	got := projectGlyphStructures(t, h, "p")
+	if got["1"] != "###" || got["2"] != "#" {
+		t.Errorf("glyphs = %v, want e and o from the branch", got)
+	}
+}
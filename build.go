@@ -0,0 +1,183 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type buildOptions struct {
+	dataDir      string
+	projectID    string
+	syntaxKey    string
+	formats      []fontFormat
+	outDir       string
+	metadataPath string
+	export       fontExportOptions
+}
+
+type builtFontFile struct {
+	Path   string
+	Syntax string
+	Glyphs int
+}
+
+func parseFontFormats(raw string) ([]fontFormat, error) {
+	formats := []fontFormat{}
+	seen := map[fontFormat]struct{}{}
+	for _, part := range strings.Split(raw, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		format, err := parseFontFormat(part)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := seen[format]; exists {
+			continue
+		}
+		seen[format] = struct{}{}
+		formats = append(formats, format)
+	}
+	if len(formats) == 0 {
+		return nil, errors.New("at least one font format is required")
+	}
+	return formats, nil
+}
+
+// buildProjectFonts compiles fonts for one syntax (or all of them) of a
+// project stored in the data dir, without going through a running server.
+func buildProjectFonts(opts buildOptions) ([]builtFontFile, error) {
+	projectID := opts.projectID
+	if !projectIDPattern.MatchString(projectID) {
+		return nil, fmt.Errorf("invalid project id %q", projectID)
+	}
+	h := newHub(opts.dataDir)
+	state, loaded, err := h.loadStateFromDisk(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if !loaded || state == nil {
+		return nil, fmt.Errorf("project %q not found in %s", projectID, opts.dataDir)
+	}
+
+	metadata := []byte(state.Doc.Metadata)
+	if opts.metadataPath != "" {
+		metadata, err = os.ReadFile(opts.metadataPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	metadata = projectFontMetadata(state.Doc, metadata)
+
+	snapshot := state.Doc.projectSnapshot
+	syntaxes := []syntaxDocument{}
+	if strings.TrimSpace(opts.syntaxKey) != "" {
+		syntax, err := findSyntaxInSnapshot(snapshot.Syntaxes, opts.syntaxKey)
+		if err != nil {
+			return nil, err
+		}
+		syntaxes = append(syntaxes, syntax)
+	} else {
+		syntaxes, err = decodeSyntaxDocuments(snapshot.Syntaxes)
+		if err != nil {
+			return nil, err
+		}
+		if len(syntaxes) == 0 {
+			return nil, fmt.Errorf("project %q has no syntaxes", projectID)
+		}
+	}
+
+	if err := os.MkdirAll(opts.outDir, 0o755); err != nil {
+		return nil, err
+	}
+	built := []builtFontFile{}
+	usedNames := map[string]struct{}{}
+	for _, syntax := range syntaxes {
+		baseName := ""
+		for _, format := range opts.formats {
+			font, err := exportProjectFont(snapshot, syntax.ID, metadata, format, opts.export)
+			if err != nil {
+				return nil, fmt.Errorf("syntax %q: %w", syntax.Name, err)
+			}
+			if baseName == "" {
+				baseName = font.Names.PostScriptName
+				if _, taken := usedNames[baseName]; taken {
+					baseName += "-" + sanitizeEntityFilenameBase(syntax.ID)
+				}
+				usedNames[baseName] = struct{}{}
+			}
+			target := filepath.Join(opts.outDir, baseName+"."+format.extension())
+			if format == fontFormatUFO {
+				err = writeUFODirectory(target, font.Files)
+			} else {
+				err = writeFileAtomic(target, font.Data)
+			}
+			if err != nil {
+				return nil, err
+			}
+			built = append(built, builtFontFile{Path: target, Syntax: syntax.Name, Glyphs: font.Glyphs})
+		}
+	}
+	return built, nil
+}
+
+func buildCommand(args []string) error {
+	flags := flag.NewFlagSet("chirone build", flag.ContinueOnError)
+	flags.Usage = printUsage
+
+	dataDir := flags.String("data-dir", "./data", "directory where project snapshots are stored")
+	projectID := flags.String("project", "default", "project to build")
+	syntaxKey := flags.String("syntax", "", "syntax id or name to build (default: all syntaxes)")
+	format := flags.String("format", "ttf", "comma-separated font formats: ttf, otf, woff, woff2, bdf, pcf, psf, psf.gz, flf, gfx, go, ufo, ufoz")
+	outDir := flags.String("out", "./fonts", "directory where font files are written")
+	metadataPath := flags.String("metadata", "", "JSON file with font metadata (default: the project metadata)")
+	scale := flags.Int("scale", defaultPixelsPerCell, "pixels per grid cell for bitmap formats")
+	goPackage := flags.String("go-package", defaultGoPackage, "package name of generated Go bitmap tables")
+	fill := flags.String("fill", string(defaultFigletFill), "character drawn for non-void cells in FIGlet fonts")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+	formats, err := parseFontFormats(*format)
+	if err != nil {
+		return err
+	}
+	pixelsPerCell, err := parsePixelsPerCell(*scale)
+	if err != nil {
+		return err
+	}
+	fillChar, err := parseFigletFill(*fill)
+	if err != nil {
+		return err
+	}
+	if !validGoPackageName(*goPackage) {
+		return fmt.Errorf("invalid Go package name %q", *goPackage)
+	}
+
+	built, err := buildProjectFonts(buildOptions{
+		dataDir:      *dataDir,
+		projectID:    *projectID,
+		syntaxKey:    *syntaxKey,
+		formats:      formats,
+		outDir:       *outDir,
+		metadataPath: *metadataPath,
+		export:       fontExportOptions{PixelsPerCell: pixelsPerCell, Fill: fillChar, GoPackage: *goPackage},
+	})
+	if err != nil {
+		return err
+	}
+	for _, file := range built {
+		fmt.Printf("%s (%s, %d glyphs)\n", file.Path, file.Syntax, file.Glyphs)
+	}
+	return nil
+}
@@ -0,0 +1,539 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Accented letters are composites of their canonical decomposition: eacute
+// is e with acutecomb placed where the _top anchor of the mark meets the top
+// anchor of e. Stacked marks attach to the mark below them when it has the
+// anchor, as in mkmk. Composites keep the advance and left sidebearing of
+// their base.
+
+// maxComposeRange bounds a single request to the Basic Multilingual Plane
+// worth of code points.
+const maxComposeRange = 0x10000
+
+type composeRequest struct {
+	ClientID string `json:"clientId"`
+	// From and To bound the code points, inclusive, written as U+00C0,
+	// 0xC0 or 00C0.
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Overwrite recomposes glyphs that already exist, keeping their id.
+	Overwrite bool `json:"overwrite"`
+}
+
+type composedGlyph struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Unicode    string   `json:"unicode"`
+	Components []string `json:"components"`
+	Replaced   bool     `json:"replaced,omitempty"`
+	Version    int64    `json:"version,omitempty"`
+}
+
+type composeIssue struct {
+	Unicode string `json:"unicode"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+type composeResponse struct {
+	Project        string          `json:"project"`
+	ProjectVersion int64           `json:"projectVersion"`
+	DryRun         bool            `json:"dryRun,omitempty"`
+	Composed       []composedGlyph `json:"composed"`
+	Skipped        []composeIssue  `json:"skipped"`
+}
+
+// parseCodepoint reads U+00E9, 0xE9 or 00E9.
+func parseCodepoint(value string) (rune, error) {
+	trimmed := strings.TrimSpace(value)
+	for _, prefix := range []string{"U+", "u+", "0x", "0X"} {
+		trimmed = strings.TrimPrefix(trimmed, prefix)
+	}
+	parsed, err := strconv.ParseUint(trimmed, 16, 32)
+	if err != nil || !isEncodableUnicode(rune(parsed)) {
+		return 0, fmt.Errorf("invalid code point %q", value)
+	}
+	return rune(parsed), nil
+}
+
+func formatCodepoint(codepoint rune) string {
+	return fmt.Sprintf("U+%04X", codepoint)
+}
+
+// composedGlyphNames names composites after the glyph list, preferring the
+// longest name of a code point (Cdotaccent over Cdot), as AGLFN does.
+// Code points without one are named by the character itself.
+func composedGlyphNames() map[rune]string {
+	names := map[rune]string{}
+	for name, codepoint := range aglfnCodepoints {
+		current, exists := names[codepoint]
+		if !exists || len(name) > len(current) || (len(name) == len(current) && name < current) {
+			names[codepoint] = name
+		}
+	}
+	return names
+}
+
+func missingCodepointGlyph(codepoint rune, names map[rune]string) string {
+	if name, ok := names[codepoint]; ok {
+		return fmt.Sprintf("no glyph for %s, such as %s", formatCodepoint(codepoint), name)
+	}
+	return fmt.Sprintf("no glyph for %s", formatCodepoint(codepoint))
+}
+
+// composeLayer is a component of a composite, with its bottom-left corner
+// in cells from the bottom-left corner of the grid.
+type composeLayer struct {
+	glyph  glyphDocument
+	x, y   int
+	width  int
+	height int
+}
+
+// planComposites builds the composites of the code points in [from, to].
+// glyphs are in id order; the first glyph mapped to a code point stands for
+// it.
+func planComposites(glyphs []glyphDocument, from, to rune, overwrite bool) ([]glyphDocument, []composedGlyph, []composeIssue) {
+	byCodepoint := map[rune]glyphDocument{}
+	for _, glyph := range glyphs {
+		if codepoint, ok := resolveUnicodeNumber(glyph.Name); ok {
+			if _, exists := byCodepoint[codepoint]; !exists {
+				byCodepoint[codepoint] = glyph
+			}
+		}
+	}
+	bodies := resolveGlyphStructures(glyphs, structureResolveOptions{})
+	newLayer := func(glyph glyphDocument) composeLayer {
+		rows := splitStructureRows(bodies[glyph.Name])
+		return composeLayer{glyph: glyph, width: structureRowsWidth(rows), height: len(rows)}
+	}
+	names := composedGlyphNames()
+
+	documents := []glyphDocument{}
+	composed := []composedGlyph{}
+	issues := []composeIssue{}
+	for codepoint := from; codepoint <= to; codepoint++ {
+		decomposed := []rune(norm.NFD.String(string(codepoint)))
+		if len(decomposed) < 2 || unicode.Is(unicode.Mn, decomposed[0]) {
+			continue
+		}
+		name, ok := names[codepoint]
+		if !ok {
+			name = string(codepoint)
+		}
+		skip := func(format string, args ...any) {
+			issues = append(issues, composeIssue{Unicode: formatCodepoint(codepoint), Name: name, Message: fmt.Sprintf(format, args...)})
+		}
+		existing, exists := byCodepoint[codepoint]
+		if exists && !overwrite {
+			skip("already in the project as %q", existing.Name)
+			continue
+		}
+		base, ok := byCodepoint[decomposed[0]]
+		if !ok {
+			skip("%s", missingCodepointGlyph(decomposed[0], names))
+			continue
+		}
+
+		layers := []composeLayer{newLayer(base)}
+		problem := ""
+		for _, markCodepoint := range decomposed[1:] {
+			mark, ok := byCodepoint[markCodepoint]
+			if !ok {
+				problem = missingCodepointGlyph(markCodepoint, names)
+				break
+			}
+			markAnchor, ok := mark.markAnchor()
+			if !ok {
+				problem = fmt.Sprintf("mark %q has no anchor starting with %s", mark.Name, markAnchorPrefix)
+				break
+			}
+			var target glyphAnchor
+			targetLayer := -1
+			for i := len(layers) - 1; i >= 0 && targetLayer < 0; i-- {
+				if anchor, ok := layers[i].glyph.anchor(markAnchor.Name); ok {
+					target, targetLayer = anchor, i
+				}
+			}
+			if targetLayer < 0 {
+				problem = fmt.Sprintf("no %s anchor on %q for %q", markAnchor.Name, base.Name, mark.Name)
+				break
+			}
+			layer := newLayer(mark)
+			layer.x = layers[targetLayer].x + int(jsRound(target.X-markAnchor.X))
+			layer.y = layers[targetLayer].y + int(jsRound(target.Y-markAnchor.Y))
+			if layer.y < 0 {
+				problem = fmt.Sprintf("%q would reach below the grid", mark.Name)
+				break
+			}
+			layers = append(layers, layer)
+		}
+		if problem != "" {
+			skip("%s", problem)
+			continue
+		}
+
+		shift := 0
+		for _, layer := range layers {
+			shift = max(shift, -layer.x)
+		}
+		width, height := 0, 0
+		for _, layer := range layers {
+			width = max(width, layer.x+shift+layer.width)
+			height = max(height, layer.y+layer.height)
+		}
+		components := make([]glyphComponentRef, 0, len(layers))
+		componentNames := make([]string, 0, len(layers))
+		for _, layer := range layers {
+			components = append(components, glyphComponentRef{
+				Name: layer.glyph.Name,
+				X:    layer.x + shift + 1,
+				Y:    height - layer.y - layer.height + 1,
+			})
+			componentNames = append(componentNames, layer.glyph.Name)
+		}
+		blank := make([]string, height)
+		for i := range blank {
+			blank[i] = strings.Repeat(" ", width)
+		}
+
+		// The first row of a composite may be a narrow accent, so the
+		// advance of the base is kept explicitly.
+		baseSpacing := base.spacing(structureColumns(bodies[base.Name]))
+		document := glyphDocument{Name: name, Set: base.Set}
+		if exists {
+			document = existing
+		}
+		document.Structure = serializeGlyphStructure(parsedGlyphStructure{Components: components, Body: strings.Join(blank, "\n")})
+		document.LeftSidebearing, document.RightSidebearing, document.Advance = nil, nil, &baseSpacing.Advance
+		if offset := baseSpacing.Offset - shift; offset != 0 {
+			document.LeftSidebearing = &offset
+		}
+		documents = append(documents, document)
+		composed = append(composed, composedGlyph{
+			ID:         document.ID,
+			Name:       name,
+			Unicode:    formatCodepoint(codepoint),
+			Components: componentNames,
+			Replaced:   exists,
+		})
+	}
+	return documents, composed, issues
+}
+
+const entityIDAlphabet = "useandom-26T198340PX75pxJACKVERYMINDBUSHWOLF_GQZbfghjklqvwyzrict"
+
+// newEntityID returns a 5 character id like the nanoid(5) of the editor,
+// unused among items.
+func newEntityID(items map[string]json.RawMessage) (string, error) {
+	limit := big.NewInt(int64(len(entityIDAlphabet)))
+	for {
+		id := make([]byte, 5)
+		for i := range id {
+			n, err := rand.Int(rand.Reader, limit)
+			if err != nil {
+				return "", err
+			}
+			id[i] = entityIDAlphabet[n.Int64()]
+		}
+		if _, taken := items[string(id)]; !taken {
+			return string(id), nil
+		}
+	}
+}
+
+// composeAttempts bounds how often composing is planned again when the
+// glyphs it read change before it is stored.
+const composeAttempts = 3
+
+var errComposeConflict = errors.New("glyphs kept changing while composing, try again")
+
+// composeWrites is a planned compose: the glyphs it writes and the
+// versions, by id, of those and of their components when planned.
+type composeWrites struct {
+	composed []composedGlyph
+	issues   []composeIssue
+	raws     map[string]json.RawMessage
+	changed  map[string]bool
+	versions map[string]int64
+}
+
+// planComposeWrites plans the composites of [from, to] over a copy of the
+// glyphs of a project. Each composite is validated on its own, against the
+// glyphs it will be stored with.
+func planComposeWrites(store *entityStore, from, to rune, overwrite bool) (composeWrites, error) {
+	glyphs := make([]glyphDocument, 0, len(store.Items))
+	idsByName := map[string]string{}
+	for _, id := range sortedEntityIDs(store.Items) {
+		glyph, err := decodeGlyphDocument(store.Items[id])
+		if err != nil {
+			return composeWrites{}, err
+		}
+		glyphs = append(glyphs, glyph)
+		if _, exists := idsByName[glyph.Name]; !exists {
+			idsByName[glyph.Name] = id
+		}
+	}
+	documents, planned, issues := planComposites(glyphs, from, to, overwrite)
+
+	writes := composeWrites{
+		composed: []composedGlyph{},
+		raws:     map[string]json.RawMessage{},
+		changed:  map[string]bool{},
+		versions: map[string]int64{},
+	}
+	items := store.Items
+	for i, document := range documents {
+		if document.ID == "" {
+			id, err := newEntityID(items)
+			if err != nil {
+				return composeWrites{}, err
+			}
+			document.ID = id
+		}
+		stored, exists := store.Items[document.ID]
+		raw, err := composedGlyphRaw(stored, document)
+		if err != nil {
+			return composeWrites{}, err
+		}
+		if err := glyphKind.validate(document.ID, raw, items); err != nil {
+			issues = append(issues, composeIssue{Unicode: planned[i].Unicode, Name: document.Name, Message: err.Error()})
+			continue
+		}
+		items[document.ID] = raw
+		writes.raws[document.ID] = raw
+		planned[i].ID = document.ID
+		planned[i].Version = store.Versions[document.ID]
+		writes.composed = append(writes.composed, planned[i])
+
+		// Recomposing a glyph that is already up to date writes nothing.
+		if exists {
+			if current, err := normalizedRawObject(stored, "glyph"); err == nil && string(current) == string(raw) {
+				continue
+			}
+		}
+		writes.changed[document.ID] = true
+		writes.versions[document.ID] = store.Versions[document.ID]
+		for _, name := range planned[i].Components {
+			id := idsByName[name]
+			writes.versions[id] = store.Versions[id]
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Unicode < issues[j].Unicode })
+	writes.issues = issues
+	return writes, nil
+}
+
+// composedGlyphRaw encodes a composite. An existing glyph keeps its stored
+// fields but for the structure and spacing composing sets, so fields the
+// server does not model survive.
+func composedGlyphRaw(stored json.RawMessage, document glyphDocument) (json.RawMessage, error) {
+	if stored == nil {
+		raw, err := json.Marshal(document)
+		if err != nil {
+			return nil, err
+		}
+		return normalizedRawObject(raw, "glyph")
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(stored, &fields); err != nil {
+		return nil, fmt.Errorf("glyph %q: %w", document.ID, err)
+	}
+	patch := map[string]any{
+		"structure":        document.Structure,
+		"advance":          document.Advance,
+		"leftSidebearing":  document.LeftSidebearing,
+		"rightSidebearing": document.RightSidebearing,
+	}
+	for key, value := range patch {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		if string(raw) == "null" {
+			delete(fields, key)
+			continue
+		}
+		fields[key] = raw
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	return normalizedRawObject(raw, "glyph")
+}
+
+// glyphStoreCopy copies the glyphs of a project and their versions, with
+// the project version.
+func (h *hub) glyphStoreCopy(projectID string) (*entityStore, int64, error) {
+	h.mu.RLock()
+	if state, ok := h.projects[projectID]; ok {
+		store, version := state.Entities[glyphKind.Name].clone(), state.Doc.Version
+		h.mu.RUnlock()
+		return store, version, nil
+	}
+	h.mu.RUnlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	state, err := h.getOrCreateProjectStateLocked(projectID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return state.Entities[glyphKind.Name].clone(), state.Doc.Version, nil
+}
+
+// composeGlyphs stores the composites of a code point range as glyph
+// upserts, one event each, in a single project version. A composite that
+// fails glyph validation is skipped. Composites are planned on a copy of
+// the glyphs; when a glyph they write or are made of changes before they
+// are stored, they are planned again.
+func (h *hub) composeGlyphs(projectID string, req composeRequest, dryRun bool) (composeResponse, error) {
+	projectID = sanitizeProjectID(projectID)
+	from, err := parseCodepoint(req.From)
+	if err != nil {
+		return composeResponse{}, err
+	}
+	to, err := parseCodepoint(req.To)
+	if err != nil {
+		return composeResponse{}, err
+	}
+	if from > to {
+		return composeResponse{}, errors.New("from must not be after to")
+	}
+	if to-from >= maxComposeRange {
+		return composeResponse{}, fmt.Errorf("range must span at most %d code points", maxComposeRange)
+	}
+
+	for attempt := 0; attempt < composeAttempts; attempt++ {
+		snapshot, projectVersion, err := h.glyphStoreCopy(projectID)
+		if err != nil {
+			return composeResponse{}, err
+		}
+		writes, err := planComposeWrites(snapshot, from, to, req.Overwrite)
+		if err != nil {
+			return composeResponse{}, err
+		}
+		response := composeResponse{Project: projectID, DryRun: dryRun, Composed: writes.composed, Skipped: writes.issues}
+		if dryRun || len(writes.changed) == 0 {
+			response.ProjectVersion = projectVersion
+			return response, nil
+		}
+
+		h.mu.Lock()
+		state, err := h.getOrCreateProjectStateLocked(projectID)
+		if err != nil {
+			h.mu.Unlock()
+			return composeResponse{}, err
+		}
+		store := state.Entities[glyphKind.Name]
+		current := true
+		for id, version := range writes.versions {
+			if store.Versions[id] != version {
+				current = false
+				break
+			}
+		}
+		if !current {
+			h.mu.Unlock()
+			continue
+		}
+
+		composed := writes.composed
+		for i, glyph := range composed {
+			if !writes.changed[glyph.ID] {
+				continue
+			}
+			composed[i].Version = max(glyph.Version, 0) + 1
+			store.Items[glyph.ID] = writes.raws[glyph.ID]
+			store.Versions[glyph.ID] = composed[i].Version
+		}
+		if err := applyProjectMutation(state, projectID); err != nil {
+			h.mu.Unlock()
+			return composeResponse{}, err
+		}
+		events := make([]projectEvent, 0, len(writes.changed))
+		for _, glyph := range composed {
+			if !writes.changed[glyph.ID] {
+				continue
+			}
+			events = append(events, projectEvent{
+				Type:            glyphKind.EventPrefix + "_upsert",
+				ClientID:        req.ClientID,
+				Entity:          glyphKind.Name,
+				EntityID:        glyph.ID,
+				EntityVersion:   glyph.Version,
+				Payload:         cloneRawMessage(writes.raws[glyph.ID]),
+				projectDocument: state.Doc,
+			})
+		}
+		persistCopy := cloneProjectStateForPersist(state)
+		channels := collectSubscriberChannels(state)
+		response.ProjectVersion = state.Doc.Version
+		h.mu.Unlock()
+
+		if err := h.saveProjectStateToDisk(projectID, persistCopy); err != nil {
+			return composeResponse{}, err
+		}
+		for _, event := range events {
+			publishProjectEvent(channels, event)
+		}
+		return response, nil
+	}
+	return composeResponse{}, errComposeConflict
+}
+
+// handleCompose generates accented composites for a code point range.
+// With ?dryRun=1 it only reports what would be written.
+func (s *server) handleCompose(w http.ResponseWriter, r *http.Request) {
+	s.writeCORS(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID := sanitizeProjectID(r.URL.Query().Get("project"))
+	if projectID == "" {
+		projectID = "default"
+	}
+
+	defer func() {
+		_ = r.Body.Close()
+	}()
+	var req composeRequest
+	if err := decodeRequestBody(w, r, &req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.hub.composeGlyphs(projectID, req, isDryRun(r))
+	if errors.Is(err, errComposeConflict) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// newComposeTestHub holds a project with e, acutecomb and a glyph stored
+// before ragged rows were rejected.
+func newComposeTestHub(t *testing.T) *hub {
+	t.Helper()
+	h := newHub(t.TempDir())
+	state, err := newEmptyProjectState("p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := state.Entities[glyphKind.Name]
+	store.Items = testGlyphMap(t,
+		glyphDocument{ID: "1", Name: "e", Structure: "###\n#\n###", Anchors: []glyphAnchor{{Name: "top", X: 1, Y: 3}}},
+		glyphDocument{ID: "2", Name: "acutecomb", Structure: "#", Anchors: []glyphAnchor{{Name: "_top", X: 0, Y: 0}}},
+		glyphDocument{ID: "3", Name: "x", Structure: "#\n##"},
+	)
+	for id := range store.Items {
+		store.Versions[id] = 1
+	}
+	h.projects["p"] = state
+	return h
+}
+
+func storedGlyphFields(t *testing.T, h *hub, id string) map[string]json.RawMessage {
+	t.Helper()
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(h.projects["p"].Entities[glyphKind.Name].Items[id], &fields); err != nil {
+		t.Fatal(err)
+	}
+	return fields
+}
+
+func TestComposeGlyphs(t *testing.T) {
+	h := newComposeTestHub(t)
+	request := composeRequest{From: "U+00E9", To: "U+00E9"}
+
+	dry, err := h.composeGlyphs("p", request, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dry.Composed) != 1 || dry.ProjectVersion != 0 || len(h.projects["p"].Entities[glyphKind.Name].Items) != 3 {
+		t.Fatalf("dry run composed %+v at version %d", dry.Composed, dry.ProjectVersion)
+	}
+
+	response, err := h.composeGlyphs("p", request, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Composed) != 1 || len(response.Skipped) != 0 {
+		t.Fatalf("composed %+v, skipped %+v", response.Composed, response.Skipped)
+	}
+	composed := response.Composed[0]
+	if composed.Name != "eacute" || composed.Version != 1 || response.ProjectVersion != 1 {
+		t.Errorf("composed %+v at project version %d", composed, response.ProjectVersion)
+	}
+	glyph, err := decodeGlyphDocument(h.projects["p"].Entities[glyphKind.Name].Items[composed.ID])
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = "---\ncomponents:\n  - name: e\n    symbol: \"\"\n    x: 1\n    y: 2\n    rotation: 0\n  - name: acutecomb\n    symbol: \"\"\n    x: 2\n    y: 1\n    rotation: 0\n---\n   \n   \n   \n   "
+	if glyph.Structure != want {
+		t.Errorf("structure = %q, want %q", glyph.Structure, want)
+	}
+	if glyph.Advance == nil || *glyph.Advance != 3 {
+		t.Errorf("advance = %v, want 3", glyph.Advance)
+	}
+
+	again, err := h.composeGlyphs("p", composeRequest{From: "U+00E9", To: "U+00E9", Overwrite: true}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ProjectVersion != 1 {
+		t.Errorf("recomposing an up to date glyph wrote version %d", again.ProjectVersion)
+	}
+}
+
+func TestComposeOverwriteKeepsUnmodeledFields(t *testing.T) {
+	h := newComposeTestHub(t)
+	store := h.projects["p"].Entities[glyphKind.Name]
+	store.Items["4"] = json.RawMessage(`{"id":"4","name":"eacute","note":"keep","rightSidebearing":1,"structure":"#"}`)
+	store.Versions["4"] = 3
+
+	response, err := h.composeGlyphs("p", composeRequest{From: "U+00E9", To: "U+00E9", Overwrite: true}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Composed) != 1 || !response.Composed[0].Replaced || response.Composed[0].Version != 4 {
+		t.Fatalf("composed %+v", response.Composed)
+	}
+	fields := storedGlyphFields(t, h, "4")
+	if string(fields["note"]) != `"keep"` {
+		t.Errorf("note = %s, want it kept", fields["note"])
+	}
+	if _, ok := fields["rightSidebearing"]; ok {
+		t.Error("rightSidebearing survived next to the composed advance")
+	}
+	if string(fields["advance"]) != "3" {
+		t.Errorf("advance = %s, want 3", fields["advance"])
+	}
+}
+
+func TestPlanComposeWritesTracksComponentVersions(t *testing.T) {
+	h := newComposeTestHub(t)
+	store, _, err := h.glyphStoreCopy("p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writes, err := planComposeWrites(store, 0xe9, 0xe9, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(writes.composed) != 1 {
+		t.Fatalf("composed %+v", writes.composed)
+	}
+	id := writes.composed[0].ID
+	want := map[string]int64{id: 0, "1": 1, "2": 1}
+	if len(writes.versions) != len(want) {
+		t.Fatalf("versions = %v, want %v", writes.versions, want)
+	}
+	for key, version := range want {
+		if got, ok := writes.versions[key]; !ok || got != version {
+			t.Errorf("versions[%q] = %d, %v; want %d", key, got, ok, version)
+		}
+	}
+	if len(h.projects["p"].Entities[glyphKind.Name].Items) != 3 {
+		t.Error("planning wrote to the project")
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sssuperio/chirone/internal/woff"
+)
+
+const maxFontUploadBytes = 20 << 20
+
+type convertedFontFile struct {
+	Path string
+	Size int
+}
+
+func parseWebFontFormats(raw string) ([]fontFormat, error) {
+	formats, err := parseFontFormats(raw)
+	if err != nil {
+		return nil, err
+	}
+	for _, format := range formats {
+		if format != fontFormatWOFF && format != fontFormatWOFF2 {
+			return nil, fmt.Errorf("unsupported web font format %q (use woff or woff2)", format)
+		}
+	}
+	return formats, nil
+}
+
+// convertFontFiles writes a web font next to each input (or into outDir)
+// for every requested format.
+func convertFontFiles(paths []string, formats []fontFormat, outDir string) ([]convertedFontFile, error) {
+	converted := []convertedFontFile{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		dir := outDir
+		if dir == "" {
+			dir = filepath.Dir(path)
+		}
+		baseName := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		for _, format := range formats {
+			encoded, err := convertFont(data, format)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			target := filepath.Join(dir, baseName+"."+string(format))
+			if err := writeFileAtomic(target, encoded); err != nil {
+				return nil, err
+			}
+			converted = append(converted, convertedFontFile{Path: target, Size: len(encoded)})
+		}
+	}
+	return converted, nil
+}
+
+func woffCommand(args []string) error {
+	flags := flag.NewFlagSet("chirone woff", flag.ContinueOnError)
+	flags.Usage = printUsage
+
+	format := flags.String("format", "woff,woff2", "comma-separated web font formats: woff, woff2")
+	outDir := flags.String("out", "", "directory where web fonts are written (default: next to each input)")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("at least one TTF or OTF file is required")
+	}
+	formats, err := parseWebFontFormats(*format)
+	if err != nil {
+		return err
+	}
+
+	converted, err := convertFontFiles(flags.Args(), formats, *outDir)
+	if err != nil {
+		return err
+	}
+	for _, file := range converted {
+		fmt.Printf("%s (%d bytes)\n", file.Path, file.Size)
+	}
+	return nil
+}
+
+// readUploadedFont accepts either a multipart form with a "font" file field
+// or the raw font bytes as the request body.
+func readUploadedFont(w http.ResponseWriter, r *http.Request) ([]byte, string, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxFontUploadBytes)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		data, err := io.ReadAll(r.Body)
+		return data, "", err
+	}
+	if err := r.ParseMultipartForm(maxFontUploadBytes); err != nil {
+		return nil, "", err
+	}
+	file, header, err := r.FormFile("font")
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	return data, header.Filename, err
+}
+
+// handleConvert packages an uploaded TTF or OTF file as WOFF or WOFF2.
+func (s *server) handleConvert(w http.ResponseWriter, r *http.Request) {
+	s.writeCORS(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, filename, err := readUploadedFont(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(data) == 0 {
+		http.Error(w, "font file is required", http.StatusBadRequest)
+		return
+	}
+	rawFormat := r.FormValue("format")
+	if strings.TrimSpace(rawFormat) == "" {
+		rawFormat = string(fontFormatWOFF2)
+	}
+	formats, err := parseWebFontFormats(rawFormat)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(formats) != 1 {
+		http.Error(w, "exactly one format is required", http.StatusBadRequest)
+		return
+	}
+	format := formats[0]
+
+	encoded, err := convertFont(data, format)
+	if err != nil {
+		if errors.Is(err, woff.ErrInvalidFont) {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	baseName := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	if baseName == "" || baseName == "." {
+		baseName = "font"
+	}
+	w.Header().Set("Content-Type", format.mimeType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": baseName + "." + string(format)}))
+	w.Header().Set("Access-Control-Expose-Headers", "Content-Disposition")
+	w.Header().Set("Content-Length", strconv.Itoa(len(encoded)))
+	_, _ = w.Write(encoded)
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"unicode"
+)
+
+// The coverage report answers "does this font do Polish?": the code points
+// the glyph names map to, as in the cmap of built fonts, counted per Unicode
+// block and checked against the exemplar letters of each language.
+
+// nearlyCompleteMissing is how many letters a language may lack and still
+// have them listed one by one.
+const nearlyCompleteMissing = 10
+
+type coverageBlock struct {
+	Name    string `json:"name"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+	Covered int    `json:"covered"`
+	// Total counts the assigned code points of the block.
+	Total int `json:"total"`
+}
+
+type coverageGlyph struct {
+	Unicode   string `json:"unicode"`
+	Character string `json:"character"`
+	// Name is the glyph list name the glyph would be drawn under.
+	Name string `json:"name"`
+}
+
+type languageCoverage struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Supported bool   `json:"supported"`
+	Covered   int    `json:"covered"`
+	Total     int    `json:"total"`
+	// Missing is listed for nearly complete languages only.
+	Missing []coverageGlyph `json:"missing,omitempty"`
+}
+
+type coverageReport struct {
+	Project    string `json:"project"`
+	Version    int64  `json:"version"`
+	Codepoints int    `json:"codepoints"`
+	// Supported lists the codes of the languages with every letter.
+	Supported []string           `json:"supported"`
+	Blocks    []coverageBlock    `json:"blocks"`
+	Languages []languageCoverage `json:"languages"`
+}
+
+// isAssignedCodepoint reports code points with a general category other
+// than unassigned or surrogate.
+func isAssignedCodepoint(codepoint rune) bool {
+	return unicode.In(codepoint, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z, unicode.Cc, unicode.Cf, unicode.Co)
+}
+
+// exemplarCodepoints returns the letters of a language in both cases, in
+// order and without duplicates.
+func (language exemplarLanguage) exemplarCodepoints() []rune {
+	seen := map[rune]struct{}{}
+	letters := []rune{}
+	add := func(r rune) {
+		if _, exists := seen[r]; !exists {
+			seen[r] = struct{}{}
+			letters = append(letters, r)
+		}
+	}
+	for _, r := range exemplarBasicLatin + language.Letters {
+		add(r)
+	}
+	for _, r := range exemplarBasicLatin + language.Letters {
+		if upper := unicode.ToUpper(r); upper != r {
+			add(upper)
+		}
+	}
+	for _, r := range language.Extra {
+		add(r)
+	}
+	return letters
+}
+
+func coverProject(projectID string, version int64, snapshot projectSnapshot) (coverageReport, error) {
+	glyphs, err := decodeGlyphDocuments(snapshot.Glyphs)
+	if err != nil {
+		return coverageReport{}, err
+	}
+	covered := map[rune]struct{}{}
+	for _, glyph := range glyphs {
+		if codepoint, ok := resolveUnicodeNumber(glyph.Name); ok && isEncodableUnicode(codepoint) {
+			covered[codepoint] = struct{}{}
+		}
+	}
+
+	report := coverageReport{
+		Project:    projectID,
+		Version:    version,
+		Codepoints: len(covered),
+		Supported:  []string{},
+		Blocks:     []coverageBlock{},
+		Languages:  []languageCoverage{},
+	}
+	codepoints := make([]rune, 0, len(covered))
+	for codepoint := range covered {
+		codepoints = append(codepoints, codepoint)
+	}
+	sort.Slice(codepoints, func(i, j int) bool { return codepoints[i] < codepoints[j] })
+	for _, block := range unicodeBlocks {
+		count := 0
+		for _, codepoint := range codepoints {
+			if codepoint >= block.Start && codepoint <= block.End {
+				count++
+			}
+		}
+		if count == 0 {
+			continue
+		}
+		total := 0
+		for codepoint := block.Start; codepoint <= block.End; codepoint++ {
+			if isAssignedCodepoint(codepoint) {
+				total++
+			}
+		}
+		report.Blocks = append(report.Blocks, coverageBlock{
+			Name:    block.Name,
+			Start:   formatCodepoint(block.Start),
+			End:     formatCodepoint(block.End),
+			Covered: count,
+			Total:   total,
+		})
+	}
+
+	names := composedGlyphNames()
+	for _, language := range exemplarLanguages {
+		letters := language.exemplarCodepoints()
+		missing := []coverageGlyph{}
+		for _, letter := range letters {
+			if _, ok := covered[letter]; ok {
+				continue
+			}
+			name, ok := names[letter]
+			if !ok {
+				name = string(letter)
+			}
+			missing = append(missing, coverageGlyph{Unicode: formatCodepoint(letter), Character: string(letter), Name: name})
+		}
+		entry := languageCoverage{
+			Code:      language.Code,
+			Name:      language.Name,
+			Supported: len(missing) == 0,
+			Covered:   len(letters) - len(missing),
+			Total:     len(letters),
+		}
+		if len(missing) <= nearlyCompleteMissing {
+			entry.Missing = missing
+		}
+		if entry.Supported {
+			report.Supported = append(report.Supported, language.Code)
+		}
+		report.Languages = append(report.Languages, entry)
+	}
+	return report, nil
+}
+
+func (s *server) handleCoverage(w http.ResponseWriter, r *http.Request) {
+	s.writeCORS(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID := sanitizeProjectID(r.URL.Query().Get("project"))
+	doc, ok, err := s.hub.getProject(projectID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Error(w, "project not found", http.StatusNotFound)
+		return
+	}
+	report, err := coverProject(doc.Project, doc.Version, doc.projectSnapshot)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestCoverProject(t *testing.T) {
+	glyphs := []glyphDocument{}
+	add := func(name string) {
+		glyphs = append(glyphs, glyphDocument{ID: strconv.Itoa(len(glyphs) + 1), Name: name, Structure: "#"})
+	}
+	for r := 'a'; r <= 'z'; r++ {
+		add(string(r))
+		add(string(r - 'a' + 'A'))
+	}
+	// Italian letters by glyph list name, without Ugrave; alternates map to
+	// no code point.
+	for _, name := range []string{"agrave", "egrave", "eacute", "igrave", "ograve", "oacute", "ugrave", "Agrave", "Egrave", "Eacute", "Igrave", "Ograve", "Oacute", "a.ss01"} {
+		add(name)
+	}
+
+	report, err := coverProject("p", 3, testSnapshot(t, glyphs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Codepoints != 65 {
+		t.Errorf("codepoints = %d, want 65", report.Codepoints)
+	}
+	wantBlocks := []coverageBlock{
+		{Name: "Basic Latin", Start: "U+0000", End: "U+007F", Covered: 52, Total: 128},
+		{Name: "Latin-1 Supplement", Start: "U+0080", End: "U+00FF", Covered: 13, Total: 128},
+	}
+	if len(report.Blocks) != len(wantBlocks) {
+		t.Fatalf("blocks = %+v, want %+v", report.Blocks, wantBlocks)
+	}
+	for i, want := range wantBlocks {
+		if report.Blocks[i] != want {
+			t.Errorf("block %d = %+v, want %+v", i, report.Blocks[i], want)
+		}
+	}
+	if len(report.Supported) != 1 || report.Supported[0] != "en" {
+		t.Errorf("supported = %v, want only en", report.Supported)
+	}
+
+	for _, language := range report.Languages {
+		switch language.Code {
+		case "it":
+			if language.Supported || language.Covered != language.Total-1 {
+				t.Errorf("Italian = %+v, want all but one letter", language)
+			}
+			want := coverageGlyph{Unicode: "U+00D9", Character: "Ù", Name: "Ugrave"}
+			if len(language.Missing) != 1 || language.Missing[0] != want {
+				t.Errorf("Italian misses %+v, want %+v", language.Missing, want)
+			}
+		case "cs":
+			// Czech lacks more than ten letters, so they are not listed.
+			if language.Supported || len(language.Missing) != 0 {
+				t.Errorf("Czech = %+v, want unsupported without the missing letters", language)
+			}
+		}
+	}
+}
@@ -0,0 +1,552 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// Every synced part of a project is an entity kind. Collections hold objects
+// keyed by their id, singletons a single object. Versioning, conflict
+// detection, persistence and events are shared, so a new synced collection
+// is a registered kind plus its projectSnapshot field.
+
+type entityKind struct {
+	// Name identifies the kind in requests, conflicts and events. Writes go
+	// to /api/<name> with the entity in the body field of the same name.
+	Name string
+	// Field is the projectSnapshot field of the kind. The split dump
+	// stores collections in the <field> directory and singletons in
+	// <field>.json.
+	Field     string
+	Singleton bool
+	// EventPrefix starts the event types: <prefix>_upsert and
+	// <prefix>_delete, or <prefix>_update for singletons.
+	EventPrefix string
+	// Label names the kind in suggested revision messages: the Italian
+	// plural of collections, the whole change note of singletons.
+	Label string
+	// Optional kinds may be missing from snapshots. Project saves and
+	// reverts without them keep the stored value.
+	Optional bool
+
+	snapshotField func(*projectSnapshot) *json.RawMessage
+	// normalize returns the stored form of a singleton.
+	normalize func(raw json.RawMessage) (json.RawMessage, error)
+	// validate checks an entity before it is stored, given the entities of
+	// its kind. Singletons get the value as sent, with an empty id.
+	validate func(id string, raw json.RawMessage, items map[string]json.RawMessage) error
+	// validateAll checks a collection written by a project save. Entities
+	// equal to the stored ones are not checked again.
+	validateAll func(items, stored map[string]json.RawMessage) error
+}
+
+var (
+	glyphKind = &entityKind{
+		Name:          "glyph",
+		Field:         "glyphs",
+		EventPrefix:   "glyph",
+		Label:         "glifi",
+		snapshotField: func(s *projectSnapshot) *json.RawMessage { return &s.Glyphs },
+		validate:      validateGlyph,
+		validateAll:   validateGlyphMap,
+	}
+	syntaxKind = &entityKind{
+		Name:          "syntax",
+		Field:         "syntaxes",
+		EventPrefix:   "syntax",
+		Label:         "sintassi",
+		snapshotField: func(s *projectSnapshot) *json.RawMessage { return &s.Syntaxes },
+		validate: func(id string, raw json.RawMessage, _ map[string]json.RawMessage) error {
+			return validateSyntax(id, raw)
+		},
+		validateAll: validateSyntaxMap,
+	}
+	metricsKind = &entityKind{
+		Name:          "metrics",
+		Field:         "metrics",
+		Singleton:     true,
+		EventPrefix:   "metrics",
+		Label:         "metriche aggiornate",
+		snapshotField: func(s *projectSnapshot) *json.RawMessage { return &s.Metrics },
+		normalize:     normalizedMetricsRaw,
+		validate: func(_ string, raw json.RawMessage, _ map[string]json.RawMessage) error {
+			return validateMetrics(raw)
+		},
+	}
+	metadataKind = &entityKind{
+		Name:          "metadata",
+		Field:         "metadata",
+		Singleton:     true,
+		EventPrefix:   "metadata",
+		Label:         "metadati aggiornati",
+		Optional:      true,
+		snapshotField: func(s *projectSnapshot) *json.RawMessage { return &s.Metadata },
+		normalize: func(raw json.RawMessage) (json.RawMessage, error) {
+			return normalizedRawObject(raw, "metadata")
+		},
+	}
+	kerningKind = &entityKind{
+		Name:          "kerning",
+		Field:         "kerning",
+		EventPrefix:   "kerning",
+		Label:         "coppie di crenatura",
+		Optional:      true,
+		snapshotField: func(s *projectSnapshot) *json.RawMessage { return &s.Kerning },
+		validate:      validateKerning,
+		validateAll:   validateKerningMap,
+	}
+)
+
+// entityKinds is the registry of synced kinds, in the order they are
+// checked, stored and dumped.
+var entityKinds = []*entityKind{glyphKind, syntaxKind, metricsKind, metadataKind, kerningKind}
+
+// entityStore holds the entities of one kind and their versions. Singletons
+// keep their object under the empty id; a version of 0 means never written.
+type entityStore struct {
+	Items    map[string]json.RawMessage
+	Versions map[string]int64
+}
+
+func (kind *entityKind) emptySnapshot() json.RawMessage {
+	if kind.Singleton {
+		return json.RawMessage(`{}`)
+	}
+	return json.RawMessage(`[]`)
+}
+
+func (kind *entityKind) newStore() *entityStore {
+	store := &entityStore{Items: map[string]json.RawMessage{}, Versions: map[string]int64{}}
+	if kind.Singleton {
+		store.Items[""] = kind.emptySnapshot()
+	}
+	return store
+}
+
+func newEntityStores() map[string]*entityStore {
+	stores := make(map[string]*entityStore, len(entityKinds))
+	for _, kind := range entityKinds {
+		stores[kind.Name] = kind.newStore()
+	}
+	return stores
+}
+
+func (store *entityStore) clone() *entityStore {
+	return &entityStore{Items: cloneRawMap(store.Items), Versions: cloneInt64Map(store.Versions)}
+}
+
+// parseSnapshot reads the entities of a kind from a stored snapshot as they
+// are, without validating them.
+func (kind *entityKind) parseSnapshot(raw json.RawMessage) (map[string]json.RawMessage, error) {
+	if len(raw) == 0 {
+		raw = kind.emptySnapshot()
+	}
+	if kind.Singleton {
+		object, err := normalizedRawObject(raw, kind.Field)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]json.RawMessage{"": object}, nil
+	}
+	return parseEntityArrayByID(raw, kind.Field)
+}
+
+// parseWrite reads the entities of a kind from a project save and checks
+// the ones that differ from stored as single writes would be, so entities
+// stored before a rule existed do not block the save.
+func (kind *entityKind) parseWrite(raw json.RawMessage, stored map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	if kind.Singleton {
+		object, err := kind.normalize(raw)
+		if err != nil {
+			return nil, err
+		}
+		if previous, ok := stored[""]; ok && string(previous) == string(object) {
+			return map[string]json.RawMessage{"": object}, nil
+		}
+		if kind.validate != nil {
+			if err := kind.validate("", raw, nil); err != nil {
+				return nil, err
+			}
+		}
+		return map[string]json.RawMessage{"": object}, nil
+	}
+	items, err := parseEntityArrayByID(raw, kind.Field)
+	if err != nil {
+		return nil, err
+	}
+	if kind.validateAll != nil {
+		if err := kind.validateAll(items, stored); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// parseRevision reads the entities of a kind from a revision, normalized
+// like writes but not validated, so any stored revision can be restored.
+func (kind *entityKind) parseRevision(raw json.RawMessage) (map[string]json.RawMessage, error) {
+	if kind.Singleton {
+		object, err := kind.normalize(raw)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]json.RawMessage{"": object}, nil
+	}
+	return parseEntityArrayByID(raw, kind.Field)
+}
+
+func (kind *entityKind) serialize(items map[string]json.RawMessage) (json.RawMessage, error) {
+	if kind.Singleton {
+		if object := items[""]; len(object) > 0 {
+			return object, nil
+		}
+		return kind.emptySnapshot(), nil
+	}
+	return serializeEntityMap(items)
+}
+
+// replace stores the entities of a kind written as a whole, by a project
+// save or a revert, bumping the versions of what changed.
+func (store *entityStore) replace(kind *entityKind, items map[string]json.RawMessage) {
+	if kind.Singleton {
+		if string(store.Items[""]) != string(items[""]) {
+			store.Versions[""] = max(store.Versions[""], 0) + 1
+		}
+	} else {
+		store.Versions = mergeVersionMap(store.Versions, items, store.Items)
+	}
+	store.Items = items
+}
+
+func (h *hub) projectEntityPath(projectID string, kind *entityKind) string {
+	if kind.Singleton {
+		return filepath.Join(h.projectDir(projectID), kind.Field+".json")
+	}
+	return filepath.Join(h.projectDir(projectID), kind.Field)
+}
+
+func (h *hub) saveEntityStore(projectID string, kind *entityKind, store *entityStore) error {
+	target := h.projectEntityPath(projectID, kind)
+	if kind.Singleton {
+		bytes, err := json.MarshalIndent(json.RawMessage(store.Items[""]), "", "  ")
+		if err != nil {
+			return err
+		}
+		return writeJSONAtomic(target, bytes)
+	}
+
+	filesByID := entityFileNamesByID(store.Items)
+	expectedFiles := make(map[string]struct{}, len(filesByID))
+	for id, raw := range store.Items {
+		bytes, err := json.MarshalIndent(raw, "", "  ")
+		if err != nil {
+			return err
+		}
+		filename := filesByID[id]
+		expectedFiles[filename] = struct{}{}
+		if err := writeJSONAtomic(filepath.Join(target, filename), bytes); err != nil {
+			return err
+		}
+	}
+	return removeStaleEntityFiles(target, expectedFiles)
+}
+
+// entityWriteRequest is the body of a write to /api/<kind>: the entity is
+// sent in the field named after the kind, deletes name the id instead.
+type entityWriteRequest struct {
+	ClientID    string          `json:"clientId"`
+	BaseVersion *int64          `json:"baseVersion,omitempty"`
+	ID          string          `json:"id,omitempty"`
+	Entity      json.RawMessage `json:"-"`
+	// DryRun validates the entity without storing it.
+	DryRun bool `json:"-"`
+}
+
+func decodeEntityWriteRequest(w http.ResponseWriter, r *http.Request, kind *entityKind) (entityWriteRequest, error) {
+	var fields map[string]json.RawMessage
+	if err := decodeRequestBody(w, r, &fields); err != nil {
+		return entityWriteRequest{}, err
+	}
+	var req entityWriteRequest
+	for key, value := range fields {
+		var err error
+		switch key {
+		case "clientId":
+			err = json.Unmarshal(value, &req.ClientID)
+		case "baseVersion":
+			err = json.Unmarshal(value, &req.BaseVersion)
+		case "id":
+			err = json.Unmarshal(value, &req.ID)
+		case kind.Name:
+			req.Entity = value
+		default:
+			err = fmt.Errorf("json: unknown field %q", key)
+		}
+		if err != nil {
+			return entityWriteRequest{}, err
+		}
+	}
+	return req, nil
+}
+
+func (h *hub) putEntity(projectID string, kind *entityKind, req entityWriteRequest) (entityUpdateResponse, error) {
+	projectID = sanitizeProjectID(projectID)
+	var (
+		id  string
+		raw json.RawMessage
+		err error
+	)
+	if kind.Singleton {
+		raw, err = kind.normalize(req.Entity)
+	} else {
+		id, raw, err = parseEntityItem(req.Entity, kind.Name)
+	}
+	if err != nil {
+		return entityUpdateResponse{}, err
+	}
+
+	var (
+		response    entityUpdateResponse
+		persistCopy *projectState
+		channels    []chan projectEvent
+		event       *projectEvent
+	)
+
+	h.mu.Lock()
+	state, err := h.getOrCreateProjectStateLocked(projectID)
+	if err != nil {
+		h.mu.Unlock()
+		return entityUpdateResponse{}, err
+	}
+	store := state.Entities[kind.Name]
+	if kind.validate != nil {
+		checked := raw
+		if kind.Singleton {
+			checked = req.Entity
+		}
+		if err := kind.validate(id, checked, store.Items); err != nil {
+			h.mu.Unlock()
+			return entityUpdateResponse{}, err
+		}
+	}
+	if req.DryRun {
+		h.mu.Unlock()
+		return entityUpdateResponse{}, nil
+	}
+
+	if req.BaseVersion == nil {
+		h.mu.Unlock()
+		return entityUpdateResponse{}, errors.New("missing baseVersion")
+	}
+
+	currentVersion := store.Versions[id]
+	current, exists := store.Items[id]
+	if *req.BaseVersion != currentVersion {
+		h.mu.Unlock()
+		return entityUpdateResponse{}, &entityConflictError{
+			ExpectedVersion: *req.BaseVersion,
+			CurrentVersion:  currentVersion,
+			ProjectVersion:  state.Doc.Version,
+			Entity:          kind.Name,
+			EntityID:        id,
+			EntityDeleted:   !exists,
+			UpdatedAt:       state.Doc.UpdatedAt,
+			Payload:         cloneRawMessage(current),
+		}
+	}
+
+	nextVersion := currentVersion
+	if !exists || string(current) != string(raw) {
+		nextVersion = max(currentVersion, 0) + 1
+		store.Items[id] = raw
+		store.Versions[id] = nextVersion
+		if err := applyProjectMutation(state, projectID); err != nil {
+			h.mu.Unlock()
+			return entityUpdateResponse{}, err
+		}
+		eventType := kind.EventPrefix + "_upsert"
+		if kind.Singleton {
+			eventType = kind.EventPrefix + "_update"
+		}
+		persistCopy = cloneProjectStateForPersist(state)
+		channels = collectSubscriberChannels(state)
+		event = &projectEvent{
+			Type:            eventType,
+			ClientID:        req.ClientID,
+			Entity:          kind.Name,
+			EntityID:        id,
+			EntityVersion:   nextVersion,
+			Payload:         cloneRawMessage(raw),
+			projectDocument: state.Doc,
+		}
+	}
+
+	response = entityUpdateResponse{
+		Project:        projectID,
+		Entity:         kind.Name,
+		EntityID:       id,
+		Version:        nextVersion,
+		ProjectVersion: state.Doc.Version,
+		UpdatedAt:      state.Doc.UpdatedAt,
+		Payload:        cloneRawMessage(raw),
+	}
+	h.mu.Unlock()
+
+	if persistCopy != nil {
+		if err := h.saveProjectStateToDisk(projectID, persistCopy); err != nil {
+			return entityUpdateResponse{}, err
+		}
+	}
+	if event != nil {
+		publishProjectEvent(channels, *event)
+	}
+
+	return response, nil
+}
+
+func (h *hub) deleteEntity(projectID string, kind *entityKind, req entityWriteRequest) (entityUpdateResponse, error) {
+	projectID = sanitizeProjectID(projectID)
+	id := strings.TrimSpace(req.ID)
+	if id == "" {
+		return entityUpdateResponse{}, errors.New("missing id")
+	}
+
+	var (
+		response    entityUpdateResponse
+		persistCopy *projectState
+		channels    []chan projectEvent
+		event       *projectEvent
+	)
+
+	h.mu.Lock()
+	state, err := h.getOrCreateProjectStateLocked(projectID)
+	if err != nil {
+		h.mu.Unlock()
+		return entityUpdateResponse{}, err
+	}
+
+	if req.BaseVersion == nil {
+		h.mu.Unlock()
+		return entityUpdateResponse{}, errors.New("missing baseVersion")
+	}
+
+	store := state.Entities[kind.Name]
+	currentVersion := store.Versions[id]
+	current, exists := store.Items[id]
+	if *req.BaseVersion != currentVersion {
+		h.mu.Unlock()
+		return entityUpdateResponse{}, &entityConflictError{
+			ExpectedVersion: *req.BaseVersion,
+			CurrentVersion:  currentVersion,
+			ProjectVersion:  state.Doc.Version,
+			Entity:          kind.Name,
+			EntityID:        id,
+			EntityDeleted:   !exists,
+			UpdatedAt:       state.Doc.UpdatedAt,
+			Payload:         cloneRawMessage(current),
+		}
+	}
+
+	if exists {
+		delete(store.Items, id)
+		delete(store.Versions, id)
+		if err := applyProjectMutation(state, projectID); err != nil {
+			h.mu.Unlock()
+			return entityUpdateResponse{}, err
+		}
+		persistCopy = cloneProjectStateForPersist(state)
+		channels = collectSubscriberChannels(state)
+		event = &projectEvent{
+			Type:            kind.EventPrefix + "_delete",
+			ClientID:        req.ClientID,
+			Entity:          kind.Name,
+			EntityID:        id,
+			EntityVersion:   currentVersion,
+			EntityDeleted:   true,
+			projectDocument: state.Doc,
+		}
+	}
+
+	response = entityUpdateResponse{
+		Project:        projectID,
+		Entity:         kind.Name,
+		EntityID:       id,
+		Version:        currentVersion,
+		ProjectVersion: state.Doc.Version,
+		Deleted:        true,
+		UpdatedAt:      state.Doc.UpdatedAt,
+	}
+	h.mu.Unlock()
+
+	if persistCopy != nil {
+		if err := h.saveProjectStateToDisk(projectID, persistCopy); err != nil {
+			return entityUpdateResponse{}, err
+		}
+	}
+	if event != nil {
+		publishProjectEvent(channels, *event)
+	}
+
+	return response, nil
+}
+
+// handleEntity serves writes of one kind: PUT stores an entity, DELETE
+// removes one from a collection.
+func (s *server) handleEntity(kind *entityKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.writeCORS(w, r)
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if r.Method != http.MethodPut && (r.Method != http.MethodDelete || kind.Singleton) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		projectID := sanitizeProjectID(r.URL.Query().Get("project"))
+		if projectID == "" {
+			projectID = "default"
+		}
+
+		defer func() {
+			_ = r.Body.Close()
+		}()
+		req, err := decodeEntityWriteRequest(w, r, kind)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+		var resp entityUpdateResponse
+		if r.Method == http.MethodDelete {
+			resp, err = s.hub.deleteEntity(projectID, kind, req)
+		} else {
+			req.DryRun = isDryRun(r)
+			resp, err = s.hub.putEntity(projectID, kind, req)
+		}
+		if err != nil {
+			var conflictErr *entityConflictError
+			if errors.As(err, &conflictErr) {
+				writeEntityConflict(w, projectID, conflictErr)
+				return
+			}
+			var validationErr *validationError
+			if errors.As(err, &validationErr) {
+				writeValidationError(w, validationErr)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.DryRun {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
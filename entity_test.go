@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestEntityWrites writes every kind twice: collections are keyed by the id
+// in the entity, singletons have none.
+func TestEntityWrites(t *testing.T) {
+	syntax := func(name string) string {
+		return `{"id": "regular", "name": "` + name + `", "grid": {"rows": 1, "columns": 1},
+			"rules": [{"symbol": " ", "shape": {"kind": "void", "props": {}}}]}`
+	}
+	tests := []struct {
+		kind   *entityKind
+		id     string
+		event  string
+		writes [2]string
+	}{
+		{glyphKind, "1", "glyph_upsert", [2]string{
+			`{"id": "1", "name": "e", "structure": "#"}`,
+			`{"id": "1", "name": "e", "structure": "##"}`,
+		}},
+		{syntaxKind, "regular", "syntax_upsert", [2]string{syntax("Regular"), syntax("Bold")}},
+		{metricsKind, "", "metrics_update", [2]string{
+			`{"UPM": 1000, "height": 5, "descender": 1}`,
+			`{"UPM": 1000, "height": 6, "descender": 1}`,
+		}},
+		{metadataKind, "", "metadata_update", [2]string{`{"familyName": "One"}`, `{"familyName": "Two"}`}},
+	}
+	for _, test := range tests {
+		t.Run(test.kind.Name, func(t *testing.T) {
+			h := newHub(t.TempDir())
+			events := make(chan projectEvent, 4)
+			if _, _, err := h.subscribe("p", events); err != nil {
+				t.Fatal(err)
+			}
+			defer h.unsubscribe("p", events)
+
+			put := func(baseVersion, body string) entityUpdateResponse {
+				t.Helper()
+				recorder := serveTestRequest(t, h, http.MethodPut, "/api/"+test.kind.Name+"?project=p",
+					`{"clientId": "c", "baseVersion": `+baseVersion+`, "`+test.kind.Name+`": `+body+`}`)
+				if recorder.Code != http.StatusOK {
+					t.Fatalf("status = %d: %s", recorder.Code, recorder.Body)
+				}
+				var response entityUpdateResponse
+				if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+					t.Fatal(err)
+				}
+				return response
+			}
+			for i, body := range test.writes {
+				response := put(strconv.Itoa(i), body)
+				version := int64(i + 1)
+				if response.Entity != test.kind.Name || response.EntityID != test.id || response.Version != version {
+					t.Errorf("write %d = %+v, want version %d", i, response, version)
+				}
+				select {
+				case event := <-events:
+					if event.Type != test.event || event.EntityID != test.id || event.EntityVersion != version || event.Version != response.ProjectVersion {
+						t.Errorf("write %d sent %+v", i, event)
+					}
+				default:
+					t.Fatalf("write %d sent no event", i)
+				}
+			}
+
+			// Writing the stored value again keeps the version and stays quiet.
+			if response := put("2", test.writes[1]); response.Version != 2 {
+				t.Errorf("rewrite moved to version %d", response.Version)
+			}
+			select {
+			case event := <-events:
+				t.Errorf("rewrite sent %+v", event)
+			default:
+			}
+		})
+	}
+}
+
+func TestEntityWriteRequests(t *testing.T) {
+	h := newHub(t.TempDir())
+	glyph := `{"id": "1", "name": "e", "structure": "#"}`
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		want   string
+	}{
+		{"unknown field", http.MethodPut, "/api/glyph?project=p", `{"baseVersion": 0, "glyph": ` + glyph + `, "force": true}`, http.StatusBadRequest, `unknown field "force"`},
+		{"field of another kind", http.MethodPut, "/api/metrics?project=p", `{"baseVersion": 0, "metadata": {}}`, http.StatusBadRequest, `unknown field "metadata"`},
+		{"missing base version", http.MethodPut, "/api/glyph?project=p", `{"glyph": ` + glyph + `}`, http.StatusBadRequest, "missing baseVersion"},
+		{"delete a singleton", http.MethodDelete, "/api/metrics?project=p", `{"baseVersion": 0}`, http.StatusMethodNotAllowed, ""},
+		{"delete the metadata", http.MethodDelete, "/api/metadata?project=p", `{"baseVersion": 0}`, http.StatusMethodNotAllowed, ""},
+		{"delete without id", http.MethodDelete, "/api/glyph?project=p", `{"baseVersion": 0}`, http.StatusBadRequest, "missing id"},
+		{"create", http.MethodPut, "/api/glyph?project=p", `{"baseVersion": 0, "glyph": ` + glyph + `}`, http.StatusOK, `"version":1`},
+		{"delete with a stale version", http.MethodDelete, "/api/glyph?project=p", `{"baseVersion": 0, "id": "1"}`, http.StatusConflict, `"version":1`},
+		{"delete", http.MethodDelete, "/api/glyph?project=p", `{"baseVersion": 1, "id": "1"}`, http.StatusOK, `"deleted":true`},
+	}
+	for _, test := range tests {
+		recorder := serveTestRequest(t, h, test.method, test.target, test.body)
+		if recorder.Code != test.status || !strings.Contains(recorder.Body.String(), test.want) {
+			t.Errorf("%s: status = %d: %s, want %d with %s", test.name, recorder.Code, recorder.Body, test.status, test.want)
+		}
+	}
+	if glyphs := projectGlyphStructures(t, h, "p"); len(glyphs) != 0 {
+		t.Errorf("glyphs = %v after the delete", glyphs)
+	}
+}
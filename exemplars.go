@@ -0,0 +1,58 @@
+package main
+
+// Main exemplar letters of Latin-script European languages, after the CLDR
+// exemplarCharacters of each locale (common/main/<code>.xml). Only the
+// lowercase letters are listed; uppercase forms are added when the sets are
+// read, and digraphs such as Dutch ij or Welsh ll are left out since they are
+// spelled with single letters. Every set includes the basic a-z, which the
+// languages use in loanwords and names even where CLDR lists some of them as
+// auxiliary.
+
+type exemplarLanguage struct {
+	Code string
+	Name string
+	// Letters beyond a-z, lowercase.
+	Letters string
+	// Extra lists letters with no lowercase counterpart in the default case
+	// mapping, such as Turkish İ.
+	Extra string
+}
+
+const exemplarBasicLatin = "abcdefghijklmnopqrstuvwxyz"
+
+var exemplarLanguages = []exemplarLanguage{
+	{Code: "sq", Name: "Albanian", Letters: "çë"},
+	{Code: "eu", Name: "Basque", Letters: "çñ"},
+	{Code: "br", Name: "Breton", Letters: "âêñùü"},
+	{Code: "ca", Name: "Catalan", Letters: "àçèéíïŀòóúü"},
+	{Code: "hr", Name: "Croatian", Letters: "ćčđšž"},
+	{Code: "cs", Name: "Czech", Letters: "áčďéěíňóřšťúůýž"},
+	{Code: "da", Name: "Danish", Letters: "åæø"},
+	{Code: "nl", Name: "Dutch", Letters: "áäéëíïóöúü"},
+	{Code: "en", Name: "English"},
+	{Code: "et", Name: "Estonian", Letters: "äõöšüž"},
+	{Code: "fo", Name: "Faroese", Letters: "áæðíóøúý"},
+	{Code: "fi", Name: "Finnish", Letters: "åäöšž"},
+	{Code: "fr", Name: "French", Letters: "àâæçéèêëîïôœùûüÿ"},
+	{Code: "gl", Name: "Galician", Letters: "áéíñóúü"},
+	{Code: "de", Name: "German", Letters: "äößü"},
+	{Code: "hu", Name: "Hungarian", Letters: "áéíóöőúüű"},
+	{Code: "is", Name: "Icelandic", Letters: "áæðéíóöþúý"},
+	{Code: "ga", Name: "Irish", Letters: "áéíóú"},
+	{Code: "it", Name: "Italian", Letters: "àèéìòóù"},
+	{Code: "lv", Name: "Latvian", Letters: "āčēģīķļņšūž"},
+	{Code: "lt", Name: "Lithuanian", Letters: "ąčęėįšųūž"},
+	{Code: "lb", Name: "Luxembourgish", Letters: "äéë"},
+	{Code: "mt", Name: "Maltese", Letters: "àċèġħìòùż"},
+	{Code: "nb", Name: "Norwegian Bokmål", Letters: "àåæéóòôø"},
+	{Code: "pl", Name: "Polish", Letters: "ąćęłńóśźż"},
+	{Code: "pt", Name: "Portuguese", Letters: "àáâãçéêíóôõú"},
+	{Code: "ro", Name: "Romanian", Letters: "ăâîșț"},
+	{Code: "gd", Name: "Scottish Gaelic", Letters: "àèìòù"},
+	{Code: "sk", Name: "Slovak", Letters: "áäčďéíĺľňóôŕšťúýž"},
+	{Code: "sl", Name: "Slovenian", Letters: "čšž"},
+	{Code: "es", Name: "Spanish", Letters: "áéíñóúü"},
+	{Code: "sv", Name: "Swedish", Letters: "àåäéö"},
+	{Code: "tr", Name: "Turkish", Letters: "çğıöşü", Extra: "İ"},
+	{Code: "cy", Name: "Welsh", Letters: "àáâäèéêëìíîïòóôöùúûüẁẃŵẅỳýŷÿ"},
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// handleExport compiles a font from the shared project state, so every
+// collaborator downloads the same build the server holds.
+func (s *server) handleExport(w http.ResponseWriter, r *http.Request) {
+	s.writeCORS(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	projectID := sanitizeProjectID(query.Get("project"))
+	syntaxKey := strings.TrimSpace(query.Get("syntax"))
+	if syntaxKey == "" {
+		http.Error(w, "syntax is required", http.StatusBadRequest)
+		return
+	}
+	rawFormat := query.Get("format")
+	if strings.TrimSpace(rawFormat) == "" {
+		rawFormat = string(fontFormatTTF)
+	}
+	format, err := parseFontFormat(rawFormat)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if format == fontFormatUFO {
+		// A directory cannot be downloaded, so UFOs always come zipped.
+		format = fontFormatUFOZ
+	}
+
+	options := fontExportOptions{}
+	if rawScale := strings.TrimSpace(query.Get("scale")); rawScale != "" {
+		scale, err := strconv.Atoi(rawScale)
+		if err == nil {
+			scale, err = parsePixelsPerCell(scale)
+		}
+		if err != nil {
+			http.Error(w, "invalid scale", http.StatusBadRequest)
+			return
+		}
+		options.PixelsPerCell = scale
+	}
+	fill, err := parseFigletFill(query.Get("fill"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	options.Fill = fill
+	if goPackage := strings.TrimSpace(query.Get("package")); goPackage != "" {
+		if !validGoPackageName(goPackage) {
+			http.Error(w, "invalid Go package name", http.StatusBadRequest)
+			return
+		}
+		options.GoPackage = goPackage
+	}
+
+	doc, ok, err := s.hub.getProject(projectID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Error(w, "project not found", http.StatusNotFound)
+		return
+	}
+
+	font, err := exportProjectFont(doc.projectSnapshot, syntaxKey, projectFontMetadata(doc, doc.Metadata), format, options)
+	if err != nil {
+		if errors.Is(err, errSyntaxNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	filename := font.Names.PostScriptName + "." + format.extension()
+	w.Header().Set("Content-Type", format.mimeType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Header().Set("Access-Control-Expose-Headers", "Content-Disposition")
+	w.Header().Set("Content-Length", strconv.Itoa(len(font.Data)))
+	_, _ = w.Write(font.Data)
+}
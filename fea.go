@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// AFDKO feature files carry the same liga and ssNN rules encodeGSUBTable
+// compiles and the kern, mark and mkmk rules of encodeGPOSTable, so fonts
+// finished in other editors keep the substitutions, the spacing and the
+// mark positions.
+
+// featureGlyphNamePattern accepts development glyph names as the feature
+// file syntax allows them without escaping.
+var featureGlyphNamePattern = regexp.MustCompile(`^[A-Za-z_.][A-Za-z0-9_.*+:^|~-]{0,62}$`)
+
+// encodeFeatureFile writes the features of a compiled font. Rules with a
+// glyph name the feature file syntax cannot express are left out, and every
+// skipped substitution is listed in a comment and returned.
+func (f *compiledFont) encodeFeatureFile() (string, []featureIssue) {
+	issues := append([]featureIssue{}, f.Features.Issues...)
+	// writable reports whether every name can be written, recording an
+	// issue for the glyph otherwise.
+	writable := func(glyph, feature string, names ...string) bool {
+		rejected := []string{}
+		for _, name := range names {
+			if !featureGlyphNamePattern.MatchString(name) && !slices.Contains(rejected, name) {
+				rejected = append(rejected, name)
+			}
+		}
+		if len(rejected) == 0 {
+			return true
+		}
+		issues = append(issues, featureIssue{
+			Glyph:   glyph,
+			Feature: feature,
+			Message: "glyph names not allowed in feature files: " + strings.Join(rejected, ", "),
+		})
+		return false
+	}
+
+	blocks := map[string][]string{}
+	for _, ligature := range f.Features.Ligatures {
+		target := f.Glyphs[ligature.Glyph].Name
+		components := make([]string, 0, len(ligature.Components))
+		for _, index := range ligature.Components {
+			components = append(components, f.Glyphs[index].Name)
+		}
+		if writable(target, "liga", append(components, target)...) {
+			blocks["liga"] = append(blocks["liga"], fmt.Sprintf("sub %s by %s;", strings.Join(components, " "), target))
+		}
+	}
+	for tag, substitutions := range f.Features.StylisticSets {
+		for _, substitution := range substitutions {
+			from, to := f.Glyphs[substitution.From].Name, f.Glyphs[substitution.To].Name
+			if writable(to, tag, from, to) {
+				blocks[tag] = append(blocks[tag], fmt.Sprintf("sub %s by %s;", from, to))
+			}
+		}
+	}
+	for _, rule := range f.Features.Kerning {
+		left, right := f.glyphNames(rule.Left), f.glyphNames(rule.Right)
+		label := featureClass(left) + " " + featureClass(right)
+		if writable(label, "kern", append(left, right...)...) {
+			blocks["kern"] = append(blocks["kern"], fmt.Sprintf("pos %s %d;", label, rule.Value))
+		}
+	}
+
+	markClasses := []string{}
+	marks := f.Features.Marks
+	for glyph := 1; glyph < len(f.Glyphs); glyph++ {
+		name := f.Glyphs[glyph].Name
+		if anchor, ok := marks.Marks[glyph]; ok && writable(name, "mark", name) {
+			markClasses = append(markClasses, fmt.Sprintf("markClass %s %s @MC_%s;", name, featureAnchor(anchor), anchor.Name))
+		}
+	}
+	for _, attachment := range []struct {
+		tag, kind string
+		bases     map[int][]fontAnchor
+	}{{"mark", "base", marks.Bases}, {"mkmk", "mark", marks.MarkBases}} {
+		for glyph := 1; glyph < len(f.Glyphs); glyph++ {
+			name := f.Glyphs[glyph].Name
+			anchors := attachment.bases[glyph]
+			if len(anchors) == 0 || !writable(name, attachment.tag, name) {
+				continue
+			}
+			rules := make([]string, 0, len(anchors))
+			for _, anchor := range anchors {
+				rules = append(rules, fmt.Sprintf("%s mark @MC_%s", featureAnchor(anchor), anchor.Name))
+			}
+			blocks[attachment.tag] = append(blocks[attachment.tag], fmt.Sprintf("pos %s %s %s;", attachment.kind, name, strings.Join(rules, " ")))
+		}
+	}
+
+	tags := make([]string, 0, len(blocks))
+	for tag := range blocks {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Feature < issues[j].Feature })
+
+	var out strings.Builder
+	out.WriteString("# Generated by Chirone from glyph names.\n")
+	for _, issue := range issues {
+		fmt.Fprintf(&out, "# Skipped %s (%s): %s\n", issue.Glyph, issue.Feature, issue.Message)
+	}
+	out.WriteString("\nlanguagesystem DFLT dflt;\n")
+	if len(markClasses) > 0 {
+		out.WriteString("\n" + strings.Join(markClasses, "\n") + "\n")
+	}
+	for _, tag := range tags {
+		fmt.Fprintf(&out, "\nfeature %s {\n", tag)
+		for _, rule := range blocks[tag] {
+			out.WriteString("    " + rule + "\n")
+		}
+		fmt.Fprintf(&out, "} %s;\n", tag)
+	}
+	return out.String(), issues
+}
+
+func (f *compiledFont) glyphNames(indexes []int) []string {
+	names := make([]string, len(indexes))
+	for i, index := range indexes {
+		names[i] = f.Glyphs[index].Name
+	}
+	return names
+}
+
+// featureClass writes a kerning side: a glyph name, or a class in brackets.
+func featureClass(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	return "[" + strings.Join(names, " ") + "]"
+}
+
+func featureAnchor(anchor fontAnchor) string {
+	return fmt.Sprintf("<anchor %d %d>", anchor.X, anchor.Y)
+}
+
+// compileProjectFeatures builds the feature file of a project. Features only
+// depend on glyph names and their order, and kerning and anchors on the
+// metrics, so no syntax is involved.
+func compileProjectFeatures(snapshot projectSnapshot, metadata json.RawMessage) (string, []featureIssue, error) {
+	glyphs, err := decodeGlyphDocuments(snapshot.Glyphs)
+	if err != nil {
+		return "", nil, err
+	}
+	kerning, err := decodeKerningDocuments(snapshot.Kerning)
+	if err != nil {
+		return "", nil, err
+	}
+	metrics := normalizeFontMetrics(snapshot.Metrics)
+	font := &compiledFont{Glyphs: []fontGlyph{{Name: ".notdef"}}}
+	for _, glyph := range orderGlyphDocuments(glyphs, normalizeFontMetadata(metadata).GlyphOrder) {
+		font.Glyphs = append(font.Glyphs, fontGlyph{Name: glyph.Name, Anchors: fontAnchors(glyph.Anchors, glyph.spacing(0).Offset, metrics)})
+	}
+	font.Features = collectFontFeatures(font.Glyphs)
+	var kerningIssues []featureIssue
+	font.Features.Kerning, kerningIssues = collectFontKerning(font.Glyphs, kerning, metrics)
+	font.Features.Issues = append(font.Features.Issues, kerningIssues...)
+	font.Features.Marks = collectFontMarks(font.Glyphs)
+	fea, issues := font.encodeFeatureFile()
+	return fea, issues, nil
+}
+
+func featuresCommand(args []string) error {
+	flags := flag.NewFlagSet("chirone features", flag.ContinueOnError)
+	flags.Usage = printUsage
+
+	dataDir := flags.String("data-dir", "./data", "directory where project snapshots are stored")
+	projectID := flags.String("project", "default", "project to read")
+	outPath := flags.String("out", "", "file where the feature file is written (default: stdout)")
+	metadataPath := flags.String("metadata", "", "JSON file with font metadata (default: the project metadata)")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+	if !projectIDPattern.MatchString(*projectID) {
+		return fmt.Errorf("invalid project id %q", *projectID)
+	}
+	state, loaded, err := newHub(*dataDir).loadStateFromDisk(*projectID)
+	if err != nil {
+		return err
+	}
+	if !loaded || state == nil {
+		return fmt.Errorf("project %q not found in %s", *projectID, *dataDir)
+	}
+	metadata := []byte(state.Doc.Metadata)
+	if *metadataPath != "" {
+		if metadata, err = os.ReadFile(*metadataPath); err != nil {
+			return err
+		}
+	}
+
+	fea, issues, err := compileProjectFeatures(state.Doc.projectSnapshot, metadata)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		fmt.Fprintf(os.Stderr, "warning: skipped %s (%s): %s\n", issue.Glyph, issue.Feature, issue.Message)
+	}
+	if *outPath == "" {
+		_, err = os.Stdout.WriteString(fea)
+		return err
+	}
+	return writeFileAtomic(*outPath, []byte(fea))
+}
+
+type featuresResponse struct {
+	Fea    string         `json:"fea"`
+	Issues []featureIssue `json:"issues"`
+}
+
+// handleFeatures serves the feature file of a project, or with format=json
+// the file together with the skipped substitutions.
+func (s *server) handleFeatures(w http.ResponseWriter, r *http.Request) {
+	s.writeCORS(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	projectID := sanitizeProjectID(query.Get("project"))
+	responseFormat := strings.TrimSpace(query.Get("format"))
+	if responseFormat != "" && responseFormat != "fea" && responseFormat != "json" {
+		http.Error(w, "format must be fea or json", http.StatusBadRequest)
+		return
+	}
+
+	doc, ok, err := s.hub.getProject(projectID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Error(w, "project not found", http.StatusNotFound)
+		return
+	}
+	fea, issues, err := compileProjectFeatures(doc.projectSnapshot, doc.Metadata)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if responseFormat == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(featuresResponse{Fea: fea, Issues: issues})
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": projectID + ".fea"}))
+	w.Header().Set("Access-Control-Expose-Headers", "Content-Disposition")
+	_, _ = w.Write([]byte(fea))
+}
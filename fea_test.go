@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFeatureFileReportsSkippedRules(t *testing.T) {
+	snapshot := testSnapshot(t,
+		glyphDocument{ID: "1", Name: "f", Structure: "#"},
+		glyphDocument{ID: "2", Name: "i", Structure: "#"},
+		glyphDocument{ID: "3", Name: "f_i", Structure: "##"},
+		glyphDocument{ID: "4", Name: "a", Structure: "#"},
+		glyphDocument{ID: "5", Name: "a.ss01", Structure: "#"},
+		// The feature file syntax has no escape for é.
+		glyphDocument{ID: "6", Name: "é", Structure: "#"},
+		glyphDocument{ID: "7", Name: "f_é", Structure: "##"},
+		glyphDocument{ID: "8", Name: "x_y", Structure: "##"},
+	)
+	fea, issues, err := compileProjectFeatures(snapshot, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []featureIssue{
+		{Glyph: "f_é", Feature: "liga", Message: "glyph names not allowed in feature files: é, f_é"},
+		{Glyph: "x_y", Feature: "liga", Missing: []string{"x", "y"}, Message: "no glyph named x, y"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("issues = %+v, want %+v", issues, want)
+	}
+	for _, issue := range want {
+		found := false
+		for _, got := range issues {
+			found = found || got.Glyph == issue.Glyph && got.Feature == issue.Feature && got.Message == issue.Message &&
+				strings.Join(got.Missing, " ") == strings.Join(issue.Missing, " ")
+		}
+		if !found {
+			t.Errorf("issues = %+v, missing %+v", issues, issue)
+		}
+	}
+
+	for _, line := range []string{
+		"# Skipped f_é (liga): glyph names not allowed in feature files: é, f_é\n",
+		"# Skipped x_y (liga): no glyph named x, y\n",
+		"feature liga {\n    sub f i by f_i;\n} liga;\n",
+		"feature ss01 {\n    sub a by a.ss01;\n} ss01;\n",
+	} {
+		if !strings.Contains(fea, line) {
+			t.Errorf("feature file has no %q:\n%s", line, fea)
+		}
+	}
+	if strings.Contains(fea, "sub f é") {
+		t.Errorf("feature file has the skipped rule:\n%s", fea)
+	}
+}
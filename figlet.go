@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// FIGlet fonts reuse the ASCII-art bodies directly: cells whose rule is void
+// become spaces and every other cell becomes the fill character. Characters
+// are laid out full width, so the banner keeps the grid spacing of the glyphs.
+
+const (
+	defaultFigletFill = '#'
+	figletEndmark     = '@'
+	maxBannerRunes    = 256
+)
+
+// figletRequiredCodepoints are the characters every .flf file lists first,
+// in this order, before any code-tagged character.
+var figletRequiredCodepoints = func() []rune {
+	codepoints := []rune{}
+	for r := rune(32); r <= 126; r++ {
+		codepoints = append(codepoints, r)
+	}
+	return append(codepoints, 196, 214, 220, 228, 246, 252, 223)
+}()
+
+type figletChar struct {
+	Name    string
+	Unicode rune
+	// Lines has one entry per row of the font, all the same width.
+	Lines []string
+}
+
+type figletFont struct {
+	Names    fontNames
+	Height   int
+	Baseline int
+	Fill     rune
+	License  string
+	Chars    []figletChar
+}
+
+func parseFigletFill(raw string) (rune, error) {
+	if raw == "" {
+		return defaultFigletFill, nil
+	}
+	runes := []rune(raw)
+	if len(runes) != 1 || !unicode.IsPrint(runes[0]) || unicode.IsSpace(runes[0]) {
+		return 0, fmt.Errorf("fill must be a single visible character, got %q", raw)
+	}
+	return runes[0], nil
+}
+
+// compileProjectFigletFont builds FIGcharacters for the encoded glyphs of a
+// snapshot, using the given syntax to tell void cells from filled ones.
+func compileProjectFigletFont(snapshot projectSnapshot, syntaxKey string, metadata json.RawMessage, fill rune) (*figletFont, error) {
+	syntax, err := findSyntaxInSnapshot(snapshot.Syntaxes, syntaxKey)
+	if err != nil {
+		return nil, err
+	}
+	glyphs, err := decodeGlyphDocuments(snapshot.Glyphs)
+	if err != nil {
+		return nil, err
+	}
+	return compileFigletFont(syntax, glyphs, normalizeFontMetrics(snapshot.Metrics), normalizeFontMetadata(metadata), fill)
+}
+
+func compileFigletFont(syntax syntaxDocument, glyphs []glyphDocument, metrics fontMetrics, metadata fontMetadata, fill rune) (*figletFont, error) {
+	if fill == 0 {
+		fill = defaultFigletFill
+	}
+	sources := map[string]glyphDocument{}
+	for _, glyph := range glyphs {
+		if _, exists := sources[glyph.Name]; !exists {
+			sources[glyph.Name] = glyph
+		}
+	}
+
+	resolved := resolveFontGlyphs(syntax, glyphs, metadata.GlyphOrder)
+	height := metrics.Height
+	for _, glyph := range resolved {
+		if glyph.HasUnicode && glyph.Body != "" {
+			height = max(height, len(splitStructureRows(glyph.Body)))
+		}
+	}
+
+	font := &figletFont{
+		Names:    newFontNames(syntax, metadata),
+		Height:   height,
+		Baseline: height - metrics.Descender,
+		Fill:     fill,
+		License:  metadata.License,
+	}
+	for _, glyph := range resolved {
+		if !glyph.HasUnicode {
+			continue
+		}
+		rows := splitStructureRows(glyph.Body)
+		// Blank glyphs such as space lose their cells when bodies are
+		// trimmed, so the width the designer drew is taken from the source.
+		source := sources[glyph.Name]
+		spacing := source.spacing(max(structureRowsWidth(rows), structureColumns(parseGlyphStructure(source.Structure).Body)))
+		lines := make([]string, height)
+		for line := range lines {
+			bodyRow := len(rows) - height + line
+			cells := make([]rune, spacing.Advance)
+			for x := range cells {
+				cells[x] = ' '
+				column := x - spacing.Offset
+				if bodyRow < 0 || column < 0 || column >= len(rows[bodyRow]) {
+					continue
+				}
+				rule, err := syntax.getRule(string(rows[bodyRow][column]))
+				if err != nil {
+					return nil, fmt.Errorf("glyph %q: %w", glyph.Name, err)
+				}
+				if rule.Shape.Kind != shapeVoid {
+					cells[x] = fill
+				}
+			}
+			lines[line] = string(cells)
+		}
+		font.Chars = append(font.Chars, figletChar{Name: glyph.Name, Unicode: glyph.Unicode, Lines: lines})
+	}
+	return font, nil
+}
+
+func (f *figletFont) charsByCodepoint() map[rune]figletChar {
+	out := make(map[rune]figletChar, len(f.Chars))
+	for _, char := range f.Chars {
+		out[char.Unicode] = char
+	}
+	return out
+}
+
+// hardblank picks a hardblank that cannot clash with the fill character.
+// Chirone never writes hardblanks, but the header has to name one.
+func (f *figletFont) hardblank() rune {
+	for _, candidate := range "$%&" {
+		if candidate != f.Fill {
+			return candidate
+		}
+	}
+	return '$'
+}
+
+func (f *figletFont) endmark() rune {
+	if f.Fill == figletEndmark {
+		return '|'
+	}
+	return figletEndmark
+}
+
+func (f *figletFont) encode() []byte {
+	chars := f.charsByCodepoint()
+	required := map[rune]struct{}{}
+	for _, codepoint := range figletRequiredCodepoints {
+		required[codepoint] = struct{}{}
+	}
+	tagged := []figletChar{}
+	maxWidth := 0
+	for _, char := range f.Chars {
+		if len(char.Lines) > 0 {
+			maxWidth = max(maxWidth, len([]rune(char.Lines[0])))
+		}
+		if _, isRequired := required[char.Unicode]; !isRequired {
+			tagged = append(tagged, char)
+		}
+	}
+
+	comments := []string{
+		f.Names.FullName,
+		"Exported from Chirone",
+	}
+	if f.License != "" {
+		comments = append(comments, strings.Split(normalizeLineEndings(f.License), "\n")...)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "flf2a%c %d %d %d -1 %d 0 0 %d\n", f.hardblank(), f.Height, f.Baseline, maxWidth+2, len(comments), len(tagged))
+	for _, comment := range comments {
+		out.WriteString(comment + "\n")
+	}
+	endmark := string(f.endmark())
+	writeChar := func(char figletChar, ok bool) {
+		for i := 0; i < f.Height; i++ {
+			line := ""
+			if ok {
+				line = char.Lines[i]
+			}
+			out.WriteString(line + endmark)
+			if i == f.Height-1 {
+				out.WriteString(endmark)
+			}
+			out.WriteByte('\n')
+		}
+	}
+	for _, codepoint := range figletRequiredCodepoints {
+		char, ok := chars[codepoint]
+		writeChar(char, ok)
+	}
+	for _, char := range tagged {
+		fmt.Fprintf(&out, "%d  %s\n", char.Unicode, char.Name)
+		writeChar(char, true)
+	}
+	return out.Bytes()
+}
+
+// render lays the text out full width, one banner per input line. Characters
+// the font lacks are skipped, as figlet does.
+func (f *figletFont) render(text string) string {
+	chars := f.charsByCodepoint()
+	blocks := []string{}
+	for _, textLine := range strings.Split(normalizeLineEndings(text), "\n") {
+		rows := make([]strings.Builder, f.Height)
+		for _, r := range textLine {
+			char, ok := chars[r]
+			if !ok {
+				continue
+			}
+			for i := range rows {
+				rows[i].WriteString(char.Lines[i])
+			}
+		}
+		lines := make([]string, len(rows))
+		for i := range rows {
+			lines[i] = strings.TrimRightFunc(rows[i].String(), unicode.IsSpace)
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	return strings.Join(blocks, "\n") + "\n"
+}
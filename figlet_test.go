@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestFigletFont(t *testing.T) {
+	snapshot := testSnapshot(t,
+		glyphDocument{ID: "1", Name: "A", Structure: "###\n# #\n###\n# #"},
+		glyphDocument{ID: "2", Name: "eacute", Structure: "#"},
+	)
+	font, err := compileProjectFigletFont(snapshot, "regular", json.RawMessage(`{"familyName": "Test"}`), '*')
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(string(font.encode()), "\n")
+	header := strings.Fields(lines[0])
+	// Signature and hardblank, height, baseline, max length, old layout,
+	// comment lines, print direction, full layout and code-tagged count.
+	want := []string{"flf2a$", "5", "4", "5", "-1", "2", "0", "0", "1"}
+	if strings.Join(header, " ") != strings.Join(want, " ") {
+		t.Fatalf("header = %q, want %q", header, want)
+	}
+	comments, _ := strconv.Atoi(header[5])
+	if lines[1] != "Test Regular" {
+		t.Errorf("first comment = %q, want the full name", lines[1])
+	}
+	// A is the 34th required character, 5 lines each.
+	first := 1 + comments + ('A'-32)*5
+	wantA := []string{"   @", "***@", "* *@", "***@", "* *@@"}
+	if got := lines[first : first+5]; strings.Join(got, "\n") != strings.Join(wantA, "\n") {
+		t.Errorf("A = %q, want %q", got, wantA)
+	}
+	tagged := 1 + comments + len(figletRequiredCodepoints)*5
+	if lines[tagged] != "233  eacute" {
+		t.Errorf("code tag = %q, want eacute at 233", lines[tagged])
+	}
+
+	if got, want := font.render("AB"), "\n***\n* *\n***\n* *\n"; got != want {
+		t.Errorf("render = %q, want %q", got, want)
+	}
+}
+
+func TestBannerUsesProjectMetadata(t *testing.T) {
+	h := newHub(t.TempDir())
+	snapshot := testSnapshot(t,
+		glyphDocument{ID: "1", Name: "-", Structure: "#"},
+		glyphDocument{ID: "2", Name: "hyphen", Structure: "##"},
+	)
+	// Both glyphs map to U+002D; the glyph order picks the one encoded.
+	snapshot.Metadata = json.RawMessage(`{"glyphOrder": "hyphen -"}`)
+	state, err := newProjectStateFromDocument(projectDocument{Project: "p", Version: 1, projectSnapshot: snapshot})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.projects["p"] = state
+
+	recorder := httptest.NewRecorder()
+	(&server{hub: h}).routes().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/banner?project=p&text=-", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", recorder.Code, recorder.Body)
+	}
+	if got, want := recorder.Body.String(), "\n\n\n\n##\n"; got != want {
+		t.Errorf("banner = %q, want %q", got, want)
+	}
+}
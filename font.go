@@ -0,0 +1,579 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/sssuperio/chirone/internal/woff"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Server-side counterpart of generateFont in src/lib/GTL/createFont.ts. The
+// compiler works in the same coordinate space as the paper.js pipeline and
+// hands integer outlines to the sfnt writers in sfnt.go.
+
+type fontFormat string
+
+const (
+	fontFormatTTF   fontFormat = "ttf"
+	fontFormatOTF   fontFormat = "otf"
+	fontFormatWOFF  fontFormat = "woff"
+	fontFormatWOFF2 fontFormat = "woff2"
+	fontFormatBDF   fontFormat = "bdf"
+	fontFormatPCF   fontFormat = "pcf"
+	fontFormatPSF   fontFormat = "psf"
+	fontFormatPSFGZ fontFormat = "psf.gz"
+	fontFormatFLF   fontFormat = "flf"
+	fontFormatGFX   fontFormat = "gfx"
+	fontFormatGo    fontFormat = "go"
+	fontFormatUFO   fontFormat = "ufo"
+	fontFormatUFOZ  fontFormat = "ufoz"
+)
+
+func parseFontFormat(raw string) (fontFormat, error) {
+	switch format := fontFormat(strings.ToLower(strings.TrimSpace(raw))); format {
+	case fontFormatTTF, fontFormatOTF, fontFormatWOFF, fontFormatWOFF2, fontFormatBDF, fontFormatPCF, fontFormatPSF, fontFormatPSFGZ, fontFormatFLF,
+		fontFormatGFX, fontFormatGo, fontFormatUFO, fontFormatUFOZ:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported font format %q", raw)
+	}
+}
+
+func (f fontFormat) mimeType() string {
+	switch f {
+	case fontFormatOTF:
+		return "font/otf"
+	case fontFormatWOFF:
+		return "font/woff"
+	case fontFormatWOFF2:
+		return "font/woff2"
+	case fontFormatBDF:
+		return "application/x-font-bdf"
+	case fontFormatPCF:
+		return "application/x-font-pcf"
+	case fontFormatPSF:
+		return "application/x-font-linux-psf"
+	case fontFormatPSFGZ:
+		return "application/gzip"
+	case fontFormatFLF:
+		return "text/plain; charset=utf-8"
+	case fontFormatGFX:
+		return "text/x-c; charset=utf-8"
+	case fontFormatGo:
+		return "text/x-go; charset=utf-8"
+	case fontFormatUFO, fontFormatUFOZ:
+		return "application/zip"
+	default:
+		return "font/ttf"
+	}
+}
+
+// extension is the file extension of the format, without the dot.
+func (f fontFormat) extension() string {
+	if f == fontFormatGFX {
+		return "h"
+	}
+	return string(f)
+}
+
+// isBitmap reports formats built by rasterising glyphs on the cell grid.
+func (f fontFormat) isBitmap() bool {
+	switch f {
+	case fontFormatBDF, fontFormatPCF, fontFormatPSF, fontFormatPSFGZ, fontFormatGFX, fontFormatGo:
+		return true
+	default:
+		return false
+	}
+}
+
+type fontGlyph struct {
+	Name       string
+	Unicode    rune
+	HasUnicode bool
+	Advance    int
+	// Contours are in font units, y up, outer contours counter-clockwise.
+	Contours []outlineContour
+	Anchors  []fontAnchor
+}
+
+type fontLigature struct {
+	Components []int
+	Glyph      int
+}
+
+type fontSingleSubstitution struct {
+	From int
+	To   int
+}
+
+type fontFeatures struct {
+	// Ligatures feed the liga feature, longest component lists first.
+	Ligatures []fontLigature
+	// StylisticSets maps ssNN tags to base -> alternate substitutions.
+	StylisticSets map[string][]fontSingleSubstitution
+	// Kerning feeds the kern feature, glyph pairs before class pairs.
+	Kerning []fontKerning
+	// Marks feeds the mark and mkmk features and the GDEF glyph classes.
+	Marks fontMarks
+	// Issues lists glyphs named like a ligature or an alternate whose
+	// substitution could not be built, and kerning rules naming missing
+	// glyphs.
+	Issues []featureIssue
+}
+
+type featureIssue struct {
+	Glyph   string   `json:"glyph"`
+	Feature string   `json:"feature"`
+	Missing []string `json:"missing,omitempty"`
+	Message string   `json:"message"`
+}
+
+type fontNames struct {
+	FamilyName      string
+	StyleName       string
+	FullName        string
+	PostScriptName  string
+	Version         string
+	Designer        string
+	DesignerURL     string
+	Manufacturer    string
+	ManufacturerURL string
+	License         string
+}
+
+type compiledFont struct {
+	Names     fontNames
+	VendorID  string
+	Created   time.Time
+	UPM       int
+	Ascender  int
+	Descender int
+	CapHeight int
+	XHeight   int
+	Glyphs    []fontGlyph
+	Features  fontFeatures
+}
+
+var (
+	postScriptStripPattern = regexp.MustCompile(`[^a-zA-Z0-9-]`)
+	glyphOrderSplitPattern = regexp.MustCompile(`[\s,]+`)
+)
+
+func toPostScriptName(value string) string {
+	var builder strings.Builder
+	for _, r := range norm.NFKD.String(value) {
+		if r < 0x20 || r > 0x7e || unicode.IsSpace(r) {
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	normalized := postScriptStripPattern.ReplaceAllString(builder.String(), "")
+	if normalized == "" {
+		return "GTL-Regular"
+	}
+	return normalized
+}
+
+func toOS2VendorID(value string) string {
+	normalized := vendorStripPattern.ReplaceAllString(strings.ToUpper(value), "")
+	if len(normalized) > 4 {
+		normalized = normalized[:4]
+	}
+	return normalized + strings.Repeat("X", 4-len(normalized))
+}
+
+// orderGlyphDocuments applies a glyphOrder list: named glyphs first, in list
+// order, followed by every remaining glyph in its original position.
+func orderGlyphDocuments(glyphs []glyphDocument, glyphOrder string) []glyphDocument {
+	tokens := []string{}
+	for _, token := range glyphOrderSplitPattern.Split(glyphOrder, -1) {
+		if token = strings.TrimSpace(token); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 {
+		return glyphs
+	}
+
+	byName := map[string]glyphDocument{}
+	for _, glyph := range glyphs {
+		if _, exists := byName[glyph.Name]; !exists {
+			byName[glyph.Name] = glyph
+		}
+	}
+	consumed := map[string]struct{}{}
+	ordered := make([]glyphDocument, 0, len(glyphs))
+	for _, name := range tokens {
+		glyph, ok := byName[name]
+		if _, done := consumed[name]; !ok || done {
+			continue
+		}
+		ordered = append(ordered, glyph)
+		consumed[name] = struct{}{}
+	}
+	for _, glyph := range glyphs {
+		if _, done := consumed[glyph.Name]; !done {
+			ordered = append(ordered, glyph)
+		}
+	}
+	return ordered
+}
+
+// syntaxPropSeed keeps choice and range props stable across builds of the
+// same syntax.
+func syntaxPropSeed(syntax syntaxDocument) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(syntax.ID))
+	hash.Write([]byte{0})
+	hash.Write([]byte(syntax.Name))
+	return int64(hash.Sum64())
+}
+
+// compileProjectFont builds the font model for one syntax of a snapshot.
+// metadata may be empty, in which case the metadata defaults apply.
+func compileProjectFont(snapshot projectSnapshot, syntaxKey string, metadata json.RawMessage) (*compiledFont, error) {
+	syntax, err := findSyntaxInSnapshot(snapshot.Syntaxes, syntaxKey)
+	if err != nil {
+		return nil, err
+	}
+	glyphs, err := decodeGlyphDocuments(snapshot.Glyphs)
+	if err != nil {
+		return nil, err
+	}
+	kerning, err := decodeKerningDocuments(snapshot.Kerning)
+	if err != nil {
+		return nil, err
+	}
+	return compileFont(syntax, glyphs, kerning, normalizeFontMetrics(snapshot.Metrics), normalizeFontMetadata(metadata))
+}
+
+// resolvedFontGlyph is a glyph in font order with its components flattened
+// into the body and the code point it claims in the cmap, if any.
+type resolvedFontGlyph struct {
+	Name       string
+	Body       string
+	Unicode    rune
+	HasUnicode bool
+	Spacing    glyphSpacing
+	Anchors    []glyphAnchor
+}
+
+// resolveFontGlyphs applies the glyph order, flattens components and assigns
+// code points; the first glyph claiming a code point keeps it.
+func resolveFontGlyphs(syntax syntaxDocument, glyphs []glyphDocument, glyphOrder string) []resolvedFontGlyph {
+	if glyphOrder == "" {
+		names := make([]string, 0, len(glyphs))
+		for _, glyph := range glyphs {
+			names = append(names, glyph.Name)
+		}
+		glyphOrder = strings.Join(names, " ")
+	}
+	ordered := orderGlyphDocuments(glyphs, glyphOrder)
+
+	renderStructures := resolveGlyphStructures(ordered, structureResolveOptions{
+		transparentSymbols:  syntax.transparentSymbols(),
+		applySymbolOverride: false,
+		rules:               syntax.Rules,
+	})
+
+	out := make([]resolvedFontGlyph, 0, len(ordered))
+	cmapCodepoints := map[rune]struct{}{}
+	for _, glyph := range ordered {
+		body, ok := renderStructures[glyph.Name]
+		if !ok {
+			body = parseGlyphStructure(glyph.Structure).Body
+		}
+		resolved := resolvedFontGlyph{Name: glyph.Name, Body: body, Spacing: glyph.spacing(structureColumns(body)), Anchors: glyph.Anchors}
+		if codepoint, ok := resolveUnicodeNumber(glyph.Name); ok && isEncodableUnicode(codepoint) {
+			if _, taken := cmapCodepoints[codepoint]; !taken {
+				cmapCodepoints[codepoint] = struct{}{}
+				resolved.Unicode = codepoint
+				resolved.HasUnicode = true
+			}
+		}
+		out = append(out, resolved)
+	}
+	return out
+}
+
+func newFontNames(syntax syntaxDocument, metadata fontMetadata) fontNames {
+	familyName := metadata.FamilyName
+	if familyName == "" {
+		familyName = "GTL"
+	}
+	styleName := strings.TrimSpace(syntax.Name)
+	if styleName == "" {
+		styleName = "Regular"
+	}
+	fullName := metadata.Name
+	if fullName == "" {
+		fullName = strings.TrimSpace(familyName + " " + styleName)
+	}
+	return fontNames{
+		FamilyName:      familyName,
+		StyleName:       styleName,
+		FullName:        fullName,
+		PostScriptName:  toPostScriptName(familyName + "-" + styleName),
+		Version:         metadata.Version,
+		Designer:        metadata.Designer,
+		DesignerURL:     metadata.DesignerURL,
+		Manufacturer:    metadata.Manufacturer,
+		ManufacturerURL: metadata.ManufacturerURL,
+		License:         metadata.License,
+	}
+}
+
+func compileFont(syntax syntaxDocument, glyphs []glyphDocument, kerning []kerningDocument, metrics fontMetrics, metadata fontMetadata) (*compiledFont, error) {
+	unit := metrics.unitsPerCell()
+	props := newPropEvaluator(syntaxPropSeed(syntax))
+	out := []fontGlyph{{Name: ".notdef", Advance: unit * 4}}
+	for _, glyph := range resolveFontGlyphs(syntax, glyphs, metadata.GlyphOrder) {
+		contours, err := drawGlyphContours(glyph.Body, syntax, float64(unit), float64(metrics.Descender), props)
+		if err != nil {
+			return nil, fmt.Errorf("glyph %q: %w", glyph.Name, err)
+		}
+		for i := range contours {
+			translateContour(&contours[i], float64(glyph.Spacing.Offset*unit), 0)
+		}
+		out = append(out, fontGlyph{
+			Name:       glyph.Name,
+			Unicode:    glyph.Unicode,
+			HasUnicode: glyph.HasUnicode,
+			Advance:    glyph.Spacing.Advance * unit,
+			Contours:   contours,
+			Anchors:    fontAnchors(glyph.Anchors, glyph.Spacing.Offset, metrics),
+		})
+	}
+
+	created, err := time.Parse("2006-01-02", metadata.CreatedDate)
+	if err != nil {
+		created = time.Now().UTC()
+	}
+	features := collectFontFeatures(out)
+	var kerningIssues []featureIssue
+	features.Kerning, kerningIssues = collectFontKerning(out, kerning, metrics)
+	features.Issues = append(features.Issues, kerningIssues...)
+	features.Marks = collectFontMarks(out)
+
+	return &compiledFont{
+		Names:     newFontNames(syntax, metadata),
+		VendorID:  toOS2VendorID(metadata.VendorID),
+		Created:   created,
+		UPM:       metrics.UPM,
+		Ascender:  metrics.cellsToUnits(float64(metrics.Ascender)),
+		Descender: -metrics.cellsToUnits(float64(metrics.Descender)),
+		CapHeight: metrics.cellsToUnits(float64(metrics.CapHeight)),
+		XHeight:   metrics.cellsToUnits(float64(metrics.XHeight)),
+		Glyphs:    out,
+		Features:  features,
+	}, nil
+}
+
+// drawGlyphContours is generateGlyph without opentype.js: every non-void cell
+// is split into the syntax grid, each sub-box is drawn and transformed around
+// the cell center, and the result is shifted down by the descender.
+func drawGlyphContours(body string, syntax syntaxDocument, unit, descender float64, props *propEvaluator) ([]outlineContour, error) {
+	contours := []outlineContour{}
+	rows, columns := syntax.Grid.Rows, syntax.Grid.Columns
+	for _, cell := range structureCells(body) {
+		rule, err := syntax.getRule(cell.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		if rule.Shape.Kind == shapeVoid || rows <= 0 || columns <= 0 {
+			continue
+		}
+		box := outlineRect{X: float64(cell.X) * unit, Y: float64(cell.Y) * unit, Width: unit, Height: unit}
+		width := box.Width / float64(columns)
+		height := box.Height / float64(rows)
+		for row := 0; row < rows; row++ {
+			for col := 0; col < columns; col++ {
+				subBox := outlineRect{
+					X:      box.X + float64(col)*width,
+					Y:      box.Y + float64(row)*height,
+					Width:  width,
+					Height: height,
+				}
+				items := drawRuleShape(subBox, rule, props)
+				transform := shapeTransform(rule.Shape, props)
+				for _, item := range items {
+					applyOutlineTransform(item, transform, box.center())
+					contours = append(contours, reorientContours(item, true)...)
+				}
+			}
+		}
+	}
+	for i := range contours {
+		translateContour(&contours[i], 0, -unit*descender)
+	}
+	return contours, nil
+}
+
+// collectFontFeatures mirrors applyOpenTypeFeatures: liga from f_f_i style
+// names and ssNN single substitutions from base.ssNN alternates.
+func collectFontFeatures(glyphs []fontGlyph) fontFeatures {
+	indexByName := map[string]int{}
+	for i, glyph := range glyphs {
+		if _, exists := indexByName[glyph.Name]; !exists && glyph.Name != "" {
+			indexByName[glyph.Name] = i
+		}
+	}
+	lookup := func(name string) (int, bool) {
+		index, ok := indexByName[name]
+		return index, ok && index > 0
+	}
+
+	features := fontFeatures{StylisticSets: map[string][]fontSingleSubstitution{}}
+	for _, glyph := range glyphs[1:] {
+		components := getLigatureComponentNames(glyph.Name)
+		if len(components) < 2 {
+			continue
+		}
+		ligature, ok := lookup(glyph.Name)
+		if !ok {
+			continue
+		}
+		indexes := make([]int, 0, len(components))
+		missing := []string{}
+		for _, component := range components {
+			index, ok := lookup(component)
+			if !ok {
+				missing = append(missing, component)
+				continue
+			}
+			indexes = append(indexes, index)
+		}
+		if len(missing) > 0 {
+			features.Issues = append(features.Issues, featureIssue{
+				Glyph:   glyph.Name,
+				Feature: "liga",
+				Missing: missing,
+				Message: "no glyph named " + strings.Join(missing, ", "),
+			})
+			continue
+		}
+		features.Ligatures = append(features.Ligatures, fontLigature{Components: indexes, Glyph: ligature})
+	}
+	sort.SliceStable(features.Ligatures, func(i, j int) bool {
+		return len(features.Ligatures[i].Components) > len(features.Ligatures[j].Components)
+	})
+
+	for _, glyph := range glyphs[1:] {
+		baseName := getAlternateBaseName(glyph.Name)
+		if baseName == "" {
+			continue
+		}
+		tag := getStylisticSetFeature(glyph.Name)
+		alternate, okAlternate := lookup(glyph.Name)
+		base, okBase := lookup(baseName)
+		if tag != "" && !okBase {
+			features.Issues = append(features.Issues, featureIssue{
+				Glyph:   glyph.Name,
+				Feature: tag,
+				Missing: []string{baseName},
+				Message: "no glyph named " + baseName,
+			})
+			continue
+		}
+		if !okAlternate || !okBase || alternate == base {
+			continue
+		}
+		if tag != "" {
+			features.StylisticSets[tag] = append(features.StylisticSets[tag], fontSingleSubstitution{From: base, To: alternate})
+		}
+	}
+	return features
+}
+
+// encode serializes the font; web formats wrap the TrueType build.
+func (f *compiledFont) encode(format fontFormat) ([]byte, error) {
+	switch format {
+	case fontFormatWOFF, fontFormatWOFF2:
+		sfnt, err := f.encodeSFNT(fontFormatTTF)
+		if err != nil {
+			return nil, err
+		}
+		return convertFont(sfnt, format)
+	default:
+		return f.encodeSFNT(format)
+	}
+}
+
+// convertFont packages an existing TTF or OTF file as a web font.
+func convertFont(sfnt []byte, format fontFormat) ([]byte, error) {
+	switch format {
+	case fontFormatWOFF:
+		return woff.Encode(sfnt)
+	case fontFormatWOFF2:
+		return woff.Encode2(sfnt)
+	default:
+		return nil, fmt.Errorf("cannot convert fonts to %s, only woff and woff2", format)
+	}
+}
+
+type fontExportOptions struct {
+	// PixelsPerCell scales bitmap formats; zero means one pixel per cell.
+	PixelsPerCell int
+	// Fill is the FIGlet character for non-void cells; zero means '#'.
+	Fill rune
+	// GoPackage names the package of Go bitmap tables; empty means "fonts".
+	GoPackage string
+}
+
+type exportedFont struct {
+	Names  fontNames
+	Glyphs int
+	Data   []byte
+	// Files is the directory tree of ufo exports; Data then holds it zipped.
+	Files []ufoFile
+}
+
+// exportProjectFont compiles one syntax of a snapshot and encodes it in the
+// requested format: vector, bitmap, FIGlet or UFO.
+func exportProjectFont(snapshot projectSnapshot, syntaxKey string, metadata json.RawMessage, format fontFormat, options fontExportOptions) (exportedFont, error) {
+	switch {
+	case format == fontFormatFLF:
+		font, err := compileProjectFigletFont(snapshot, syntaxKey, metadata, options.Fill)
+		if err != nil {
+			return exportedFont{}, err
+		}
+		return exportedFont{Names: font.Names, Glyphs: len(font.Chars), Data: font.encode()}, nil
+	case format.isBitmap():
+		font, err := compileProjectBitmapFont(snapshot, syntaxKey, metadata, options.PixelsPerCell)
+		if err != nil {
+			return exportedFont{}, err
+		}
+		data, err := font.encode(format, options)
+		return exportedFont{Names: font.Names, Glyphs: len(font.Glyphs), Data: data}, err
+	case format == fontFormatUFO || format == fontFormatUFOZ:
+		ufo, err := compileProjectUFO(snapshot, syntaxKey, metadata)
+		if err != nil {
+			return exportedFont{}, err
+		}
+		data, err := ufo.encodeZip()
+		return exportedFont{Names: ufo.Names, Glyphs: ufo.Glyphs, Data: data, Files: ufo.Files}, err
+	}
+	font, err := compileProjectFont(snapshot, syntaxKey, metadata)
+	if err != nil {
+		return exportedFont{}, err
+	}
+	data, err := font.encode(format)
+	return exportedFont{Names: font.Names, Glyphs: len(font.Glyphs), Data: data}, err
+}
+
+// projectFontMetadata dates fonts after the last project update when the
+// metadata has no createdDate, so unchanged projects compile identically.
+func projectFontMetadata(doc projectDocument, metadata json.RawMessage) json.RawMessage {
+	updatedAt, err := time.Parse(time.RFC3339Nano, doc.UpdatedAt)
+	if err != nil {
+		return metadata
+	}
+	return metadataWithCreatedDate(metadata, updatedAt.UTC().Format("2006-01-02"))
+}
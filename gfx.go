@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"unicode"
+)
+
+// Embedded display exports use the Adafruit GFX GFXfont layout: glyph bitmaps
+// are cropped to their ink, packed most significant bit first without row
+// padding, and each glyph starts on a byte boundary. The Go variant carries
+// the same tables for firmware written in Go.
+
+const (
+	gfxFirstCodepoint = 0x20
+	gfxLastCodepoint  = 0xff // GFX write() takes a uint8
+	gfxMaxBitmapBytes = 0xffff
+	defaultGoPackage  = "fonts"
+)
+
+type gfxGlyph struct {
+	Codepoint    rune
+	Name         string
+	BitmapOffset int
+	Width        int
+	Height       int
+	XAdvance     int
+	XOffset      int
+	YOffset      int
+}
+
+type gfxFont struct {
+	Identifier string
+	Bitmaps    []byte
+	// Glyphs covers every code point from First to Last; code points the
+	// font lacks get an empty entry, as GFX indexes the table directly.
+	Glyphs   []gfxGlyph
+	First    rune
+	Last     rune
+	YAdvance int
+}
+
+// gfxIdentifier turns a PostScript name into a C and Go identifier, adding
+// the pixel size like the fontconvert names (FreeSans9pt7b).
+func gfxIdentifier(postScriptName string, pixelSize int) string {
+	var builder strings.Builder
+	for _, r := range postScriptName {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			builder.WriteRune(r)
+		}
+	}
+	identifier := builder.String()
+	if identifier == "" || unicode.IsDigit(rune(identifier[0])) {
+		identifier = "Font" + identifier
+	}
+	return fmt.Sprintf("%s%dpx", identifier, pixelSize)
+}
+
+// inkBounds returns the smallest box holding every set pixel, with rows
+// counted from the top of the glyph bitmap.
+func (g bitmapGlyph) inkBounds() (minX, minRow, maxX, maxRow int, ok bool) {
+	minX, minRow = g.Width, g.Height
+	maxX, maxRow = -1, -1
+	for row := 0; row < g.Height; row++ {
+		for x := 0; x < g.Width; x++ {
+			if g.pixel(x, row) {
+				minX, maxX = min(minX, x), max(maxX, x)
+				minRow, maxRow = min(minRow, row), max(maxRow, row)
+			}
+		}
+	}
+	return minX, minRow, maxX, maxRow, maxX >= 0
+}
+
+func (f *bitmapFont) gfxLayout() (*gfxFont, error) {
+	byCodepoint := map[rune]bitmapGlyph{}
+	first, last := rune(-1), rune(-1)
+	for _, glyph := range f.Glyphs {
+		if !glyph.HasUnicode || glyph.Unicode < gfxFirstCodepoint || glyph.Unicode > gfxLastCodepoint {
+			continue
+		}
+		byCodepoint[glyph.Unicode] = glyph
+		if first < 0 || glyph.Unicode < first {
+			first = glyph.Unicode
+		}
+		last = max(last, glyph.Unicode)
+	}
+	if first < 0 {
+		return nil, fmt.Errorf("no glyph has a code point between U+%04X and U+%04X", gfxFirstCodepoint, gfxLastCodepoint)
+	}
+
+	yAdvance := f.pixelSize()
+	if _, height, _, _ := f.boundingBox(); height > yAdvance {
+		yAdvance = height
+	}
+	font := &gfxFont{
+		Identifier: gfxIdentifier(f.Names.PostScriptName, f.pixelSize()),
+		First:      first,
+		Last:       last,
+		YAdvance:   yAdvance,
+	}
+	for codepoint := first; codepoint <= last; codepoint++ {
+		entry := gfxGlyph{Codepoint: codepoint, BitmapOffset: len(font.Bitmaps)}
+		glyph, ok := byCodepoint[codepoint]
+		if !ok {
+			font.Glyphs = append(font.Glyphs, entry)
+			continue
+		}
+		entry.Name = glyph.Name
+		entry.XAdvance = glyph.Advance
+		if minX, minRow, maxX, maxRow, hasInk := glyph.inkBounds(); hasInk {
+			entry.Width = maxX - minX + 1
+			entry.Height = maxRow - minRow + 1
+			entry.XOffset = glyph.OffsetX + minX
+			entry.YOffset = -(glyph.OffsetY + glyph.Height - minRow)
+			packed := make([]byte, (entry.Width*entry.Height+7)/8)
+			bit := 0
+			for row := minRow; row <= maxRow; row++ {
+				for x := minX; x <= maxX; x++ {
+					if glyph.pixel(x, row) {
+						packed[bit/8] |= 0x80 >> (bit % 8)
+					}
+					bit++
+				}
+			}
+			font.Bitmaps = append(font.Bitmaps, packed...)
+		}
+		// xAdvance is a uint8, so a negative advance would wrap around.
+		if entry.XAdvance < 0 {
+			return nil, fmt.Errorf("glyph %q has a negative advance, which the GFX layout cannot hold", glyph.Name)
+		}
+		if entry.Width > 0xff || entry.Height > 0xff || entry.XAdvance > 0xff ||
+			entry.XOffset < -0x80 || entry.XOffset > 0x7f || entry.YOffset < -0x80 || entry.YOffset > 0x7f {
+			return nil, fmt.Errorf("glyph %q is too large for the GFX layout, lower the scale", glyph.Name)
+		}
+		font.Glyphs = append(font.Glyphs, entry)
+	}
+	if len(font.Bitmaps) > gfxMaxBitmapBytes {
+		return nil, fmt.Errorf("bitmaps take %d bytes, more than the %d GFX can address", len(font.Bitmaps), gfxMaxBitmapBytes)
+	}
+	return font, nil
+}
+
+func gfxGlyphComment(glyph gfxGlyph) string {
+	if glyph.Codepoint < 0x7f {
+		return fmt.Sprintf("0x%02X '%c'", glyph.Codepoint, glyph.Codepoint)
+	}
+	if glyph.Name != "" {
+		return fmt.Sprintf("0x%02X %s", glyph.Codepoint, glyph.Name)
+	}
+	return fmt.Sprintf("0x%02X", glyph.Codepoint)
+}
+
+func writeByteRows(out *bytes.Buffer, data []byte, indent string) {
+	for i := 0; i < len(data); i += 12 {
+		out.WriteString(indent)
+		for j, value := range data[i:min(i+12, len(data))] {
+			if j > 0 {
+				out.WriteString(" ")
+			}
+			fmt.Fprintf(out, "0x%02X,", value)
+		}
+		out.WriteString("\n")
+	}
+}
+
+// encodeC writes the header fontconvert would produce for the same bitmaps.
+func (g *gfxFont) encodeC() []byte {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// %s, exported from Chirone\n", g.Identifier)
+	out.WriteString("#pragma once\n\n#include <Adafruit_GFX.h>\n\n")
+	fmt.Fprintf(&out, "const uint8_t %sBitmaps[] PROGMEM = {\n", g.Identifier)
+	writeByteRows(&out, g.Bitmaps, "  ")
+	out.WriteString("};\n\n")
+
+	fmt.Fprintf(&out, "const GFXglyph %sGlyphs[] PROGMEM = {\n", g.Identifier)
+	for _, glyph := range g.Glyphs {
+		fmt.Fprintf(&out, "  { %5d, %3d, %3d, %3d, %4d, %4d }, // %s\n",
+			glyph.BitmapOffset, glyph.Width, glyph.Height, glyph.XAdvance, glyph.XOffset, glyph.YOffset, gfxGlyphComment(glyph))
+	}
+	out.WriteString("};\n\n")
+
+	fmt.Fprintf(&out, "const GFXfont %s PROGMEM = {\n", g.Identifier)
+	fmt.Fprintf(&out, "  (uint8_t  *)%sBitmaps,\n", g.Identifier)
+	fmt.Fprintf(&out, "  (GFXglyph *)%sGlyphs,\n", g.Identifier)
+	fmt.Fprintf(&out, "  0x%02X, 0x%02X, %d };\n\n", g.First, g.Last, g.YAdvance)
+	fmt.Fprintf(&out, "// Approx. %d bytes\n", len(g.Bitmaps)+7*len(g.Glyphs)+7)
+	return out.Bytes()
+}
+
+// encodeGo writes the same tables as a gofmt-ed Go file in package pkg.
+func (g *gfxFont) encodeGo(pkg string) ([]byte, error) {
+	var out bytes.Buffer
+	out.WriteString("// Code generated by chirone. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	fmt.Fprintf(&out, "// %s covers code points 0x%02X to 0x%02X in the Adafruit GFX layout.\n", g.Identifier, g.First, g.Last)
+	fmt.Fprintf(&out, "var %s = struct {\n", g.Identifier)
+	out.WriteString("First, Last rune\nYAdvance int\n")
+	out.WriteString("// Bitmaps packs each glyph most significant bit first, without row padding.\n")
+	out.WriteString("Bitmaps []byte\n")
+	out.WriteString("Glyphs []struct {\nBitmapOffset uint16\nWidth, Height, XAdvance uint8\nXOffset, YOffset int8\n}\n")
+	out.WriteString("}{\n")
+	fmt.Fprintf(&out, "First: 0x%02X,\nLast: 0x%02X,\nYAdvance: %d,\n", g.First, g.Last, g.YAdvance)
+	out.WriteString("Bitmaps: []byte{\n")
+	writeByteRows(&out, g.Bitmaps, "")
+	out.WriteString("},\n")
+	out.WriteString("Glyphs: []struct {\nBitmapOffset uint16\nWidth, Height, XAdvance uint8\nXOffset, YOffset int8\n}{\n")
+	for _, glyph := range g.Glyphs {
+		fmt.Fprintf(&out, "{%d, %d, %d, %d, %d, %d}, // %s\n",
+			glyph.BitmapOffset, glyph.Width, glyph.Height, glyph.XAdvance, glyph.XOffset, glyph.YOffset, gfxGlyphComment(glyph))
+	}
+	out.WriteString("},\n}\n")
+	return format.Source(out.Bytes())
+}
+
+func validGoPackageName(name string) bool {
+	if name == "" || name == "_" {
+		return false
+	}
+	for i, r := range name {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || r == '_' || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return true
+}
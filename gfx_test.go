@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+func TestGFXLayout(t *testing.T) {
+	layout, err := testBitmapFont(t).gfxLayout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if layout.First != '.' || layout.Last != 'A' || len(layout.Glyphs) != 'A'-'.'+1 {
+		t.Fatalf("layout covers %q to %q with %d glyphs", layout.First, layout.Last, len(layout.Glyphs))
+	}
+	// Bodies sit on the descender, one row below the baseline. The period
+	// is one pixel, A is 3x4 packed without row padding:
+	// ### # # ### # # is 1111 0111 1101 0000.
+	if want := []byte{0x80, 0xf7, 0xd0}; !bytes.Equal(layout.Bitmaps, want) {
+		t.Errorf("bitmaps = %x, want %x", layout.Bitmaps, want)
+	}
+	want := map[rune]gfxGlyph{
+		'.': {Codepoint: '.', Name: ".", BitmapOffset: 0, Width: 1, Height: 1, XAdvance: 1, XOffset: 0, YOffset: 0},
+		// Code points the font lacks are empty entries at the next offset.
+		'/': {Codepoint: '/', BitmapOffset: 1},
+		'@': {Codepoint: '@', BitmapOffset: 1},
+		'A': {Codepoint: 'A', Name: "A", BitmapOffset: 1, Width: 3, Height: 4, XAdvance: 3, XOffset: 0, YOffset: -3},
+	}
+	for codepoint, entry := range want {
+		if got := layout.Glyphs[codepoint-layout.First]; got != entry {
+			t.Errorf("entry of %q = %+v, want %+v", codepoint, got, entry)
+		}
+	}
+
+	header := string(layout.encodeC())
+	for _, line := range []string{
+		"const uint8_t TestRegular5pxBitmaps[] PROGMEM = {\n  0x80, 0xF7, 0xD0,\n};",
+		"  {     1,   3,   4,   3,    0,   -3 }, // 0x41 'A'\n",
+		"  0x2E, 0x41, 5 };",
+	} {
+		if !strings.Contains(header, line) {
+			t.Errorf("C header has no %q:\n%s", line, header)
+		}
+	}
+}
+
+// TestGFXGoSourceCompiles type-checks the Go tables, which also catches
+// values that overflow their fields.
+func TestGFXGoSourceCompiles(t *testing.T) {
+	layout, err := testBitmapFont(t).gfxLayout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := layout.encodeGo("fonts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "font.go", source, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&types.Config{}).Check("fonts", fset, []*ast.File{file}, nil); err != nil {
+		t.Errorf("generated Go does not compile: %v\n%s", err, source)
+	}
+}
+
+func TestGFXLayoutRejectsOverflow(t *testing.T) {
+	snapshot := testSnapshot(t, glyphDocument{ID: "1", Name: "A", Structure: "#####"})
+	// Five cells of 64 pixels are wider than the uint8 width.
+	font, err := compileProjectBitmapFont(snapshot, "regular", json.RawMessage(`{"familyName": "Test"}`), 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := font.gfxLayout(); err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Errorf("320 pixel glyph: err = %v", err)
+	}
+
+	font = testBitmapFont(t)
+	for i := range font.Glyphs {
+		font.Glyphs[i].Advance = -1
+	}
+	if _, err := font.gfxLayout(); err == nil || !strings.Contains(err.Error(), "negative advance") {
+		t.Errorf("negative advance: err = %v", err)
+	}
+}
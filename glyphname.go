@@ -0,0 +1,67 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var stylisticSetSuffixPattern = regexp.MustCompile(`(?i)\.(ss\d\d)$`)
+
+// resolveUnicodeNumber mirrors the client helper in glyphName.ts: AGLFN names
+// win, otherwise a name of one UTF-16 code unit, as name.length === 1 counts
+// it, maps to its own codepoint. Characters beyond the BMP take two units in
+// JavaScript, so their glyphs stay unmapped on both sides.
+func resolveUnicodeNumber(name string) (rune, bool) {
+	if codepoint, ok := aglfnCodepoints[name]; ok {
+		return codepoint, true
+	}
+	codepoint, size := utf8.DecodeRuneInString(name)
+	if size == 0 || size != len(name) || (codepoint == utf8.RuneError && size == 1) || codepoint > 0xffff {
+		return 0, false
+	}
+	return codepoint, true
+}
+
+func isEncodableUnicode(value rune) bool {
+	if value < 0 || value > 0x10ffff {
+		return false
+	}
+	// Surrogate code points are not valid Unicode scalar values.
+	return value < 0xd800 || value > 0xdfff
+}
+
+func getLigatureComponentNames(name string) []string {
+	if !strings.Contains(name, "_") {
+		return nil
+	}
+	parts := strings.Split(name, "_")
+	if len(parts) < 2 {
+		return nil
+	}
+	components := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil
+		}
+		components = append(components, part)
+	}
+	return components
+}
+
+func getAlternateBaseName(name string) string {
+	dotIndex := strings.Index(name, ".")
+	if dotIndex <= 0 {
+		return ""
+	}
+	return name[:dotIndex]
+}
+
+func getStylisticSetFeature(name string) string {
+	match := stylisticSetSuffixPattern.FindStringSubmatch(name)
+	if match == nil {
+		return ""
+	}
+	return strings.ToLower(match[1])
+}
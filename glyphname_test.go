@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestResolveUnicodeNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		want rune
+		ok   bool
+	}{
+		{"A", 'A', true},
+		{"é", 'é', true},
+		{"eacute", 0x00E9, true},
+		{"acutecomb", 0x0301, true},
+		{"space", 0x0020, true},
+		{"Cdotaccent", 0x010A, true},
+		// Only AGLFN names and single characters resolve, as in the client.
+		{"uni00E9", 0, false},
+		{"u1F600", 0, false},
+		// One UTF-16 code unit, as name.length === 1 in the client.
+		{"\uffff", 0xFFFF, true},
+		{"\U0001F600", 0, false},
+		{"\xff", 0, false},
+		{"A.ss01", 0, false},
+		{"f_i", 0, false},
+		{"", 0, false},
+	}
+	for _, test := range tests {
+		got, ok := resolveUnicodeNumber(test.name)
+		if got != test.want || ok != test.ok {
+			t.Errorf("resolveUnicodeNumber(%q) = %U, %v; want %U, %v", test.name, got, ok, test.want, test.ok)
+		}
+	}
+}
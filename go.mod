@@ -1,3 +1,9 @@
 module github.com/sssuperio/chirone
 
 go 1.22
+
+require (
+	github.com/andybalholm/brotli v1.2.0
+	golang.org/x/image v0.18.0
+	golang.org/x/text v0.21.0
+)
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// The history of an entity is read from the revisions, oldest first, and the
+// current project: every time the entity differs from the one before, it is
+// listed with the revision that first holds it. The server keeps no log of
+// the writes in between, so edits undone before a revision leave no trace.
+// Glyphs also get a blame of their structure, unless ?blame=0 skips it.
+
+type historyEntry struct {
+	// Revision is the revision id, or "current" for the live working copy
+	// of the project, as diffs name it. That entry is listed last, when the
+	// working copy differs from the latest revision, and can still change.
+	Revision  string          `json:"revision"`
+	Version   int64           `json:"version"`
+	CreatedAt string          `json:"createdAt"`
+	Message   string          `json:"message,omitempty"`
+	ClientID  string          `json:"clientId,omitempty"`
+	Deleted   bool            `json:"deleted,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+}
+
+type historyResponse struct {
+	Project string         `json:"project"`
+	Entity  string         `json:"entity"`
+	ID      string         `json:"id"`
+	Entries []historyEntry `json:"entries"`
+	// Blame covers the latest glyph structure, which may be the working
+	// copy; it is missing when skipped, for other kinds and for deleted
+	// glyphs.
+	Blame *glyphBlame `json:"blame,omitempty"`
+}
+
+// glyphBlame points each body row and component of a glyph at the entry
+// since which it has been as it is now. Rows are listed top to bottom but
+// followed from the bottom, where bodies are anchored.
+type glyphBlame struct {
+	Rows       []blameRow       `json:"rows"`
+	Components []blameComponent `json:"components"`
+}
+
+type blameRow struct {
+	Text string `json:"text"`
+	// Entry indexes the history entries.
+	Entry    int    `json:"entry"`
+	Revision string `json:"revision"`
+}
+
+type blameComponent struct {
+	Component glyphComponentRef `json:"component"`
+	Entry     int               `json:"entry"`
+	Revision  string            `json:"revision"`
+}
+
+func entityKindByName(name string) (*entityKind, bool) {
+	for _, kind := range entityKinds {
+		if kind.Name == name {
+			return kind, true
+		}
+	}
+	return nil, false
+}
+
+func (h *hub) entityHistory(projectID string, kind *entityKind, id string, blame bool) (historyResponse, error) {
+	projectID = sanitizeProjectID(projectID)
+	if kind.Singleton {
+		id = ""
+	}
+
+	doc, ok, err := h.getProject(projectID)
+	if err != nil {
+		return historyResponse{}, err
+	}
+	if !ok {
+		return historyResponse{}, os.ErrNotExist
+	}
+	revisions, err := h.listRevisionDocuments(projectID)
+	if err != nil {
+		return historyResponse{}, err
+	}
+
+	response := historyResponse{Project: projectID, Entity: kind.Name, ID: id, Entries: []historyEntry{}}
+	var previous json.RawMessage
+	record := func(entry historyEntry, snapshot projectSnapshot, parse func(json.RawMessage) (map[string]json.RawMessage, error)) error {
+		raw := *kind.snapshotField(&snapshot)
+		if kind.Optional && len(raw) == 0 {
+			return nil
+		}
+		items, err := parse(raw)
+		if err != nil {
+			return err
+		}
+		current, exists := items[id]
+		switch {
+		case exists && string(current) == string(previous):
+			return nil
+		case !exists && previous == nil:
+			return nil
+		}
+		entry.Deleted = !exists
+		entry.Payload = current
+		response.Entries = append(response.Entries, entry)
+		previous = current
+		return nil
+	}
+	for i := len(revisions) - 1; i >= 0; i-- {
+		revision := revisions[i]
+		entry := historyEntry{Revision: revision.ID, Version: revision.Version, CreatedAt: revision.CreatedAt, Message: revision.Message, ClientID: revision.ClientID}
+		if err := record(entry, revision.projectSnapshot, kind.parseRevision); err != nil {
+			return historyResponse{}, err
+		}
+	}
+	current := historyEntry{Revision: currentRevisionID, Version: doc.Version, CreatedAt: doc.UpdatedAt}
+	if err := record(current, doc.projectSnapshot, kind.parseSnapshot); err != nil {
+		return historyResponse{}, err
+	}
+	if len(response.Entries) == 0 {
+		return historyResponse{}, fmt.Errorf("%s %q: %w", kind.Name, id, os.ErrNotExist)
+	}
+	if blame && kind == glyphKind {
+		response.Blame = blameGlyph(response.Entries)
+	}
+	return response, nil
+}
+
+// blameGlyph walks the entries back from the latest, so a row or component
+// is blamed on the oldest entry of the unbroken run that ends with it.
+func blameGlyph(entries []historyEntry) *glyphBlame {
+	structures := make([]*parsedGlyphStructure, len(entries))
+	for i, entry := range entries {
+		if entry.Deleted {
+			continue
+		}
+		glyph, err := decodeGlyphDocument(entry.Payload)
+		if err != nil {
+			continue
+		}
+		parsed := parseGlyphStructure(glyph.Structure)
+		structures[i] = &parsed
+	}
+	last := len(entries) - 1
+	if structures[last] == nil {
+		return nil
+	}
+
+	bodyRows := func(body string) []string {
+		rows := strings.Split(structureRowsToBody(splitStructureRows(body)), "\n")
+		if len(rows) == 1 && rows[0] == "" {
+			return nil
+		}
+		return rows
+	}
+	latestRows := bodyRows(structures[last].Body)
+	rowsFromBottom := make([][]string, len(entries))
+	for i, structure := range structures {
+		if structure != nil {
+			rowsFromBottom[i] = bodyRows(structure.Body)
+		}
+	}
+
+	blame := &glyphBlame{Rows: []blameRow{}, Components: []blameComponent{}}
+	for top, text := range latestRows {
+		fromBottom := len(latestRows) - 1 - top
+		entry := last
+		for entry > 0 {
+			rows := rowsFromBottom[entry-1]
+			if structures[entry-1] == nil || fromBottom >= len(rows) || rows[len(rows)-1-fromBottom] != text {
+				break
+			}
+			entry--
+		}
+		blame.Rows = append(blame.Rows, blameRow{Text: text, Entry: entry, Revision: entries[entry].Revision})
+	}
+	for _, component := range structures[last].Components {
+		entry := last
+		for entry > 0 && structures[entry-1] != nil && containsComponent(structures[entry-1].Components, component) {
+			entry--
+		}
+		blame.Components = append(blame.Components, blameComponent{Component: component, Entry: entry, Revision: entries[entry].Revision})
+	}
+	return blame
+}
+
+func containsComponent(components []glyphComponentRef, component glyphComponentRef) bool {
+	for _, candidate := range components {
+		if candidate == component {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	s.writeCORS(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	projectID := sanitizeProjectID(query.Get("project"))
+	if projectID == "" {
+		projectID = "default"
+	}
+	kind, ok := entityKindByName(strings.TrimSpace(query.Get("entity")))
+	if !ok {
+		http.Error(w, "unknown entity", http.StatusBadRequest)
+		return
+	}
+	id := strings.TrimSpace(query.Get("id"))
+	if id == "" && !kind.Singleton {
+		http.Error(w, "missing id", http.StatusBadRequest)
+		return
+	}
+
+	blame := true
+	if value, err := strconv.ParseBool(query.Get("blame")); err == nil {
+		blame = value
+	}
+	resp, err := s.hub.entityHistory(projectID, kind, id, blame)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			http.Error(w, fmt.Sprintf("%s not found", kind.Name), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestEntityHistory(t *testing.T) {
+	h := newHub(t.TempDir())
+	saveTestGlyphs(t, h, "p", glyphDocument{ID: "1", Name: "e", Structure: "##\n##"})
+	first := createTestRevision(t, h, "p", "first")
+	saveTestGlyphs(t, h, "p", glyphDocument{ID: "1", Name: "e", Structure: "##\n# "})
+
+	history, err := h.entityHistory("p", glyphKind, "1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Entries) != 2 || history.Entries[0].Revision != first.ID || history.Entries[1].Revision != currentRevisionID {
+		t.Fatalf("entries = %+v, want the revision then the working copy", history.Entries)
+	}
+	if history.Blame != nil {
+		t.Error("blame computed when skipped")
+	}
+
+	history, err = h.entityHistory("p", glyphKind, "1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history.Blame == nil {
+		t.Fatal("no blame")
+	}
+	want := []blameRow{{Text: "##", Entry: 0, Revision: first.ID}, {Text: "#", Entry: 1, Revision: currentRevisionID}}
+	if len(history.Blame.Rows) != len(want) {
+		t.Fatalf("blame rows = %+v, want %+v", history.Blame.Rows, want)
+	}
+	for i, row := range want {
+		if history.Blame.Rows[i] != row {
+			t.Errorf("blame row %d = %+v, want %+v", i, history.Blame.Rows[i], row)
+		}
+	}
+
+	// A working copy saved as a revision is listed once, under the revision.
+	second := createTestRevision(t, h, "p", "second")
+	history, err = h.entityHistory("p", glyphKind, "1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Entries) != 2 || history.Entries[1].Revision != second.ID {
+		t.Errorf("entries = %+v, want the two revisions", history.Entries)
+	}
+}
+
+func TestHistoryBlameByDefault(t *testing.T) {
+	h := newHub(t.TempDir())
+	saveTestGlyphs(t, h, "p", glyphDocument{ID: "1", Name: "e", Structure: "##"})
+	for query, want := range map[string]bool{"": true, "&blame=1": true, "&blame=0": false} {
+		recorder := serveTestRequest(t, h, http.MethodGet, "/api/history?project=p&entity=glyph&id=1"+query, "")
+		var history historyResponse
+		if recorder.Code != http.StatusOK || json.Unmarshal(recorder.Body.Bytes(), &history) != nil {
+			t.Fatalf("%q: status = %d: %s", query, recorder.Code, recorder.Body)
+		}
+		if got := history.Blame != nil; got != want {
+			t.Errorf("%q: blame = %v, want %v", query, got, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// importUFOs replaces the glyphs, syntaxes and metrics of a project with the
+// ones carried by Chirone UFOs, one UFO per syntax.
+func (h *hub) importUFOs(projectID string, ufos []ufoProject, clientID string) (projectDocument, error) {
+	snapshot, err := mergeUFOProjects(ufos)
+	if err != nil {
+		return projectDocument{}, err
+	}
+	current, _, err := h.getProject(projectID)
+	if err != nil {
+		return projectDocument{}, err
+	}
+	return h.updateProject(projectID, updateProjectRequest{
+		ClientID:        clientID,
+		BaseVersion:     &current.Version,
+		projectSnapshot: snapshot,
+	})
+}
+
+func importCommand(args []string) error {
+	flags := flag.NewFlagSet("chirone import", flag.ContinueOnError)
+	flags.Usage = printUsage
+
+	dataDir := flags.String("data-dir", "./data", "directory where project snapshots are stored")
+	projectID := flags.String("project", "default", "project to restore")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("at least one .ufo or .ufoz written by Chirone is required")
+	}
+	if !projectIDPattern.MatchString(*projectID) {
+		return fmt.Errorf("invalid project id %q", *projectID)
+	}
+
+	ufos := []ufoProject{}
+	for _, name := range flags.Args() {
+		fsys, err := openUFO(name)
+		if err != nil {
+			return err
+		}
+		ufo, err := readUFO(fsys)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		ufos = append(ufos, ufo)
+	}
+
+	doc, err := newHub(*dataDir).importUFOs(*projectID, ufos, "")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s restored from %d UFO(s) (version %d)\n", doc.Project, len(ufos), doc.Version)
+	return nil
+}
+
+// readUploadedUFOs accepts either a multipart form with one or more "ufo"
+// file fields or a single .ufoz archive as the request body.
+func readUploadedUFOs(w http.ResponseWriter, r *http.Request) ([][]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxFontUploadBytes)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		data, err := io.ReadAll(r.Body)
+		if err != nil || len(data) == 0 {
+			return nil, err
+		}
+		return [][]byte{data}, nil
+	}
+	if err := r.ParseMultipartForm(maxFontUploadBytes); err != nil {
+		return nil, err
+	}
+	archives := [][]byte{}
+	for _, header := range r.MultipartForm.File["ufo"] {
+		file, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		archives = append(archives, data)
+	}
+	return archives, nil
+}
+
+// handleImport restores a project from uploaded .ufoz archives written by
+// Chirone, replacing its glyphs, syntaxes and metrics.
+func (s *server) handleImport(w http.ResponseWriter, r *http.Request) {
+	s.writeCORS(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	projectID := sanitizeProjectID(query.Get("project"))
+	archives, err := readUploadedUFOs(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(archives) == 0 {
+		http.Error(w, "UFO archive is required", http.StatusBadRequest)
+		return
+	}
+	ufos := make([]ufoProject, 0, len(archives))
+	for _, data := range archives {
+		fsys, err := openUFOArchive(data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		ufo, err := readUFO(fsys)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				err = errNotChironeUFO
+			}
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		ufos = append(ufos, ufo)
+	}
+
+	doc, err := s.hub.importUFOs(projectID, ufos, strings.TrimSpace(query.Get("clientId")))
+	if err != nil {
+		var conflictErr *versionConflictError
+		if errors.As(err, &conflictErr) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(conflictErr.Current)
+			return
+		}
+		var validationErr *validationError
+		if errors.As(err, &validationErr) {
+			writeValidationError(w, validationErr)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(doc)
+}
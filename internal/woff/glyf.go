@@ -0,0 +1,364 @@
+package woff
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The WOFF 2.0 glyf transform splits TrueType outlines into separate streams
+// (contour counts, point counts, flags, coordinate triplets, composites,
+// bounding boxes and instructions) that Brotli compresses much better than
+// the interleaved glyf layout. loca is then rebuilt by the decoder.
+
+const (
+	glyfFlagOnCurve      = 1 << 0
+	glyfFlagXShort       = 1 << 1
+	glyfFlagYShort       = 1 << 2
+	glyfFlagRepeat       = 1 << 3
+	glyfFlagXSame        = 1 << 4
+	glyfFlagYSame        = 1 << 5
+	glyfFlagOverlap      = 1 << 6
+	compositeArgsWords   = 1 << 0
+	compositeHasScale    = 1 << 3
+	compositeMore        = 1 << 5
+	compositeHasXYScale  = 1 << 6
+	compositeHasTwoByTwo = 1 << 7
+	compositeHasInstr    = 1 << 8
+	glyfHeaderSize       = 10
+	glyfTransformHeader  = 36
+)
+
+// errGlyfNotTransformable reports outlines that decode fine but cannot be
+// carried by the transform without loss, such as overlap flags.
+var errGlyfNotTransformable = errors.New("woff: glyf table cannot be transformed")
+
+type glyfPoint struct {
+	X, Y    int
+	OnCurve bool
+}
+
+type glyfGlyph struct {
+	Contours     int // -1 for composite glyphs
+	Box          [4]int16
+	EndPoints    []int
+	Points       []glyfPoint
+	Instructions []byte
+	Components   []byte
+	HasInstr     bool
+}
+
+func (g glyfGlyph) computedBox() [4]int16 {
+	if len(g.Points) == 0 {
+		return [4]int16{}
+	}
+	box := [4]int{g.Points[0].X, g.Points[0].Y, g.Points[0].X, g.Points[0].Y}
+	for _, p := range g.Points[1:] {
+		box[0], box[1] = min(box[0], p.X), min(box[1], p.Y)
+		box[2], box[3] = max(box[2], p.X), max(box[3], p.Y)
+	}
+	return [4]int16{int16(box[0]), int16(box[1]), int16(box[2]), int16(box[3])}
+}
+
+func readLoca(loca []byte, numGlyphs int, long bool) ([]int, error) {
+	offsets := make([]int, numGlyphs+1)
+	for i := range offsets {
+		if long {
+			if len(loca) < 4*(i+1) {
+				return nil, fmt.Errorf("%w: loca is too short", ErrInvalidFont)
+			}
+			offsets[i] = int(be.Uint32(loca[4*i:]))
+		} else {
+			if len(loca) < 2*(i+1) {
+				return nil, fmt.Errorf("%w: loca is too short", ErrInvalidFont)
+			}
+			offsets[i] = 2 * int(be.Uint16(loca[2*i:]))
+		}
+	}
+	return offsets, nil
+}
+
+func parseGlyf(glyf, loca []byte, numGlyphs int, longLoca bool) ([]glyfGlyph, error) {
+	offsets, err := readLoca(loca, numGlyphs, longLoca)
+	if err != nil {
+		return nil, err
+	}
+	glyphs := make([]glyfGlyph, numGlyphs)
+	for i := range glyphs {
+		start, end := offsets[i], offsets[i+1]
+		if start > end || end > len(glyf) {
+			return nil, fmt.Errorf("%w: glyph %d is out of bounds", ErrInvalidFont, i)
+		}
+		if start == end {
+			continue
+		}
+		glyph, err := parseGlyfGlyph(glyf[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("glyph %d: %w", i, err)
+		}
+		glyphs[i] = glyph
+	}
+	return glyphs, nil
+}
+
+func parseGlyfGlyph(data []byte) (glyfGlyph, error) {
+	truncated := fmt.Errorf("%w: truncated glyph", ErrInvalidFont)
+	if len(data) < glyfHeaderSize {
+		return glyfGlyph{}, truncated
+	}
+	glyph := glyfGlyph{Contours: int(int16(be.Uint16(data)))}
+	for i := range glyph.Box {
+		glyph.Box[i] = int16(be.Uint16(data[2+2*i:]))
+	}
+	pos := glyfHeaderSize
+
+	if glyph.Contours < 0 {
+		glyph.Contours = -1
+		for {
+			if len(data) < pos+4 {
+				return glyfGlyph{}, truncated
+			}
+			flags := be.Uint16(data[pos:])
+			size := 4
+			if flags&compositeArgsWords != 0 {
+				size += 4
+			} else {
+				size += 2
+			}
+			switch {
+			case flags&compositeHasScale != 0:
+				size += 2
+			case flags&compositeHasXYScale != 0:
+				size += 4
+			case flags&compositeHasTwoByTwo != 0:
+				size += 8
+			}
+			if len(data) < pos+size {
+				return glyfGlyph{}, truncated
+			}
+			glyph.Components = append(glyph.Components, data[pos:pos+size]...)
+			pos += size
+			if flags&compositeHasInstr != 0 {
+				glyph.HasInstr = true
+			}
+			if flags&compositeMore == 0 {
+				break
+			}
+		}
+		if glyph.HasInstr {
+			if len(data) < pos+2 {
+				return glyfGlyph{}, truncated
+			}
+			length := int(be.Uint16(data[pos:]))
+			pos += 2
+			if len(data) < pos+length {
+				return glyfGlyph{}, truncated
+			}
+			glyph.Instructions = data[pos : pos+length]
+		}
+		return glyph, nil
+	}
+	if glyph.Contours == 0 {
+		return glyph, nil
+	}
+
+	if len(data) < pos+2*glyph.Contours+2 {
+		return glyfGlyph{}, truncated
+	}
+	glyph.EndPoints = make([]int, glyph.Contours)
+	previous := -1
+	for i := range glyph.EndPoints {
+		glyph.EndPoints[i] = int(be.Uint16(data[pos:]))
+		if glyph.EndPoints[i] <= previous {
+			return glyfGlyph{}, fmt.Errorf("%w: contour end points are not increasing", ErrInvalidFont)
+		}
+		previous = glyph.EndPoints[i]
+		pos += 2
+	}
+	numPoints := previous + 1
+	length := int(be.Uint16(data[pos:]))
+	pos += 2
+	if len(data) < pos+length {
+		return glyfGlyph{}, truncated
+	}
+	glyph.Instructions = data[pos : pos+length]
+	pos += length
+
+	flags := make([]byte, 0, numPoints)
+	for len(flags) < numPoints {
+		if pos >= len(data) {
+			return glyfGlyph{}, truncated
+		}
+		flag := data[pos]
+		pos++
+		if flag&glyfFlagOverlap != 0 {
+			return glyfGlyph{}, errGlyfNotTransformable
+		}
+		flags = append(flags, flag)
+		if flag&glyfFlagRepeat != 0 {
+			if pos >= len(data) {
+				return glyfGlyph{}, truncated
+			}
+			for count := data[pos]; count > 0 && len(flags) < numPoints; count-- {
+				flags = append(flags, flag)
+			}
+			pos++
+		}
+	}
+
+	readDeltas := func(shortBit, sameBit byte) ([]int, error) {
+		deltas := make([]int, numPoints)
+		for i, flag := range flags {
+			switch {
+			case flag&shortBit != 0:
+				if pos >= len(data) {
+					return nil, truncated
+				}
+				deltas[i] = int(data[pos])
+				if flag&sameBit == 0 {
+					deltas[i] = -deltas[i]
+				}
+				pos++
+			case flag&sameBit == 0:
+				if len(data) < pos+2 {
+					return nil, truncated
+				}
+				deltas[i] = int(int16(be.Uint16(data[pos:])))
+				pos += 2
+			}
+		}
+		return deltas, nil
+	}
+	dxs, err := readDeltas(glyfFlagXShort, glyfFlagXSame)
+	if err != nil {
+		return glyfGlyph{}, err
+	}
+	dys, err := readDeltas(glyfFlagYShort, glyfFlagYSame)
+	if err != nil {
+		return glyfGlyph{}, err
+	}
+	glyph.Points = make([]glyfPoint, numPoints)
+	x, y := 0, 0
+	for i, flag := range flags {
+		x += dxs[i]
+		y += dys[i]
+		glyph.Points[i] = glyfPoint{X: x, Y: y, OnCurve: flag&glyfFlagOnCurve != 0}
+	}
+	return glyph, nil
+}
+
+func append255UInt16(out []byte, value int) []byte {
+	switch {
+	case value < 253:
+		return append(out, byte(value))
+	case value < 506:
+		return append(out, 255, byte(value-253))
+	case value < 762:
+		return append(out, 254, byte(value-506))
+	default:
+		return be.AppendUint16(append(out, 253), uint16(value))
+	}
+}
+
+// appendTriplet encodes a point delta with the WOFF 2.0 triplet encoding,
+// returning the updated flag and glyph streams.
+func appendTriplet(flags, glyphs []byte, onCurve bool, dx, dy int) ([]byte, []byte) {
+	absX, absY := dx, dy
+	if absX < 0 {
+		absX = -absX
+	}
+	if absY < 0 {
+		absY = -absY
+	}
+	flag := 0
+	if !onCurve {
+		flag = 128
+	}
+	xSign, ySign := 0, 0
+	if dx >= 0 {
+		xSign = 1
+	}
+	if dy >= 0 {
+		ySign = 1
+	}
+	signs := xSign + 2*ySign
+
+	switch {
+	case dx == 0 && absY < 1280:
+		flag += (absY&0xf00)>>7 + ySign
+		glyphs = append(glyphs, byte(absY))
+	case dy == 0 && absX < 1280:
+		flag += 10 + (absX&0xf00)>>7 + xSign
+		glyphs = append(glyphs, byte(absX))
+	case absX < 65 && absY < 65:
+		flag += 20 + (absX-1)&0x30 + ((absY-1)&0x30)>>2 + signs
+		glyphs = append(glyphs, byte(((absX-1)&0xf)<<4|(absY-1)&0xf))
+	case absX < 769 && absY < 769:
+		flag += 84 + 12*(((absX-1)&0x300)>>8) + ((absY-1)&0x300)>>6 + signs
+		glyphs = append(glyphs, byte(absX-1), byte(absY-1))
+	case absX < 4096 && absY < 4096:
+		flag += 120 + signs
+		glyphs = append(glyphs, byte(absX>>4), byte((absX&0xf)<<4|absY>>8), byte(absY))
+	default:
+		flag += 124 + signs
+		glyphs = append(glyphs, byte(absX>>8), byte(absX), byte(absY>>8), byte(absY))
+	}
+	return append(flags, byte(flag)), glyphs
+}
+
+// transformGlyf builds the transformed glyf table (version 0 of the WOFF 2.0
+// glyf transform). indexFormat is copied from head.indexToLocFormat.
+func transformGlyf(glyphs []glyfGlyph, indexFormat uint16) []byte {
+	var nContours, nPoints, flagStream, glyphStream, composites, boxes, instructions []byte
+	bitmap := make([]byte, 4*((len(glyphs)+31)/32))
+
+	for i, glyph := range glyphs {
+		nContours = be.AppendUint16(nContours, uint16(int16(glyph.Contours)))
+		explicitBox := false
+		switch {
+		case glyph.Contours < 0:
+			composites = append(composites, glyph.Components...)
+			if glyph.HasInstr {
+				glyphStream = append255UInt16(glyphStream, len(glyph.Instructions))
+				instructions = append(instructions, glyph.Instructions...)
+			}
+			explicitBox = true
+		case glyph.Contours > 0:
+			previous := -1
+			for _, end := range glyph.EndPoints {
+				nPoints = append255UInt16(nPoints, end-previous)
+				previous = end
+			}
+			x, y := 0, 0
+			for _, p := range glyph.Points {
+				flagStream, glyphStream = appendTriplet(flagStream, glyphStream, p.OnCurve, p.X-x, p.Y-y)
+				x, y = p.X, p.Y
+			}
+			glyphStream = append255UInt16(glyphStream, len(glyph.Instructions))
+			instructions = append(instructions, glyph.Instructions...)
+			explicitBox = glyph.Box != glyph.computedBox()
+		}
+		if explicitBox {
+			bitmap[i/8] |= 0x80 >> (i % 8)
+			for _, v := range glyph.Box {
+				boxes = be.AppendUint16(boxes, uint16(v))
+			}
+		}
+	}
+
+	out := make([]byte, 0, glyfTransformHeader+len(nContours)+len(nPoints)+len(flagStream)+
+		len(glyphStream)+len(composites)+len(bitmap)+len(boxes)+len(instructions))
+	out = be.AppendUint16(out, 0) // reserved
+	out = be.AppendUint16(out, 0) // optionFlags
+	out = be.AppendUint16(out, uint16(len(glyphs)))
+	out = be.AppendUint16(out, indexFormat)
+	for _, size := range []int{
+		len(nContours), len(nPoints), len(flagStream), len(glyphStream),
+		len(composites), len(bitmap) + len(boxes), len(instructions),
+	} {
+		out = be.AppendUint32(out, uint32(size))
+	}
+	for _, stream := range [][]byte{nContours, nPoints, flagStream, glyphStream, composites, bitmap, boxes, instructions} {
+		out = append(out, stream...)
+	}
+	return out
+}
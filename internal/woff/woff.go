@@ -0,0 +1,141 @@
+// Package woff wraps sfnt fonts (TrueType or CFF OpenType) into the WOFF 1.0
+// and WOFF 2.0 web font containers.
+package woff
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var be = binary.BigEndian
+
+var (
+	// ErrInvalidFont is returned when the input is not a parsable sfnt font.
+	ErrInvalidFont = errors.New("woff: invalid sfnt font")
+)
+
+const (
+	sfntHeaderSize      = 12
+	sfntTableRecordSize = 16
+	woffHeaderSize      = 44
+	woffTableEntrySize  = 20
+)
+
+type table struct {
+	Tag      string
+	Checksum uint32
+	Data     []byte
+}
+
+// readSFNT splits an sfnt font into its tables, sorted by tag.
+func readSFNT(data []byte) (uint32, []table, error) {
+	if len(data) < sfntHeaderSize {
+		return 0, nil, ErrInvalidFont
+	}
+	flavor := be.Uint32(data)
+	switch flavor {
+	case 0x00010000, 0x4F54544F, 0x74727565: // 1.0, "OTTO", "true"
+	default:
+		return 0, nil, fmt.Errorf("%w: unsupported flavor %#08x", ErrInvalidFont, flavor)
+	}
+	numTables := int(be.Uint16(data[4:]))
+	if numTables == 0 || len(data) < sfntHeaderSize+numTables*sfntTableRecordSize {
+		return 0, nil, ErrInvalidFont
+	}
+	tables := make([]table, 0, numTables)
+	seen := map[string]struct{}{}
+	for i := 0; i < numTables; i++ {
+		record := data[sfntHeaderSize+i*sfntTableRecordSize:]
+		tag := string(record[:4])
+		offset := int(be.Uint32(record[8:]))
+		length := int(be.Uint32(record[12:]))
+		if offset < 0 || length < 0 || offset > len(data) || length > len(data)-offset {
+			return 0, nil, fmt.Errorf("%w: table %q is out of bounds", ErrInvalidFont, tag)
+		}
+		if _, exists := seen[tag]; exists {
+			return 0, nil, fmt.Errorf("%w: duplicate table %q", ErrInvalidFont, tag)
+		}
+		seen[tag] = struct{}{}
+		tables = append(tables, table{Tag: tag, Checksum: be.Uint32(record[4:]), Data: data[offset : offset+length]})
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Tag < tables[j].Tag })
+	return flavor, tables, nil
+}
+
+func pad4(n int) int {
+	return (n + 3) &^ 3
+}
+
+func sfntSize(tables []table) int {
+	size := sfntHeaderSize + sfntTableRecordSize*len(tables)
+	for _, t := range tables {
+		size += pad4(len(t.Data))
+	}
+	return size
+}
+
+// Encode converts an sfnt font into WOFF 1.0, compressing every table with
+// zlib unless that would make it larger.
+func Encode(sfnt []byte) ([]byte, error) {
+	flavor, tables, err := readSFNT(sfnt)
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		table
+		stored []byte
+	}
+	entries := make([]entry, len(tables))
+	for i, t := range tables {
+		var compressed bytes.Buffer
+		writer, err := zlib.NewWriterLevel(&compressed, zlib.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(t.Data); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		stored := t.Data
+		if compressed.Len() < len(t.Data) {
+			stored = compressed.Bytes()
+		}
+		entries[i] = entry{table: t, stored: stored}
+	}
+
+	offset := woffHeaderSize + woffTableEntrySize*len(entries)
+	directory := make([]byte, 0, woffTableEntrySize*len(entries))
+	body := []byte{}
+	for _, e := range entries {
+		directory = append(directory, e.Tag...)
+		directory = be.AppendUint32(directory, uint32(offset+len(body)))
+		directory = be.AppendUint32(directory, uint32(len(e.stored)))
+		directory = be.AppendUint32(directory, uint32(len(e.Data)))
+		directory = be.AppendUint32(directory, e.Checksum)
+		body = append(body, e.stored...)
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+	}
+
+	total := offset + len(body)
+	out := make([]byte, 0, total)
+	out = append(out, "wOFF"...)
+	out = be.AppendUint32(out, flavor)
+	out = be.AppendUint32(out, uint32(total))
+	out = be.AppendUint16(out, uint16(len(entries)))
+	out = be.AppendUint16(out, 0) // reserved
+	out = be.AppendUint32(out, uint32(sfntSize(tables)))
+	out = be.AppendUint16(out, 1) // majorVersion
+	out = be.AppendUint16(out, 0) // minorVersion
+	out = append(out, make([]byte, 20)...)
+	out = append(out, directory...)
+	return append(out, body...), nil
+}
@@ -0,0 +1,203 @@
+package woff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	woff2HeaderSize     = 48
+	woff2ArbitraryTag   = 63
+	woff2NullTransform  = 3 << 6 // glyf and loca only; 0 means null elsewhere
+	woff2BrotliQuality  = 11
+	woff2BrotliLgWindow = 22
+)
+
+// woff2KnownTags is the known table tag list of the WOFF 2.0 table directory.
+var woff2KnownTags = []string{
+	"cmap", "head", "hhea", "hmtx", "maxp", "name", "OS/2", "post",
+	"cvt ", "fpgm", "glyf", "loca", "prep", "CFF ", "VORG", "EBDT",
+	"EBLC", "gasp", "hdmx", "kern", "LTSH", "PCLT", "VDMX", "vhea",
+	"vmtx", "BASE", "GDEF", "GPOS", "GSUB", "EBSC", "JSTF", "MATH",
+	"CBDT", "CBLC", "COLR", "CPAL", "SVG ", "sbix", "acnt", "avar",
+	"bdat", "bloc", "bsln", "cvar", "fdsc", "feat", "fmtx", "fvar",
+	"gvar", "hsty", "just", "lcar", "mort", "morx", "opbd", "prop",
+	"trak", "Zapf", "Silf", "Glat", "Gloc", "Feat", "Sill",
+}
+
+func appendUIntBase128(out []byte, value uint32) []byte {
+	var digits [5]byte
+	n := 0
+	for {
+		digits[n] = byte(value & 0x7f)
+		n++
+		value >>= 7
+		if value == 0 {
+			break
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		digit := digits[i]
+		if i > 0 {
+			digit |= 0x80
+		}
+		out = append(out, digit)
+	}
+	return out
+}
+
+// woff2Order keeps tables sorted by tag but moves loca right after glyf, as
+// the WOFF 2.0 table directory requires.
+func woff2Order(tables []table) []table {
+	ordered := make([]table, 0, len(tables))
+	var loca *table
+	for i := range tables {
+		if tables[i].Tag == "loca" {
+			loca = &tables[i]
+		}
+	}
+	for _, t := range tables {
+		if t.Tag == "loca" {
+			continue
+		}
+		ordered = append(ordered, t)
+		if t.Tag == "glyf" && loca != nil {
+			ordered = append(ordered, *loca)
+		}
+	}
+	if loca != nil && len(ordered) < len(tables) {
+		ordered = append(ordered, *loca)
+	}
+	return ordered
+}
+
+type woff2Entry struct {
+	Tag         string
+	OrigLength  int
+	Data        []byte // stored in the compressed stream
+	Transformed bool
+}
+
+// woff2Entries prepares the table directory entries, applying the glyf/loca
+// transform when the outlines allow it.
+func woff2Entries(tables []table) ([]woff2Entry, error) {
+	byTag := map[string][]byte{}
+	for _, t := range tables {
+		byTag[t.Tag] = t.Data
+	}
+	var transformedGlyf []byte
+	glyf, hasGlyf := byTag["glyf"]
+	loca, hasLoca := byTag["loca"]
+	head, maxp := byTag["head"], byTag["maxp"]
+	if hasGlyf && hasLoca {
+		if len(head) < 54 || len(maxp) < 6 {
+			return nil, fmt.Errorf("%w: head or maxp is truncated", ErrInvalidFont)
+		}
+		indexFormat := be.Uint16(head[50:])
+		glyphs, err := parseGlyf(glyf, loca, int(be.Uint16(maxp[4:])), indexFormat != 0)
+		switch {
+		case errors.Is(err, errGlyfNotTransformable):
+		case err != nil:
+			return nil, err
+		default:
+			transformedGlyf = transformGlyf(glyphs, indexFormat)
+		}
+	}
+
+	// origLength stays the length of the source table, transformed or not,
+	// as the reference encoder writes it. Decoders lay glyphs out their own
+	// way, so the rebuilt glyf may differ in size; totalSfntSize is only a
+	// hint for them.
+	entries := make([]woff2Entry, 0, len(tables))
+	for _, t := range woff2Order(tables) {
+		entry := woff2Entry{Tag: t.Tag, OrigLength: len(t.Data), Data: t.Data}
+		if transformedGlyf != nil {
+			switch t.Tag {
+			case "glyf":
+				entry.Data, entry.Transformed = transformedGlyf, true
+			case "loca":
+				entry.Data, entry.Transformed = nil, true
+			case "head":
+				// Bit 11 flags fonts whose glyf bytes changed in a lossless transform.
+				entry.Data = append([]byte{}, t.Data...)
+				entry.Data[16] |= 0x08
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Encode2 converts an sfnt font into WOFF 2.0. TrueType outlines go through
+// the glyf/loca transform and all tables share a single Brotli stream.
+func Encode2(sfnt []byte) ([]byte, error) {
+	flavor, tables, err := readSFNT(sfnt)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := woff2Entries(tables)
+	if err != nil {
+		return nil, err
+	}
+
+	directory := []byte{}
+	var stream bytes.Buffer
+	totalSfntSize := sfntHeaderSize + sfntTableRecordSize*len(entries)
+	for _, e := range entries {
+		index := woff2ArbitraryTag
+		for i, known := range woff2KnownTags {
+			if known == e.Tag {
+				index = i
+				break
+			}
+		}
+		flags := byte(index)
+		if (e.Tag == "glyf" || e.Tag == "loca") && !e.Transformed {
+			flags |= woff2NullTransform
+		}
+		directory = append(directory, flags)
+		if index == woff2ArbitraryTag {
+			directory = append(directory, e.Tag...)
+		}
+		directory = appendUIntBase128(directory, uint32(e.OrigLength))
+		if e.Transformed {
+			directory = appendUIntBase128(directory, uint32(len(e.Data)))
+		}
+		stream.Write(e.Data)
+		totalSfntSize += pad4(e.OrigLength)
+	}
+
+	var compressed bytes.Buffer
+	writer := brotli.NewWriterOptions(&compressed, brotli.WriterOptions{
+		Quality: woff2BrotliQuality,
+		LGWin:   woff2BrotliLgWindow,
+	})
+	if _, err := writer.Write(stream.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	total := pad4(woff2HeaderSize + len(directory) + compressed.Len())
+	out := make([]byte, 0, total)
+	out = append(out, "wOF2"...)
+	out = be.AppendUint32(out, flavor)
+	out = be.AppendUint32(out, uint32(total))
+	out = be.AppendUint16(out, uint16(len(entries)))
+	out = be.AppendUint16(out, 0) // reserved
+	out = be.AppendUint32(out, uint32(totalSfntSize))
+	out = be.AppendUint32(out, uint32(compressed.Len()))
+	out = be.AppendUint16(out, 1) // majorVersion
+	out = be.AppendUint16(out, 0) // minorVersion
+	out = append(out, make([]byte, 20)...)
+	out = append(out, directory...)
+	out = append(out, compressed.Bytes()...)
+	for len(out) < total {
+		out = append(out, 0)
+	}
+	return out, nil
+}
@@ -0,0 +1,468 @@
+package woff
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+// testGlyph is a glyph of the test font, written with every flag and
+// coordinate spelled out so the encoder has to do all of the packing.
+type testGlyph struct {
+	contours     [][]glyfPoint
+	box          *[4]int16 // computed from the points when nil
+	instructions []byte
+	components   []byte // composite glyph records, flags first
+}
+
+func (g testGlyph) encode() []byte {
+	if g.components != nil {
+		out := be.AppendUint16(nil, 0xffff)
+		for _, v := range g.box {
+			out = be.AppendUint16(out, uint16(v))
+		}
+		out = append(out, g.components...)
+		if g.instructions != nil {
+			out = be.AppendUint16(out, uint16(len(g.instructions)))
+			out = append(out, g.instructions...)
+		}
+		return out
+	}
+	if len(g.contours) == 0 {
+		return nil
+	}
+	points := []glyfPoint{}
+	ends := []int{}
+	for _, contour := range g.contours {
+		points = append(points, contour...)
+		ends = append(ends, len(points)-1)
+	}
+	box := glyfGlyph{Points: points}.computedBox()
+	if g.box != nil {
+		box = *g.box
+	}
+	out := be.AppendUint16(nil, uint16(len(g.contours)))
+	for _, v := range box {
+		out = be.AppendUint16(out, uint16(v))
+	}
+	for _, end := range ends {
+		out = be.AppendUint16(out, uint16(end))
+	}
+	out = be.AppendUint16(out, uint16(len(g.instructions)))
+	out = append(out, g.instructions...)
+	for _, p := range points {
+		flag := byte(0)
+		if p.OnCurve {
+			flag = glyfFlagOnCurve
+		}
+		out = append(out, flag)
+	}
+	x, y := 0, 0
+	for _, p := range points {
+		out = be.AppendUint16(out, uint16(int16(p.X-x)))
+		x = p.X
+	}
+	for _, p := range points {
+		out = be.AppendUint16(out, uint16(int16(p.Y-y)))
+		y = p.Y
+	}
+	return out
+}
+
+// testTables builds the tables of a small TrueType font with short loca
+// offsets: an empty glyph, two outlines covering every triplet encoding and
+// a composite with instructions.
+func testTables() map[string][]byte {
+	on := func(x, y int) glyfPoint { return glyfPoint{X: x, Y: y, OnCurve: true} }
+	off := func(x, y int) glyfPoint { return glyfPoint{X: x, Y: y} }
+	glyphs := []testGlyph{
+		{},
+		{
+			contours: [][]glyfPoint{
+				{on(0, 0), on(0, 100), off(-300, 100), on(-295, 93), on(5, -407), on(2005, -3407), on(7005, -3307), on(7005, -1307)},
+				{on(10, 10), off(10, 20), on(20, 20)},
+			},
+			instructions: []byte{0xb0, 0x01},
+		},
+		{
+			contours: [][]glyfPoint{{on(0, 0), on(200, 0), on(200, 700), on(0, 700)}},
+			box:      &[4]int16{-10, -10, 210, 710},
+		},
+		{
+			box: &[4]int16{-295, -3407, 7205, 800},
+			components: []byte{
+				// ARG_1_AND_2_ARE_WORDS | MORE_COMPONENTS, glyph 1 at (0, 0).
+				0x00, 0x21, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+				// WE_HAVE_A_SCALE | WE_HAVE_INSTRUCTIONS, glyph 2 at (0, 100), scaled by 0.5.
+				0x01, 0x08, 0x00, 0x02, 0x00, 0x64, 0x20, 0x00,
+			},
+			instructions: []byte{0x4b},
+		},
+	}
+
+	glyf, loca := []byte{}, []byte{}
+	for _, glyph := range glyphs {
+		loca = be.AppendUint16(loca, uint16(len(glyf)/2))
+		glyf = append(glyf, glyph.encode()...)
+		if len(glyf)%2 != 0 {
+			glyf = append(glyf, 0)
+		}
+	}
+	loca = be.AppendUint16(loca, uint16(len(glyf)/2))
+
+	head := make([]byte, 54)
+	be.PutUint32(head, 0x00010000)
+	be.PutUint16(head[18:], 1000) // unitsPerEm
+	maxp := be.AppendUint32(nil, 0x00005000)
+	maxp = be.AppendUint16(maxp, uint16(len(glyphs)))
+	return map[string][]byte{
+		"head": head,
+		"maxp": maxp,
+		"glyf": glyf,
+		"loca": loca,
+		"cmap": {0, 0, 0, 0},
+		"Zzzz": []byte("arbitrary tag"),
+	}
+}
+
+func assembleTestSFNT(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	out := be.AppendUint32(nil, 0x00010000)
+	out = be.AppendUint16(out, uint16(len(tags)))
+	out = append(out, make([]byte, 6)...)
+	offset := sfntHeaderSize + sfntTableRecordSize*len(tags)
+	body := []byte{}
+	for _, tag := range tags {
+		out = append(out, tag...)
+		out = be.AppendUint32(out, 0)
+		out = be.AppendUint32(out, uint32(offset+len(body)))
+		out = be.AppendUint32(out, uint32(len(tables[tag])))
+		body = append(body, tables[tag]...)
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+	}
+	return append(out, body...)
+}
+
+// sameGlyph compares glyphs, not telling missing from empty instructions.
+func sameGlyph(a, b glyfGlyph) bool {
+	for _, g := range []*glyfGlyph{&a, &b} {
+		if len(g.Instructions) == 0 {
+			g.Instructions = nil
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	tables := testTables()
+	out, err := Encode(assembleTestSFNT(tables))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out[:4]) != "wOFF" || int(be.Uint32(out[8:])) != len(out) {
+		t.Fatalf("bad header: %q, length %d of %d", out[:4], be.Uint32(out[8:]), len(out))
+	}
+	numTables := int(be.Uint16(out[12:]))
+	if numTables != len(tables) {
+		t.Fatalf("numTables = %d, want %d", numTables, len(tables))
+	}
+	for i := 0; i < numTables; i++ {
+		entry := out[woffHeaderSize+i*woffTableEntrySize:]
+		tag := string(entry[:4])
+		offset, compLength, origLength := be.Uint32(entry[4:]), be.Uint32(entry[8:]), be.Uint32(entry[12:])
+		data := out[offset : offset+compLength]
+		if compLength < origLength {
+			reader, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("%s: %v", tag, err)
+			}
+			if data, err = io.ReadAll(reader); err != nil {
+				t.Fatalf("%s: %v", tag, err)
+			}
+		}
+		if !bytes.Equal(data, tables[tag]) {
+			t.Errorf("%s = %x, want %x", tag, data, tables[tag])
+		}
+	}
+}
+
+// woff2Reader walks one of the streams of a transformed glyf table.
+type woff2Reader struct {
+	t    *testing.T
+	name string
+	data []byte
+}
+
+func (r *woff2Reader) bytes(n int) []byte {
+	r.t.Helper()
+	if len(r.data) < n {
+		r.t.Fatalf("%s stream is truncated", r.name)
+	}
+	out := r.data[:n]
+	r.data = r.data[n:]
+	return out
+}
+
+func (r *woff2Reader) uint16() int {
+	return int(be.Uint16(r.bytes(2)))
+}
+
+func (r *woff2Reader) uint255() int {
+	switch code := r.bytes(1)[0]; code {
+	case 253:
+		return r.uint16()
+	case 254:
+		return 506 + int(r.bytes(1)[0])
+	case 255:
+		return 253 + int(r.bytes(1)[0])
+	default:
+		return int(code)
+	}
+}
+
+func readUIntBase128(t *testing.T, data []byte) (uint32, []byte) {
+	t.Helper()
+	var value uint32
+	for i := 0; i < 5; i++ {
+		digit := data[i]
+		value = value<<7 | uint32(digit&0x7f)
+		if digit&0x80 == 0 {
+			return value, data[i+1:]
+		}
+	}
+	t.Fatal("UIntBase128 is longer than 5 bytes")
+	return 0, nil
+}
+
+// readTriplet decodes a point delta of the WOFF 2.0 triplet encoding.
+func readTriplet(flag byte, glyphs *woff2Reader) (dx, dy int, onCurve bool) {
+	onCurve = flag&0x80 == 0
+	flag &= 0x7f
+	signed := func(value int, positive bool) int {
+		if positive {
+			return value
+		}
+		return -value
+	}
+	switch {
+	case flag < 10:
+		b := glyphs.bytes(1)
+		return 0, signed(int(flag&14)<<7+int(b[0]), flag&1 != 0), onCurve
+	case flag < 20:
+		flag -= 10
+		b := glyphs.bytes(1)
+		return signed(int(flag&14)<<7+int(b[0]), flag&1 != 0), 0, onCurve
+	case flag < 84:
+		flag -= 20
+		b := glyphs.bytes(1)[0]
+		return signed(1+int(flag&0x30)+int(b>>4), flag&1 != 0), signed(1+int(flag&0x0c)<<2+int(b&0x0f), flag&2 != 0), onCurve
+	case flag < 120:
+		flag -= 84
+		b := glyphs.bytes(2)
+		high, rest := int(flag/12), flag%12
+		return signed(1+high<<8+int(b[0]), rest&1 != 0), signed(1+int(rest>>2)<<8+int(b[1]), rest&2 != 0), onCurve
+	case flag < 124:
+		b := glyphs.bytes(3)
+		return signed(int(b[0])<<4|int(b[1])>>4, flag&1 != 0), signed(int(b[1]&0x0f)<<8|int(b[2]), flag&2 != 0), onCurve
+	default:
+		b := glyphs.bytes(4)
+		return signed(int(b[0])<<8|int(b[1]), flag&1 != 0), signed(int(b[2])<<8|int(b[3]), flag&2 != 0), onCurve
+	}
+}
+
+// untransformGlyf decodes a transformed glyf table back into glyphs, as a
+// WOFF 2.0 decoder would before laying them out again.
+func untransformGlyf(t *testing.T, data []byte) []glyfGlyph {
+	t.Helper()
+	header := &woff2Reader{t: t, name: "header", data: data}
+	header.bytes(4) // reserved, optionFlags
+	numGlyphs := header.uint16()
+	header.uint16() // indexFormat
+	streams := make([]*woff2Reader, 7)
+	names := []string{"nContour", "nPoints", "flag", "glyph", "composite", "bbox", "instruction"}
+	sizes := make([]int, 7)
+	for i := range sizes {
+		sizes[i] = int(be.Uint32(header.bytes(4)))
+	}
+	for i, size := range sizes {
+		streams[i] = &woff2Reader{t: t, name: names[i], data: header.bytes(size)}
+	}
+	if len(header.data) != 0 {
+		t.Fatalf("%d bytes after the glyf streams", len(header.data))
+	}
+	nContours, nPoints, flags, glyphStream, composites, boxes, instructions := streams[0], streams[1], streams[2], streams[3], streams[4], streams[5], streams[6]
+	bitmap := boxes.bytes(4 * ((numGlyphs + 31) / 32))
+
+	glyphs := make([]glyfGlyph, numGlyphs)
+	for i := range glyphs {
+		glyph := glyfGlyph{Contours: int(int16(nContours.uint16()))}
+		switch {
+		case glyph.Contours < 0:
+			for {
+				flags := be.Uint16(composites.data)
+				size := 6
+				if flags&compositeArgsWords != 0 {
+					size = 8
+				}
+				switch {
+				case flags&compositeHasScale != 0:
+					size += 2
+				case flags&compositeHasXYScale != 0:
+					size += 4
+				case flags&compositeHasTwoByTwo != 0:
+					size += 8
+				}
+				glyph.Components = append(glyph.Components, composites.bytes(size)...)
+				glyph.HasInstr = glyph.HasInstr || flags&compositeHasInstr != 0
+				if flags&compositeMore == 0 {
+					break
+				}
+			}
+			if glyph.HasInstr {
+				glyph.Instructions = instructions.bytes(glyphStream.uint255())
+			}
+		case glyph.Contours > 0:
+			total := 0
+			for c := 0; c < glyph.Contours; c++ {
+				total += nPoints.uint255()
+				glyph.EndPoints = append(glyph.EndPoints, total-1)
+			}
+			x, y := 0, 0
+			for p := 0; p < total; p++ {
+				dx, dy, onCurve := readTriplet(flags.bytes(1)[0], glyphStream)
+				x, y = x+dx, y+dy
+				glyph.Points = append(glyph.Points, glyfPoint{X: x, Y: y, OnCurve: onCurve})
+			}
+			glyph.Instructions = instructions.bytes(glyphStream.uint255())
+			glyph.Box = glyph.computedBox()
+		}
+		if bitmap[i/8]&(0x80>>(i%8)) != 0 {
+			for j := range glyph.Box {
+				glyph.Box[j] = int16(boxes.uint16())
+			}
+		} else if glyph.Contours < 0 {
+			t.Fatalf("composite glyph %d has no bounding box", i)
+		}
+		glyphs[i] = glyph
+	}
+	for _, stream := range streams {
+		if len(stream.data) != 0 {
+			t.Errorf("%d bytes left in the %s stream", len(stream.data), stream.name)
+		}
+	}
+	return glyphs
+}
+
+func TestEncode2RoundTrip(t *testing.T) {
+	tables := testTables()
+	out, err := Encode2(assembleTestSFNT(tables))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out[:4]) != "wOF2" || int(be.Uint32(out[8:])) != len(out) {
+		t.Fatalf("bad header: %q, length %d of %d", out[:4], be.Uint32(out[8:]), len(out))
+	}
+	numTables := int(be.Uint16(out[12:]))
+	totalSfntSize := int(be.Uint32(out[16:]))
+	compressedLength := int(be.Uint32(out[20:]))
+
+	type entry struct {
+		tag             string
+		origLength      int
+		transformLength int
+		transformed     bool
+	}
+	entries := []entry{}
+	directory := out[woff2HeaderSize:]
+	for i := 0; i < numTables; i++ {
+		flags := directory[0]
+		directory = directory[1:]
+		e := entry{}
+		if index := int(flags & 0x3f); index == woff2ArbitraryTag {
+			e.tag, directory = string(directory[:4]), directory[4:]
+		} else {
+			e.tag = woff2KnownTags[index]
+		}
+		var length uint32
+		length, directory = readUIntBase128(t, directory)
+		e.origLength = int(length)
+		version := flags >> 6
+		e.transformed = (e.tag == "glyf" || e.tag == "loca") == (version == 0)
+		if e.transformed {
+			length, directory = readUIntBase128(t, directory)
+			e.transformLength = int(length)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) != len(tables) {
+		t.Fatalf("%d tables, want %d", len(entries), len(tables))
+	}
+
+	stream, err := io.ReadAll(brotli.NewReader(bytes.NewReader(directory[:compressedLength])))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSfntSize := sfntHeaderSize + sfntTableRecordSize*len(tables)
+	for i, e := range entries {
+		source := tables[e.tag]
+		if e.origLength != len(source) {
+			t.Errorf("%s origLength = %d, want %d", e.tag, e.origLength, len(source))
+		}
+		wantSfntSize += pad4(len(source))
+		switch e.tag {
+		case "glyf":
+			if !e.transformed {
+				t.Fatal("glyf is not transformed")
+			}
+			want, err := parseGlyf(source, tables["loca"], 4, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := untransformGlyf(t, stream[:e.transformLength])
+			if len(got) != len(want) {
+				t.Fatalf("%d glyphs, want %d", len(got), len(want))
+			}
+			for g := range want {
+				if !sameGlyph(got[g], want[g]) {
+					t.Errorf("glyph %d = %+v, want %+v", g, got[g], want[g])
+				}
+			}
+			stream = stream[e.transformLength:]
+		case "loca":
+			if i == 0 || entries[i-1].tag != "glyf" {
+				t.Error("loca does not follow glyf")
+			}
+			if !e.transformed || e.transformLength != 0 {
+				t.Errorf("loca is stored with %d bytes", e.transformLength)
+			}
+		default:
+			got := stream[:e.origLength]
+			stream = stream[e.origLength:]
+			want := source
+			if e.tag == "head" {
+				want = append([]byte{}, source...)
+				want[16] |= 0x08
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s = %x, want %x", e.tag, got, want)
+			}
+		}
+	}
+	if len(stream) != 0 {
+		t.Errorf("%d bytes left in the table stream", len(stream))
+	}
+	if totalSfntSize != wantSfntSize {
+		t.Errorf("totalSfntSize = %d, want %d", totalSfntSize, wantSfntSize)
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kerning corrects the spacing the grid gets wrong, such as LT or r
+// followed by a comma. Each rule kerns a glyph or a class of glyphs against
+// another by a number of grid cells, which may be fractional.
+
+type kerningDocument struct {
+	ID    string      `json:"id"`
+	Left  kerningSide `json:"left"`
+	Right kerningSide `json:"right"`
+	Value float64     `json:"value"`
+}
+
+// kerningSide is a glyph name or a list of glyph names forming a class.
+type kerningSide []string
+
+func (side *kerningSide) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*side = kerningSide{name}
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return errors.New("must be a glyph name or a list of glyph names")
+	}
+	*side = names
+	return nil
+}
+
+// label writes a side as in feature files: the glyph name or a bracketed
+// class.
+func (side kerningSide) label() string {
+	if len(side) == 1 {
+		return side[0]
+	}
+	return "[" + strings.Join(side, " ") + "]"
+}
+
+// decodeKerningDocuments decodes a snapshot kerning array, keeping its
+// order. Snapshots without kerning have none.
+func decodeKerningDocuments(raw json.RawMessage) ([]kerningDocument, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var list []kerningDocument
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("kerning: %w", err)
+	}
+	return list, nil
+}
+
+// validateKerning checks that a rule names glyphs on both sides and has a
+// value in cells. Names are not checked against the glyphs: a rule for a
+// glyph not drawn yet is skipped when fonts are built.
+func validateKerning(id string, raw json.RawMessage, _ map[string]json.RawMessage) error {
+	problems := &validationError{Entity: "kerning", EntityID: id}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		problems.add("", "must be a JSON object")
+		return problems
+	}
+	for _, key := range []string{"left", "right"} {
+		value, ok := fields[key]
+		if !ok {
+			problems.add(key, "is required")
+			continue
+		}
+		var side kerningSide
+		if err := json.Unmarshal(value, &side); err != nil {
+			problems.add(key, "%v", err)
+			continue
+		}
+		if len(side) == 0 {
+			problems.add(key, "must name at least one glyph")
+		}
+		for i, name := range side {
+			if strings.TrimSpace(name) == "" {
+				problems.add(fmt.Sprintf("%s[%d]", key, i), "must not be empty")
+			}
+		}
+	}
+	var value float64
+	if raw, ok := fields["value"]; !ok || json.Unmarshal(raw, &value) != nil {
+		problems.add("value", "must be a number of grid cells")
+	}
+	return problems.result()
+}
+
+// validateKerningMap checks the kerning rules of a project snapshot that
+// differ from the stored ones, in id order, and stops at the first invalid
+// rule.
+func validateKerningMap(kerning, stored map[string]json.RawMessage) error {
+	for _, id := range changedEntityIDs(kerning, stored) {
+		if err := validateKerning(id, kerning[id], nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fontKerning is a kern rule in glyph indexes and font units.
+type fontKerning struct {
+	Left  []int
+	Right []int
+	// Class is set when either side was written as a class.
+	Class bool
+	Value int
+}
+
+// collectFontKerning resolves kerning rules against the glyphs of a font.
+// Glyph pairs come first, so they win over the class pairs they belong to
+// as in AFDKO kern features. Names with no glyph are left out and reported;
+// a rule left without a glyph on either side is skipped.
+func collectFontKerning(glyphs []fontGlyph, kerning []kerningDocument, metrics fontMetrics) ([]fontKerning, []featureIssue) {
+	indexByName := map[string]int{}
+	for i, glyph := range glyphs[1:] {
+		if _, exists := indexByName[glyph.Name]; !exists && glyph.Name != "" {
+			indexByName[glyph.Name] = i + 1
+		}
+	}
+
+	rules := []fontKerning{}
+	issues := []featureIssue{}
+	for _, document := range kerning {
+		missing := []string{}
+		resolve := func(side kerningSide) []int {
+			indexes := []int{}
+			for _, name := range side {
+				if index, ok := indexByName[name]; ok {
+					indexes = append(indexes, index)
+				} else {
+					missing = append(missing, name)
+				}
+			}
+			return indexes
+		}
+		rule := fontKerning{
+			Left:  resolve(document.Left),
+			Right: resolve(document.Right),
+			Class: len(document.Left) > 1 || len(document.Right) > 1,
+			Value: metrics.cellsToUnits(document.Value),
+		}
+		skipped := len(rule.Left) == 0 || len(rule.Right) == 0
+		if len(missing) > 0 {
+			message := "no glyph named " + strings.Join(missing, ", ")
+			if !skipped {
+				message += ", left out of the class"
+			}
+			issues = append(issues, featureIssue{
+				Glyph:   document.Left.label() + " " + document.Right.label(),
+				Feature: "kern",
+				Missing: missing,
+				Message: message,
+			})
+		}
+		if !skipped {
+			rules = append(rules, rule)
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return !rules[i].Class && rules[j].Class })
+	return rules, issues
+}
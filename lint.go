@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Lint findings point at problems that do not stop a write but break or
+// degrade the fonts built from a project. Errors make a project unclean;
+// warnings are reported only.
+
+const (
+	lintError   = "error"
+	lintWarning = "warning"
+)
+
+type lintFinding struct {
+	Severity string `json:"severity"`
+	// Code identifies the check: unused-symbol, missing-symbol,
+	// duplicate-glyph-name, no-unicode, missing-base or clipped-ink.
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+	Syntax   string   `json:"syntax,omitempty"`
+	Glyph    string   `json:"glyph,omitempty"`
+	GlyphIDs []string `json:"glyphIds,omitempty"`
+	Symbols  []string `json:"symbols,omitempty"`
+}
+
+type lintReport struct {
+	Project  string        `json:"project"`
+	Version  int64         `json:"version"`
+	Clean    bool          `json:"clean"`
+	Errors   int           `json:"errors"`
+	Warnings int           `json:"warnings"`
+	Findings []lintFinding `json:"findings"`
+}
+
+// lintFailedError rejects a revision of a project that does not lint clean.
+type lintFailedError struct {
+	Report lintReport
+}
+
+func (e *lintFailedError) Error() string {
+	return fmt.Sprintf("project %q has %d lint error(s)", e.Report.Project, e.Report.Errors)
+}
+
+func lintProject(projectID string, version int64, snapshot projectSnapshot) (lintReport, error) {
+	glyphs, err := decodeGlyphDocuments(snapshot.Glyphs)
+	if err != nil {
+		return lintReport{}, err
+	}
+	syntaxes, err := decodeSyntaxDocuments(snapshot.Syntaxes)
+	if err != nil {
+		return lintReport{}, err
+	}
+
+	findings := []lintFinding{}
+	idsByName := map[string][]string{}
+	names := []string{}
+	for _, glyph := range glyphs {
+		if _, seen := idsByName[glyph.Name]; !seen {
+			names = append(names, glyph.Name)
+		}
+		idsByName[glyph.Name] = append(idsByName[glyph.Name], glyph.ID)
+	}
+	for _, name := range names {
+		if ids := idsByName[name]; len(ids) > 1 {
+			findings = append(findings, lintFinding{
+				Severity: lintError,
+				Code:     "duplicate-glyph-name",
+				Message:  fmt.Sprintf("%d glyphs are named %q; only the first one is built", len(ids), name),
+				Glyph:    name,
+				GlyphIDs: ids,
+			})
+		}
+	}
+
+	for _, syntax := range syntaxes {
+		findings = append(findings, lintSyntaxSymbols(syntax, glyphs)...)
+	}
+
+	fontGlyphs := []fontGlyph{{Name: ".notdef"}}
+	for _, name := range names {
+		fontGlyphs = append(fontGlyphs, fontGlyph{Name: name})
+		if isFeatureGlyphName(name) {
+			continue
+		}
+		if codepoint, ok := resolveUnicodeNumber(name); !ok || !isEncodableUnicode(codepoint) {
+			findings = append(findings, lintFinding{
+				Severity: lintWarning,
+				Code:     "no-unicode",
+				Message:  fmt.Sprintf("%q has no Unicode mapping, so no text can reach it", name),
+				Glyph:    name,
+			})
+		}
+	}
+	for _, issue := range collectFontFeatures(fontGlyphs).Issues {
+		findings = append(findings, lintFinding{
+			Severity: lintError,
+			Code:     "missing-base",
+			Message:  fmt.Sprintf("%s substitution for %q is dropped: %s", issue.Feature, issue.Glyph, issue.Message),
+			Glyph:    issue.Glyph,
+			GlyphIDs: idsByName[issue.Glyph],
+		})
+	}
+
+	findings = append(findings, lintGlyphSpacing(glyphs)...)
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity == lintError && findings[j].Severity != lintError
+	})
+	report := lintReport{Project: projectID, Version: version, Findings: findings}
+	for _, finding := range findings {
+		if finding.Severity == lintError {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+	}
+	report.Clean = report.Errors == 0
+	return report, nil
+}
+
+// lintSyntaxSymbols compares the symbols of the resolved glyph bodies with
+// the rules of a syntax, as the build does.
+func lintSyntaxSymbols(syntax syntaxDocument, glyphs []glyphDocument) []lintFinding {
+	findings := []lintFinding{}
+	used := map[string]struct{}{}
+	for _, glyph := range resolveFontGlyphs(syntax, glyphs, "") {
+		missing := []string{}
+		for _, cell := range structureCells(glyph.Body) {
+			used[cell.Symbol] = struct{}{}
+			if _, err := syntax.getRule(cell.Symbol); err != nil && !slices.Contains(missing, cell.Symbol) {
+				missing = append(missing, cell.Symbol)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			findings = append(findings, lintFinding{
+				Severity: lintError,
+				Code:     "missing-symbol",
+				Message:  fmt.Sprintf("%q uses symbols with no rule in %q: %s", glyph.Name, syntax.Name, quoteSymbols(missing)),
+				Syntax:   syntax.ID,
+				Glyph:    glyph.Name,
+				Symbols:  missing,
+			})
+		}
+	}
+	for _, rule := range syntax.Rules {
+		// Rules the editor marks unused are kept on purpose.
+		if _, ok := used[rule.Symbol]; ok || rule.Symbol == " " || rule.Unused {
+			continue
+		}
+		findings = append(findings, lintFinding{
+			Severity: lintWarning,
+			Code:     "unused-symbol",
+			Message:  fmt.Sprintf("no glyph uses symbol %q of %q", rule.Symbol, syntax.Name),
+			Syntax:   syntax.ID,
+			Symbols:  []string{rule.Symbol},
+		})
+	}
+	return findings
+}
+
+// isFeatureGlyphName reports names reached through liga or alternates
+// rather than the cmap, such as f_i, a.ss01 or a.component.
+func isFeatureGlyphName(name string) bool {
+	return len(getLigatureComponentNames(name)) >= 2 || getAlternateBaseName(name) != ""
+}
+
+func quoteSymbols(symbols []string) string {
+	quoted := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		quoted[i] = fmt.Sprintf("%q", symbol)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// handleLint reports the lint findings of the live project state.
+func (s *server) handleLint(w http.ResponseWriter, r *http.Request) {
+	s.writeCORS(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID := sanitizeProjectID(r.URL.Query().Get("project"))
+	doc, ok, err := s.hub.getProject(projectID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Error(w, "project not found", http.StatusNotFound)
+		return
+	}
+	report, err := lintProject(doc.Project, doc.Version, doc.projectSnapshot)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLintProject(t *testing.T) {
+	snapshot := testSnapshot(t,
+		glyphDocument{ID: "1", Name: "A", Structure: "#"},
+		glyphDocument{ID: "2", Name: "A", Structure: "#"},
+		glyphDocument{ID: "3", Name: "B", Structure: "#*"},
+		glyphDocument{ID: "4", Name: "flourish", Structure: "#"},
+		glyphDocument{ID: "5", Name: "c.ss01", Structure: "#"},
+	)
+	// + is never drawn; o is not drawn either but marked unused.
+	snapshot.Syntaxes = json.RawMessage(`[{
+		"id": "regular",
+		"name": "Regular",
+		"grid": {"rows": 1, "columns": 1},
+		"rules": [
+			{"symbol": " ", "shape": {"kind": "void", "props": {}}},
+			{"symbol": "#", "shape": {"kind": "void", "props": {}}},
+			{"symbol": "+", "shape": {"kind": "void", "props": {}}},
+			{"symbol": "o", "shape": {"kind": "void", "props": {}}, "unused": true}
+		]
+	}]`)
+	report, err := lintProject("p", 3, snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []lintFinding{
+		{Severity: lintError, Code: "duplicate-glyph-name", Glyph: "A", GlyphIDs: []string{"1", "2"}},
+		{Severity: lintError, Code: "missing-symbol", Syntax: "regular", Glyph: "B", Symbols: []string{"*"}},
+		{Severity: lintError, Code: "missing-base", Glyph: "c.ss01", GlyphIDs: []string{"5"}},
+		{Severity: lintWarning, Code: "unused-symbol", Syntax: "regular", Symbols: []string{"+"}},
+		{Severity: lintWarning, Code: "no-unicode", Glyph: "flourish"},
+	}
+	if len(report.Findings) != len(want) {
+		t.Fatalf("findings = %+v, want %d", report.Findings, len(want))
+	}
+	for i, finding := range report.Findings {
+		if finding.Message == "" {
+			t.Errorf("%s has no message", finding.Code)
+		}
+		finding.Message = ""
+		got, _ := json.Marshal(finding)
+		expected, _ := json.Marshal(want[i])
+		if string(got) != string(expected) {
+			t.Errorf("finding %d = %s, want %s", i, got, expected)
+		}
+	}
+	if report.Clean || report.Errors != 3 || report.Warnings != 2 || report.Version != 3 {
+		t.Errorf("report = %+v", report)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"embed"
 	"encoding/json"
@@ -42,6 +43,12 @@ type projectSnapshot struct {
 	Glyphs   json.RawMessage `json:"glyphs"`
 	Syntaxes json.RawMessage `json:"syntaxes"`
 	Metrics  json.RawMessage `json:"metrics"`
+	// Metadata is the FontMetadata shared by every collaborator. Snapshots
+	// written before it existed have none.
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+	// Kerning lists kerningDocument rules; snapshots written before it
+	// existed have none.
+	Kerning json.RawMessage `json:"kerning,omitempty"`
 }
 
 type projectDocument struct {
@@ -55,36 +62,8 @@ type updateProjectRequest struct {
 	ClientID    string `json:"clientId"`
 	BaseVersion *int64 `json:"baseVersion,omitempty"`
 	projectSnapshot
-}
-
-type updateGlyphRequest struct {
-	ClientID    string          `json:"clientId"`
-	BaseVersion *int64          `json:"baseVersion,omitempty"`
-	Glyph       json.RawMessage `json:"glyph"`
-}
-
-type deleteGlyphRequest struct {
-	ClientID    string `json:"clientId"`
-	BaseVersion *int64 `json:"baseVersion,omitempty"`
-	ID          string `json:"id"`
-}
-
-type updateSyntaxRequest struct {
-	ClientID    string          `json:"clientId"`
-	BaseVersion *int64          `json:"baseVersion,omitempty"`
-	Syntax      json.RawMessage `json:"syntax"`
-}
-
-type deleteSyntaxRequest struct {
-	ClientID    string `json:"clientId"`
-	BaseVersion *int64 `json:"baseVersion,omitempty"`
-	ID          string `json:"id"`
-}
-
-type updateMetricsRequest struct {
-	ClientID    string          `json:"clientId"`
-	BaseVersion *int64          `json:"baseVersion,omitempty"`
-	Metrics     json.RawMessage `json:"metrics"`
+	// DryRun validates the snapshot without storing it.
+	DryRun bool `json:"-"`
 }
 
 type versionConflictError struct {
@@ -126,9 +105,11 @@ type entityUpdateResponse struct {
 
 type projectResponse struct {
 	projectDocument
-	GlyphVersions  map[string]int64 `json:"glyphVersions,omitempty"`
-	SyntaxVersions map[string]int64 `json:"syntaxVersions,omitempty"`
-	MetricsVersion int64            `json:"metricsVersion,omitempty"`
+	GlyphVersions   map[string]int64 `json:"glyphVersions,omitempty"`
+	SyntaxVersions  map[string]int64 `json:"syntaxVersions,omitempty"`
+	MetricsVersion  int64            `json:"metricsVersion,omitempty"`
+	MetadataVersion int64            `json:"metadataVersion,omitempty"`
+	KerningVersions map[string]int64 `json:"kerningVersions,omitempty"`
 }
 
 type projectVersionResponse struct {
@@ -143,14 +124,22 @@ type revisionDocument struct {
 	Version   int64  `json:"version"`
 	CreatedAt string `json:"createdAt"`
 	Message   string `json:"message"`
+	// ClientID is the collaborator who created the revision, when known.
+	ClientID string `json:"clientId,omitempty"`
+	// Tags name milestones such as v1.0; each names one revision of the
+	// project, and tagged revisions cannot be deleted.
+	Tags []string `json:"tags,omitempty"`
 	projectSnapshot
 }
 
 type revisionMeta struct {
-	ID        string `json:"id"`
-	Version   int64  `json:"version"`
-	CreatedAt string `json:"createdAt"`
-	Message   string `json:"message"`
+	ID        string   `json:"id"`
+	Version   int64    `json:"version"`
+	CreatedAt string   `json:"createdAt"`
+	Message   string   `json:"message"`
+	ClientID  string   `json:"clientId,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Protected bool     `json:"protected,omitempty"`
 }
 
 type revisionsResponse struct {
@@ -163,6 +152,10 @@ type revisionsResponse struct {
 type createRevisionRequest struct {
 	ClientID string `json:"clientId,omitempty"`
 	Message  string `json:"message"`
+	// RequireCleanLint refuses the revision while the project has lint
+	// errors.
+	RequireCleanLint bool     `json:"requireCleanLint,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
 }
 
 type createRevisionResponse struct {
@@ -174,6 +167,15 @@ type createRevisionResponse struct {
 type revertRevisionRequest struct {
 	ClientID string `json:"clientId,omitempty"`
 	ID       string `json:"id"`
+	// Glyphs, Syntaxes and Metrics restore only the named entities of the
+	// revision; without them the whole project is reverted.
+	Glyphs   []string `json:"glyphs,omitempty"`
+	Syntaxes []string `json:"syntaxes,omitempty"`
+	Metrics  bool     `json:"metrics,omitempty"`
+}
+
+func (req revertRevisionRequest) partial() bool {
+	return len(req.Glyphs) > 0 || len(req.Syntaxes) > 0 || req.Metrics
 }
 
 type appVersionResponse struct {
@@ -201,20 +203,19 @@ type projectEvent struct {
 }
 
 type projectState struct {
-	Doc            projectDocument
-	Glyphs         map[string]json.RawMessage
-	Syntaxes       map[string]json.RawMessage
-	Metrics        json.RawMessage
-	GlyphVersions  map[string]int64
-	SyntaxVersions map[string]int64
-	MetricsVersion int64
-	Subs           map[chan projectEvent]struct{}
+	Doc projectDocument
+	// Entities holds the store of every registered entity kind by name.
+	Entities map[string]*entityStore
+	Subs     map[chan projectEvent]struct{}
 }
 
 type hub struct {
 	mu       sync.RWMutex
 	projects map[string]*projectState
 	dataDir  string
+	// revisionLocks serialize the revision writes of each project, so a tag
+	// is checked against every other write; guarded by mu.
+	revisionLocks map[string]*sync.Mutex
 }
 
 var (
@@ -224,41 +225,27 @@ var (
 
 func newHub(dataDir string) *hub {
 	return &hub{
-		projects: map[string]*projectState{},
-		dataDir:  dataDir,
+		projects:      map[string]*projectState{},
+		dataDir:       dataDir,
+		revisionLocks: map[string]*sync.Mutex{},
 	}
 }
 
+// normalizeSnapshot fills in missing kinds, except optional ones, which stay
+// missing so older revisions and clients can be told apart.
 func normalizeSnapshot(snapshot projectSnapshot) (projectSnapshot, error) {
 	var out projectSnapshot
-
-	if len(snapshot.Glyphs) == 0 {
-		out.Glyphs = json.RawMessage(`[]`)
-	} else {
-		if !json.Valid(snapshot.Glyphs) {
-			return out, errors.New("glyphs is not valid JSON")
-		}
-		out.Glyphs = snapshot.Glyphs
-	}
-
-	if len(snapshot.Syntaxes) == 0 {
-		out.Syntaxes = json.RawMessage(`[]`)
-	} else {
-		if !json.Valid(snapshot.Syntaxes) {
-			return out, errors.New("syntaxes is not valid JSON")
-		}
-		out.Syntaxes = snapshot.Syntaxes
-	}
-
-	if len(snapshot.Metrics) == 0 {
-		out.Metrics = json.RawMessage(`{}`)
-	} else {
-		if !json.Valid(snapshot.Metrics) {
-			return out, errors.New("metrics is not valid JSON")
+	for _, kind := range entityKinds {
+		raw := *kind.snapshotField(&snapshot)
+		if len(raw) == 0 {
+			if !kind.Optional {
+				raw = kind.emptySnapshot()
+			}
+		} else if !json.Valid(raw) {
+			return out, fmt.Errorf("%s is not valid JSON", kind.Field)
 		}
-		out.Metrics = snapshot.Metrics
+		*kind.snapshotField(&out) = raw
 	}
-
 	return out, nil
 }
 
@@ -342,24 +329,15 @@ func serializeEntityMap(items map[string]json.RawMessage) (json.RawMessage, erro
 }
 
 func rebuildProjectSnapshot(state *projectState) error {
-	glyphs, err := serializeEntityMap(state.Glyphs)
-	if err != nil {
-		return err
-	}
-	syntaxes, err := serializeEntityMap(state.Syntaxes)
-	if err != nil {
-		return err
-	}
-	metrics := state.Metrics
-	if len(metrics) == 0 {
-		metrics = json.RawMessage(`{}`)
-	}
-
-	state.Doc.projectSnapshot = projectSnapshot{
-		Glyphs:   glyphs,
-		Syntaxes: syntaxes,
-		Metrics:  metrics,
+	var snapshot projectSnapshot
+	for _, kind := range entityKinds {
+		raw, err := kind.serialize(state.Entities[kind.Name].Items)
+		if err != nil {
+			return err
+		}
+		*kind.snapshotField(&snapshot) = raw
 	}
+	state.Doc.projectSnapshot = snapshot
 	return nil
 }
 
@@ -370,35 +348,21 @@ func newProjectStateFromDocument(doc projectDocument) (*projectState, error) {
 	}
 	doc.projectSnapshot = snapshot
 
-	glyphMap, err := parseEntityArrayByID(snapshot.Glyphs, "glyphs")
-	if err != nil {
-		return nil, err
-	}
-	syntaxMap, err := parseEntityArrayByID(snapshot.Syntaxes, "syntaxes")
-	if err != nil {
-		return nil, err
-	}
-
-	metrics, err := normalizedRawObject(snapshot.Metrics, "metrics")
-	if err != nil {
-		return nil, err
-	}
-
 	state := &projectState{
-		Doc:            doc,
-		Glyphs:         glyphMap,
-		Syntaxes:       syntaxMap,
-		Metrics:        metrics,
-		GlyphVersions:  map[string]int64{},
-		SyntaxVersions: map[string]int64{},
-		MetricsVersion: 1,
-		Subs:           map[chan projectEvent]struct{}{},
+		Doc:      doc,
+		Entities: map[string]*entityStore{},
+		Subs:     map[chan projectEvent]struct{}{},
 	}
-	for id := range glyphMap {
-		state.GlyphVersions[id] = 1
-	}
-	for id := range syntaxMap {
-		state.SyntaxVersions[id] = 1
+	for _, kind := range entityKinds {
+		items, err := kind.parseSnapshot(*kind.snapshotField(&snapshot))
+		if err != nil {
+			return nil, err
+		}
+		store := &entityStore{Items: items, Versions: map[string]int64{}}
+		for id := range items {
+			store.Versions[id] = 1
+		}
+		state.Entities[kind.Name] = store
 	}
 	if err := rebuildProjectSnapshot(state); err != nil {
 		return nil, err
@@ -422,26 +386,6 @@ func (h *hub) projectDir(projectID string) string {
 	return filepath.Join(h.dataDir, projectID)
 }
 
-func (h *hub) projectGlyphDir(projectID string) string {
-	return filepath.Join(h.projectDir(projectID), "glyphs")
-}
-
-func (h *hub) projectSyntaxDir(projectID string) string {
-	return filepath.Join(h.projectDir(projectID), "syntaxes")
-}
-
-func (h *hub) projectMetricsFile(projectID string) string {
-	return filepath.Join(h.projectDir(projectID), "metrics.json")
-}
-
-func (h *hub) projectGlyphFile(projectID, filename string) string {
-	return filepath.Join(h.projectGlyphDir(projectID), filename)
-}
-
-func (h *hub) projectSyntaxFile(projectID, filename string) string {
-	return filepath.Join(h.projectSyntaxDir(projectID), filename)
-}
-
 func (h *hub) projectRevisionDir(projectID string) string {
 	return filepath.Join(h.projectDir(projectID), "revisions")
 }
@@ -450,6 +394,20 @@ func (h *hub) projectRevisionFile(projectID, revisionID string) string {
 	return filepath.Join(h.projectRevisionDir(projectID), fmt.Sprintf("%s.json", revisionID))
 }
 
+// lockRevisions holds the revision lock of a project until the returned
+// function is called.
+func (h *hub) lockRevisions(projectID string) func() {
+	h.mu.Lock()
+	lock, ok := h.revisionLocks[projectID]
+	if !ok {
+		lock = &sync.Mutex{}
+		h.revisionLocks[projectID] = lock
+	}
+	h.mu.Unlock()
+	lock.Lock()
+	return lock.Unlock
+}
+
 func (h *hub) loadProjectFromDisk(projectID string) (*projectDocument, error) {
 	bytes, err := os.ReadFile(h.projectFile(projectID))
 	if err != nil {
@@ -517,11 +475,17 @@ func (h *hub) saveProjectToDisk(doc projectDocument) error {
 }
 
 func writeJSONAtomic(target string, bytes []byte) error {
+	return writeFileAtomic(target, bytes)
+}
+
+// writeFileAtomic writes data next to target and renames it into place, so
+// readers never see a partial file.
+func writeFileAtomic(target string, data []byte) error {
 	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
 		return err
 	}
 	temp := target + ".tmp"
-	if err := os.WriteFile(temp, bytes, 0o644); err != nil {
+	if err := os.WriteFile(temp, data, 0o644); err != nil {
 		return err
 	}
 	return os.Rename(temp, target)
@@ -627,49 +591,11 @@ func (h *hub) saveProjectStateToDisk(projectID string, state *projectState) erro
 	if err := h.saveProjectToDisk(state.Doc); err != nil {
 		return err
 	}
-
-	glyphFilesByID := entityFileNamesByID(state.Glyphs)
-	glyphExpectedFiles := make(map[string]struct{}, len(glyphFilesByID))
-	for id, glyphRaw := range state.Glyphs {
-		glyphBytes, err := json.MarshalIndent(json.RawMessage(glyphRaw), "", "  ")
-		if err != nil {
-			return err
-		}
-		filename := glyphFilesByID[id]
-		glyphExpectedFiles[filename] = struct{}{}
-		if err := writeJSONAtomic(h.projectGlyphFile(projectID, filename), glyphBytes); err != nil {
-			return err
-		}
-	}
-	if err := removeStaleEntityFiles(h.projectGlyphDir(projectID), glyphExpectedFiles); err != nil {
-		return err
-	}
-
-	syntaxFilesByID := entityFileNamesByID(state.Syntaxes)
-	syntaxExpectedFiles := make(map[string]struct{}, len(syntaxFilesByID))
-	for id, syntaxRaw := range state.Syntaxes {
-		syntaxBytes, err := json.MarshalIndent(json.RawMessage(syntaxRaw), "", "  ")
-		if err != nil {
-			return err
-		}
-		filename := syntaxFilesByID[id]
-		syntaxExpectedFiles[filename] = struct{}{}
-		if err := writeJSONAtomic(h.projectSyntaxFile(projectID, filename), syntaxBytes); err != nil {
+	for _, kind := range entityKinds {
+		if err := h.saveEntityStore(projectID, kind, state.Entities[kind.Name]); err != nil {
 			return err
 		}
 	}
-	if err := removeStaleEntityFiles(h.projectSyntaxDir(projectID), syntaxExpectedFiles); err != nil {
-		return err
-	}
-
-	metricsBytes, err := json.MarshalIndent(json.RawMessage(state.Metrics), "", "  ")
-	if err != nil {
-		return err
-	}
-	if err := writeJSONAtomic(h.projectMetricsFile(projectID), metricsBytes); err != nil {
-		return err
-	}
-
 	return nil
 }
 
@@ -697,32 +623,33 @@ func cloneInt64Map(input map[string]int64) map[string]int64 {
 }
 
 func cloneProjectStateForPersist(state *projectState) *projectState {
+	entities := make(map[string]*entityStore, len(state.Entities))
+	for name, store := range state.Entities {
+		entities[name] = store.clone()
+	}
 	return &projectState{
-		Doc:            state.Doc,
-		Glyphs:         cloneRawMap(state.Glyphs),
-		Syntaxes:       cloneRawMap(state.Syntaxes),
-		Metrics:        cloneRawMessage(state.Metrics),
-		GlyphVersions:  cloneInt64Map(state.GlyphVersions),
-		SyntaxVersions: cloneInt64Map(state.SyntaxVersions),
-		MetricsVersion: state.MetricsVersion,
-		Subs:           nil,
+		Doc:      state.Doc,
+		Entities: entities,
+		Subs:     nil,
 	}
 }
 
 func cloneProjectSnapshot(snapshot projectSnapshot) projectSnapshot {
-	return projectSnapshot{
-		Glyphs:   cloneRawMessage(snapshot.Glyphs),
-		Syntaxes: cloneRawMessage(snapshot.Syntaxes),
-		Metrics:  cloneRawMessage(snapshot.Metrics),
+	var out projectSnapshot
+	for _, kind := range entityKinds {
+		*kind.snapshotField(&out) = cloneRawMessage(*kind.snapshotField(&snapshot))
 	}
+	return out
 }
 
 func projectResponseFromState(state *projectState) projectResponse {
 	return projectResponse{
 		projectDocument: state.Doc,
-		GlyphVersions:   cloneInt64Map(state.GlyphVersions),
-		SyntaxVersions:  cloneInt64Map(state.SyntaxVersions),
-		MetricsVersion:  state.MetricsVersion,
+		GlyphVersions:   cloneInt64Map(state.Entities[glyphKind.Name].Versions),
+		SyntaxVersions:  cloneInt64Map(state.Entities[syntaxKind.Name].Versions),
+		MetricsVersion:  state.Entities[metricsKind.Name].Versions[""],
+		MetadataVersion: state.Entities[metadataKind.Name].Versions[""],
+		KerningVersions: cloneInt64Map(state.Entities[kerningKind.Name].Versions),
 	}
 }
 
@@ -809,28 +736,50 @@ func buildEntityDiffSegment(label string, currentRaw, previousRaw json.RawMessag
 	return fmt.Sprintf("%s %s", label, strings.Join(parts, " "))
 }
 
+// rawJSONEqual compares two JSON values ignoring layout, since revisions are
+// stored indented.
+func rawJSONEqual(a, b json.RawMessage) bool {
+	var compactA, compactB bytes.Buffer
+	if json.Compact(&compactA, a) != nil || json.Compact(&compactB, b) != nil {
+		return string(a) == string(b)
+	}
+	return compactA.String() == compactB.String()
+}
+
 func buildSuggestedRevisionMessage(current projectSnapshot, previous *projectSnapshot) string {
 	if previous == nil {
-		glyphCount := 0
-		syntaxCount := 0
-		if parsedGlyphs, err := parseEntityArrayByID(current.Glyphs, "glyphs"); err == nil {
-			glyphCount = len(parsedGlyphs)
-		}
-		if parsedSyntaxes, err := parseEntityArrayByID(current.Syntaxes, "syntaxes"); err == nil {
-			syntaxCount = len(parsedSyntaxes)
+		counts := []string{}
+		for _, kind := range entityKinds {
+			if kind.Singleton {
+				continue
+			}
+			count := 0
+			if parsed, err := parseEntityArrayByID(*kind.snapshotField(&current), kind.Field); err == nil {
+				count = len(parsed)
+			}
+			if kind.Optional && count == 0 {
+				continue
+			}
+			counts = append(counts, fmt.Sprintf("%d %s", count, kind.Label))
 		}
-		return fmt.Sprintf("Init progetto: %d glifi, %d sintassi", glyphCount, syntaxCount)
+		return "Init progetto: " + strings.Join(counts, ", ")
 	}
 
-	segments := make([]string, 0, 3)
-	if glyphSegment := buildEntityDiffSegment("glifi", current.Glyphs, previous.Glyphs); glyphSegment != "" {
-		segments = append(segments, glyphSegment)
-	}
-	if syntaxSegment := buildEntityDiffSegment("sintassi", current.Syntaxes, previous.Syntaxes); syntaxSegment != "" {
-		segments = append(segments, syntaxSegment)
-	}
-	if string(current.Metrics) != string(previous.Metrics) {
-		segments = append(segments, "metriche aggiornate")
+	segments := make([]string, 0, len(entityKinds))
+	for _, kind := range entityKinds {
+		currentRaw, previousRaw := *kind.snapshotField(&current), *kind.snapshotField(previous)
+		if kind.Optional && len(previousRaw) == 0 {
+			continue
+		}
+		if !kind.Singleton {
+			if segment := buildEntityDiffSegment(kind.Label, currentRaw, previousRaw); segment != "" {
+				segments = append(segments, segment)
+			}
+			continue
+		}
+		if !rawJSONEqual(currentRaw, previousRaw) {
+			segments = append(segments, kind.Label)
+		}
 	}
 
 	if len(segments) == 0 {
@@ -845,6 +794,9 @@ func revisionMetaFromDocument(doc revisionDocument) revisionMeta {
 		Version:   doc.Version,
 		CreatedAt: doc.CreatedAt,
 		Message:   doc.Message,
+		ClientID:  doc.ClientID,
+		Tags:      doc.Tags,
+		Protected: doc.protected(),
 	}
 }
 
@@ -918,6 +870,12 @@ func (h *hub) loadRevisionDocument(projectID, revisionID string) (*revisionDocum
 	}
 
 	raw, err := os.ReadFile(h.projectRevisionFile(projectID, revisionID))
+	if errors.Is(err, os.ErrNotExist) {
+		// Tags stand for the revision they are on.
+		if tagged, tagErr := h.findTaggedRevision(projectID, revisionID); tagErr != nil || tagged != nil {
+			return tagged, tagErr
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -985,12 +943,31 @@ func (h *hub) createRevision(projectID string, req createRevisionRequest) (creat
 	if err != nil {
 		return createRevisionResponse{}, err
 	}
+	if req.RequireCleanLint {
+		report, err := lintProject(projectID, project.Version, project.projectSnapshot)
+		if err != nil {
+			return createRevisionResponse{}, err
+		}
+		if !report.Clean {
+			return createRevisionResponse{}, &lintFailedError{Report: report}
+		}
+	}
 
+	unlock := h.lockRevisions(projectID)
+	defer unlock()
 	revisions, err := h.listRevisionDocuments(projectID)
 	if err != nil {
 		return createRevisionResponse{}, err
 	}
 
+	tags, err := normalizeRevisionTags(req.Tags, revisions, "")
+	if err != nil {
+		return createRevisionResponse{}, err
+	}
+	if len(tags) == 0 {
+		tags = nil
+	}
+
 	var previousSnapshot *projectSnapshot
 	if len(revisions) > 0 {
 		previousSnapshot = &revisions[0].projectSnapshot
@@ -1017,6 +994,8 @@ func (h *hub) createRevision(projectID string, req createRevisionRequest) (creat
 		Version:         project.Version,
 		CreatedAt:       time.Now().UTC().Format(time.RFC3339Nano),
 		Message:         message,
+		ClientID:        strings.TrimSpace(req.ClientID),
+		Tags:            tags,
 		projectSnapshot: cloneProjectSnapshot(project.projectSnapshot),
 	}
 
@@ -1042,17 +1021,19 @@ func (h *hub) revertRevision(projectID, revisionID, clientID string) (projectRes
 		return projectResponse{}, err
 	}
 
-	nextGlyphs, err := parseEntityArrayByID(revision.Glyphs, "glyphs")
-	if err != nil {
-		return projectResponse{}, err
-	}
-	nextSyntaxes, err := parseEntityArrayByID(revision.Syntaxes, "syntaxes")
-	if err != nil {
-		return projectResponse{}, err
-	}
-	nextMetrics, err := normalizedRawObject(revision.Metrics, "metrics")
-	if err != nil {
-		return projectResponse{}, err
+	// Kinds missing from a revision, like metadata before it was synced,
+	// keep their current value.
+	next := map[string]map[string]json.RawMessage{}
+	for _, kind := range entityKinds {
+		raw := *kind.snapshotField(&revision.projectSnapshot)
+		if kind.Optional && len(raw) == 0 {
+			continue
+		}
+		items, err := kind.parseRevision(raw)
+		if err != nil {
+			return projectResponse{}, err
+		}
+		next[kind.Name] = items
 	}
 
 	var (
@@ -1068,29 +1049,23 @@ func (h *hub) revertRevision(projectID, revisionID, clientID string) (projectRes
 		return projectResponse{}, err
 	}
 
-	sameGlyphs := entityRawMapsEqual(state.Glyphs, nextGlyphs)
-	sameSyntaxes := entityRawMapsEqual(state.Syntaxes, nextSyntaxes)
-	sameMetrics := string(state.Metrics) == string(nextMetrics)
-
-	if sameGlyphs && sameSyntaxes && sameMetrics {
+	changed := false
+	for name, items := range next {
+		if !entityRawMapsEqual(state.Entities[name].Items, items) {
+			changed = true
+		}
+	}
+	if !changed {
 		response = projectResponseFromState(state)
 		h.mu.Unlock()
 		return response, nil
 	}
 
-	state.GlyphVersions = mergeVersionMap(state.GlyphVersions, nextGlyphs, state.Glyphs)
-	state.SyntaxVersions = mergeVersionMap(state.SyntaxVersions, nextSyntaxes, state.Syntaxes)
-	if !sameMetrics {
-		if state.MetricsVersion < 1 {
-			state.MetricsVersion = 1
-		} else {
-			state.MetricsVersion++
+	for _, kind := range entityKinds {
+		if items, ok := next[kind.Name]; ok {
+			state.Entities[kind.Name].replace(kind, items)
 		}
 	}
-
-	state.Glyphs = nextGlyphs
-	state.Syntaxes = nextSyntaxes
-	state.Metrics = nextMetrics
 	if err := applyProjectMutation(state, projectID); err != nil {
 		h.mu.Unlock()
 		return projectResponse{}, err
@@ -1115,6 +1090,145 @@ func (h *hub) revertRevision(projectID, revisionID, clientID string) (projectRes
 	return response, nil
 }
 
+// entityWrite changes one entity outside of /api/<kind>, for partial
+// restores and branch merges.
+type entityWrite struct {
+	kind    *entityKind
+	id      string
+	raw     json.RawMessage
+	deleted bool
+}
+
+// applyEntityWritesLocked stores writes as one project mutation, bumping the
+// version of each entity, and returns the events to publish for them.
+func applyEntityWritesLocked(state *projectState, projectID, clientID string, writes []entityWrite) ([]projectEvent, error) {
+	versions := make([]int64, len(writes))
+	for i, write := range writes {
+		store := state.Entities[write.kind.Name]
+		if write.deleted {
+			versions[i] = store.Versions[write.id]
+			delete(store.Items, write.id)
+			delete(store.Versions, write.id)
+			continue
+		}
+		versions[i] = max(store.Versions[write.id], 0) + 1
+		store.Items[write.id] = write.raw
+		store.Versions[write.id] = versions[i]
+	}
+	if err := applyProjectMutation(state, projectID); err != nil {
+		return nil, err
+	}
+
+	events := make([]projectEvent, 0, len(writes))
+	for i, write := range writes {
+		event := projectEvent{
+			Type:            write.kind.EventPrefix + "_upsert",
+			ClientID:        clientID,
+			Entity:          write.kind.Name,
+			EntityID:        write.id,
+			EntityVersion:   versions[i],
+			Payload:         cloneRawMessage(write.raw),
+			projectDocument: state.Doc,
+		}
+		switch {
+		case write.deleted:
+			event.Type = write.kind.EventPrefix + "_delete"
+			event.EntityDeleted = true
+		case write.kind.Singleton:
+			event.Type = write.kind.EventPrefix + "_update"
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// restoreRevisionEntities brings back the selected entities of a revision and
+// leaves the rest of the project alone, so collaborators keep their work
+// since then. A selected entity the revision does not have was added after
+// it and is deleted.
+func (h *hub) restoreRevisionEntities(projectID string, req revertRevisionRequest) (projectResponse, error) {
+	projectID = sanitizeProjectID(projectID)
+	revision, err := h.loadRevisionDocument(projectID, req.ID)
+	if err != nil {
+		return projectResponse{}, err
+	}
+
+	type selection struct {
+		kind  *entityKind
+		ids   []string
+		items map[string]json.RawMessage
+	}
+	selections := []*selection{{kind: glyphKind, ids: req.Glyphs}, {kind: syntaxKind, ids: req.Syntaxes}}
+	if req.Metrics {
+		selections = append(selections, &selection{kind: metricsKind, ids: []string{""}})
+	}
+	for _, selected := range selections {
+		if len(selected.ids) == 0 {
+			continue
+		}
+		items, err := selected.kind.parseRevision(*selected.kind.snapshotField(&revision.projectSnapshot))
+		if err != nil {
+			return projectResponse{}, err
+		}
+		selected.items = items
+	}
+
+	h.mu.Lock()
+	state, err := h.getOrCreateProjectStateLocked(projectID)
+	if err != nil {
+		h.mu.Unlock()
+		return projectResponse{}, err
+	}
+
+	restored := []entityWrite{}
+	seen := map[string]struct{}{}
+	for _, selected := range selections {
+		store := state.Entities[selected.kind.Name]
+		for _, id := range selected.ids {
+			id = strings.TrimSpace(id)
+			key := selected.kind.Name + "/" + id
+			if _, duplicate := seen[key]; duplicate {
+				continue
+			}
+			seen[key] = struct{}{}
+			raw, inRevision := selected.items[id]
+			current, exists := store.Items[id]
+			switch {
+			case inRevision && (!exists || string(current) != string(raw)):
+				restored = append(restored, entityWrite{kind: selected.kind, id: id, raw: raw})
+			case !inRevision && exists:
+				restored = append(restored, entityWrite{kind: selected.kind, id: id, deleted: true})
+			case !inRevision:
+				h.mu.Unlock()
+				return projectResponse{}, fmt.Errorf("%s %q is in neither the revision nor the project", selected.kind.Name, id)
+			}
+		}
+	}
+	if len(restored) == 0 {
+		response := projectResponseFromState(state)
+		h.mu.Unlock()
+		return response, nil
+	}
+
+	events, err := applyEntityWritesLocked(state, projectID, req.ClientID, restored)
+	if err != nil {
+		h.mu.Unlock()
+		return projectResponse{}, err
+	}
+	response := projectResponseFromState(state)
+	persistCopy := cloneProjectStateForPersist(state)
+	channels := collectSubscriberChannels(state)
+	h.mu.Unlock()
+
+	if err := h.saveProjectStateToDisk(projectID, persistCopy); err != nil {
+		return projectResponse{}, err
+	}
+	for _, event := range events {
+		publishProjectEvent(channels, event)
+	}
+	return response, nil
+}
+
 func collectSubscriberChannels(state *projectState) []chan projectEvent {
 	channels := make([]chan projectEvent, 0, len(state.Subs))
 	for ch := range state.Subs {
@@ -1165,13 +1279,8 @@ func newEmptyProjectState(projectID string) (*projectState, error) {
 			Version:   0,
 			UpdatedAt: now,
 		},
-		Glyphs:         map[string]json.RawMessage{},
-		Syntaxes:       map[string]json.RawMessage{},
-		Metrics:        json.RawMessage(`{}`),
-		GlyphVersions:  map[string]int64{},
-		SyntaxVersions: map[string]int64{},
-		MetricsVersion: 0,
-		Subs:           map[chan projectEvent]struct{}{},
+		Entities: newEntityStores(),
+		Subs:     map[chan projectEvent]struct{}{},
 	}
 	if err := rebuildProjectSnapshot(state); err != nil {
 		return nil, err
@@ -1227,6 +1336,32 @@ func (h *hub) getOrCreateProjectStateLocked(projectID string) (*projectState, er
 	return state, nil
 }
 
+// storedEntities copies the entities of a project, by kind, with the
+// project version they belong to. A project that does not exist yet has
+// none, at version 0; it is not created.
+func (h *hub) storedEntities(projectID string) (map[string]map[string]json.RawMessage, int64, error) {
+	h.mu.RLock()
+	state, ok := h.projects[projectID]
+	if ok {
+		defer h.mu.RUnlock()
+	} else {
+		h.mu.RUnlock()
+		loadedState, exists, err := h.loadStateFromDisk(projectID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !exists || loadedState == nil {
+			return nil, 0, nil
+		}
+		state = loadedState
+	}
+	out := make(map[string]map[string]json.RawMessage, len(state.Entities))
+	for name, store := range state.Entities {
+		out[name] = cloneRawMap(store.Items)
+	}
+	return out, state.Doc.Version, nil
+}
+
 func (h *hub) getProject(projectID string) (projectDocument, bool, error) {
 	projectID = sanitizeProjectID(projectID)
 
@@ -1260,12 +1395,7 @@ func (h *hub) getProjectResponse(projectID string) (projectResponse, bool, error
 
 	h.mu.RLock()
 	if state, ok := h.projects[projectID]; ok {
-		resp := projectResponse{
-			projectDocument: state.Doc,
-			GlyphVersions:   cloneInt64Map(state.GlyphVersions),
-			SyntaxVersions:  cloneInt64Map(state.SyntaxVersions),
-			MetricsVersion:  state.MetricsVersion,
-		}
+		resp := projectResponseFromState(state)
 		h.mu.RUnlock()
 		return resp, true, nil
 	}
@@ -1281,22 +1411,12 @@ func (h *hub) getProjectResponse(projectID string) (projectResponse, bool, error
 
 	h.mu.Lock()
 	if state, ok := h.projects[projectID]; ok {
-		resp := projectResponse{
-			projectDocument: state.Doc,
-			GlyphVersions:   cloneInt64Map(state.GlyphVersions),
-			SyntaxVersions:  cloneInt64Map(state.SyntaxVersions),
-			MetricsVersion:  state.MetricsVersion,
-		}
+		resp := projectResponseFromState(state)
 		h.mu.Unlock()
 		return resp, true, nil
 	}
 	h.projects[projectID] = loadedState
-	resp := projectResponse{
-		projectDocument: loadedState.Doc,
-		GlyphVersions:   cloneInt64Map(loadedState.GlyphVersions),
-		SyntaxVersions:  cloneInt64Map(loadedState.SyntaxVersions),
-		MetricsVersion:  loadedState.MetricsVersion,
-	}
+	resp := projectResponseFromState(loadedState)
 	h.mu.Unlock()
 	return resp, true, nil
 }
@@ -1359,17 +1479,27 @@ func (h *hub) updateProject(projectID string, req updateProjectRequest) (project
 		return projectDocument{}, err
 	}
 
-	nextGlyphs, err := parseEntityArrayByID(snapshot.Glyphs, "glyphs")
+	stored, checkedVersion, err := h.storedEntities(projectID)
 	if err != nil {
 		return projectDocument{}, err
 	}
-	nextSyntaxes, err := parseEntityArrayByID(snapshot.Syntaxes, "syntaxes")
-	if err != nil {
-		return projectDocument{}, err
+
+	// Clients that predate a kind, like metadata, send none of it; their
+	// saves keep the stored value.
+	next := map[string]map[string]json.RawMessage{}
+	for _, kind := range entityKinds {
+		raw := *kind.snapshotField(&snapshot)
+		if len(raw) == 0 {
+			continue
+		}
+		items, err := kind.parseWrite(raw, stored[kind.Name])
+		if err != nil {
+			return projectDocument{}, err
+		}
+		next[kind.Name] = items
 	}
-	nextMetrics, err := normalizedRawObject(snapshot.Metrics, "metrics")
-	if err != nil {
-		return projectDocument{}, err
+	if req.DryRun {
+		return projectDocument{}, nil
 	}
 
 	var (
@@ -1402,7 +1532,9 @@ func (h *hub) updateProject(projectID string, req updateProjectRequest) (project
 		h.mu.Unlock()
 		return projectDocument{}, errors.New("missing baseVersion")
 	}
-	if *req.BaseVersion != state.Doc.Version {
+	// Entities were checked against the stored ones at checkedVersion; a
+	// write since then may have changed what was left unchecked.
+	if *req.BaseVersion != state.Doc.Version || checkedVersion != state.Doc.Version {
 		conflictDoc := state.Doc
 		h.mu.Unlock()
 		return projectDocument{}, &versionConflictError{
@@ -1411,20 +1543,12 @@ func (h *hub) updateProject(projectID string, req updateProjectRequest) (project
 		}
 	}
 
-	state.GlyphVersions = mergeVersionMap(state.GlyphVersions, nextGlyphs, state.Glyphs)
-	state.SyntaxVersions = mergeVersionMap(state.SyntaxVersions, nextSyntaxes, state.Syntaxes)
-	if string(state.Metrics) != string(nextMetrics) {
-		if state.MetricsVersion < 1 {
-			state.MetricsVersion = 1
-		} else {
-			state.MetricsVersion++
+	for _, kind := range entityKinds {
+		if items, ok := next[kind.Name]; ok {
+			state.Entities[kind.Name].replace(kind, items)
 		}
 	}
 
-	state.Glyphs = nextGlyphs
-	state.Syntaxes = nextSyntaxes
-	state.Metrics = nextMetrics
-
 	state.Doc.Project = projectID
 	state.Doc.Version++
 	state.Doc.UpdatedAt = time.Now().UTC().Format(time.RFC3339Nano)
@@ -1458,474 +1582,24 @@ func applyProjectMutation(state *projectState, projectID string) error {
 	return rebuildProjectSnapshot(state)
 }
 
-func (h *hub) updateGlyph(projectID string, req updateGlyphRequest) (entityUpdateResponse, error) {
-	projectID = sanitizeProjectID(projectID)
-	id, glyphRaw, err := parseEntityItem(req.Glyph, "glyph")
-	if err != nil {
-		return entityUpdateResponse{}, err
+type server struct {
+	hub         *hub
+	allowOrigin string
+	uiDir       string
+	uiFS        fs.FS
+	appVersion  string
+	appSHA      string
+}
+
+func resolveGitSHA() string {
+	if sha := resolveGitSHAFromBuildInfo(); sha != "" {
+		return sha
+	}
+	if sha := resolveGitSHAFromGit(); sha != "" {
+		return sha
 	}
-
-	var (
-		response    entityUpdateResponse
-		persistCopy *projectState
-		channels    []chan projectEvent
-		event       *projectEvent
-	)
-
-	h.mu.Lock()
-	state, err := h.getOrCreateProjectStateLocked(projectID)
-	if err != nil {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, err
-	}
-
-	if req.BaseVersion == nil {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, errors.New("missing baseVersion")
-	}
-
-	currentVersion := state.GlyphVersions[id]
-	currentGlyph, hasGlyph := state.Glyphs[id]
-	if *req.BaseVersion != currentVersion {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, &entityConflictError{
-			ExpectedVersion: *req.BaseVersion,
-			CurrentVersion:  currentVersion,
-			ProjectVersion:  state.Doc.Version,
-			Entity:          "glyph",
-			EntityID:        id,
-			EntityDeleted:   !hasGlyph,
-			UpdatedAt:       state.Doc.UpdatedAt,
-			Payload:         cloneRawMessage(currentGlyph),
-		}
-	}
-
-	nextVersion := currentVersion
-	if !hasGlyph {
-		nextVersion = 1
-	} else if string(currentGlyph) != string(glyphRaw) {
-		if nextVersion < 1 {
-			nextVersion = 1
-		} else {
-			nextVersion++
-		}
-	}
-
-	if !hasGlyph || string(currentGlyph) != string(glyphRaw) {
-		state.Glyphs[id] = glyphRaw
-		state.GlyphVersions[id] = nextVersion
-		if err := applyProjectMutation(state, projectID); err != nil {
-			h.mu.Unlock()
-			return entityUpdateResponse{}, err
-		}
-		persistCopy = cloneProjectStateForPersist(state)
-		channels = collectSubscriberChannels(state)
-		event = &projectEvent{
-			Type:            "glyph_upsert",
-			ClientID:        req.ClientID,
-			Entity:          "glyph",
-			EntityID:        id,
-			EntityVersion:   nextVersion,
-			Payload:         cloneRawMessage(glyphRaw),
-			projectDocument: state.Doc,
-		}
-	}
-
-	response = entityUpdateResponse{
-		Project:        projectID,
-		Entity:         "glyph",
-		EntityID:       id,
-		Version:        nextVersion,
-		ProjectVersion: state.Doc.Version,
-		UpdatedAt:      state.Doc.UpdatedAt,
-		Payload:        cloneRawMessage(glyphRaw),
-	}
-	h.mu.Unlock()
-
-	if persistCopy != nil {
-		if err := h.saveProjectStateToDisk(projectID, persistCopy); err != nil {
-			return entityUpdateResponse{}, err
-		}
-	}
-	if event != nil {
-		publishProjectEvent(channels, *event)
-	}
-
-	return response, nil
-}
-
-func (h *hub) deleteGlyph(projectID string, req deleteGlyphRequest) (entityUpdateResponse, error) {
-	projectID = sanitizeProjectID(projectID)
-	id := strings.TrimSpace(req.ID)
-	if id == "" {
-		return entityUpdateResponse{}, errors.New("missing id")
-	}
-
-	var (
-		response    entityUpdateResponse
-		persistCopy *projectState
-		channels    []chan projectEvent
-		event       *projectEvent
-	)
-
-	h.mu.Lock()
-	state, err := h.getOrCreateProjectStateLocked(projectID)
-	if err != nil {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, err
-	}
-
-	if req.BaseVersion == nil {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, errors.New("missing baseVersion")
-	}
-
-	currentVersion := state.GlyphVersions[id]
-	currentGlyph, hasGlyph := state.Glyphs[id]
-	if *req.BaseVersion != currentVersion {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, &entityConflictError{
-			ExpectedVersion: *req.BaseVersion,
-			CurrentVersion:  currentVersion,
-			ProjectVersion:  state.Doc.Version,
-			Entity:          "glyph",
-			EntityID:        id,
-			EntityDeleted:   !hasGlyph,
-			UpdatedAt:       state.Doc.UpdatedAt,
-			Payload:         cloneRawMessage(currentGlyph),
-		}
-	}
-
-	if hasGlyph {
-		delete(state.Glyphs, id)
-		delete(state.GlyphVersions, id)
-		if err := applyProjectMutation(state, projectID); err != nil {
-			h.mu.Unlock()
-			return entityUpdateResponse{}, err
-		}
-		persistCopy = cloneProjectStateForPersist(state)
-		channels = collectSubscriberChannels(state)
-		event = &projectEvent{
-			Type:            "glyph_delete",
-			ClientID:        req.ClientID,
-			Entity:          "glyph",
-			EntityID:        id,
-			EntityVersion:   currentVersion,
-			EntityDeleted:   true,
-			projectDocument: state.Doc,
-		}
-	}
-
-	response = entityUpdateResponse{
-		Project:        projectID,
-		Entity:         "glyph",
-		EntityID:       id,
-		Version:        currentVersion,
-		ProjectVersion: state.Doc.Version,
-		Deleted:        true,
-		UpdatedAt:      state.Doc.UpdatedAt,
-	}
-	h.mu.Unlock()
-
-	if persistCopy != nil {
-		if err := h.saveProjectStateToDisk(projectID, persistCopy); err != nil {
-			return entityUpdateResponse{}, err
-		}
-	}
-	if event != nil {
-		publishProjectEvent(channels, *event)
-	}
-
-	return response, nil
-}
-
-func (h *hub) updateSyntax(projectID string, req updateSyntaxRequest) (entityUpdateResponse, error) {
-	projectID = sanitizeProjectID(projectID)
-	id, syntaxRaw, err := parseEntityItem(req.Syntax, "syntax")
-	if err != nil {
-		return entityUpdateResponse{}, err
-	}
-
-	var (
-		response    entityUpdateResponse
-		persistCopy *projectState
-		channels    []chan projectEvent
-		event       *projectEvent
-	)
-
-	h.mu.Lock()
-	state, err := h.getOrCreateProjectStateLocked(projectID)
-	if err != nil {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, err
-	}
-
-	if req.BaseVersion == nil {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, errors.New("missing baseVersion")
-	}
-
-	currentVersion := state.SyntaxVersions[id]
-	currentSyntax, hasSyntax := state.Syntaxes[id]
-	if *req.BaseVersion != currentVersion {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, &entityConflictError{
-			ExpectedVersion: *req.BaseVersion,
-			CurrentVersion:  currentVersion,
-			ProjectVersion:  state.Doc.Version,
-			Entity:          "syntax",
-			EntityID:        id,
-			EntityDeleted:   !hasSyntax,
-			UpdatedAt:       state.Doc.UpdatedAt,
-			Payload:         cloneRawMessage(currentSyntax),
-		}
-	}
-
-	nextVersion := currentVersion
-	if !hasSyntax {
-		nextVersion = 1
-	} else if string(currentSyntax) != string(syntaxRaw) {
-		if nextVersion < 1 {
-			nextVersion = 1
-		} else {
-			nextVersion++
-		}
-	}
-
-	if !hasSyntax || string(currentSyntax) != string(syntaxRaw) {
-		state.Syntaxes[id] = syntaxRaw
-		state.SyntaxVersions[id] = nextVersion
-		if err := applyProjectMutation(state, projectID); err != nil {
-			h.mu.Unlock()
-			return entityUpdateResponse{}, err
-		}
-		persistCopy = cloneProjectStateForPersist(state)
-		channels = collectSubscriberChannels(state)
-		event = &projectEvent{
-			Type:            "syntax_upsert",
-			ClientID:        req.ClientID,
-			Entity:          "syntax",
-			EntityID:        id,
-			EntityVersion:   nextVersion,
-			Payload:         cloneRawMessage(syntaxRaw),
-			projectDocument: state.Doc,
-		}
-	}
-
-	response = entityUpdateResponse{
-		Project:        projectID,
-		Entity:         "syntax",
-		EntityID:       id,
-		Version:        nextVersion,
-		ProjectVersion: state.Doc.Version,
-		UpdatedAt:      state.Doc.UpdatedAt,
-		Payload:        cloneRawMessage(syntaxRaw),
-	}
-	h.mu.Unlock()
-
-	if persistCopy != nil {
-		if err := h.saveProjectStateToDisk(projectID, persistCopy); err != nil {
-			return entityUpdateResponse{}, err
-		}
-	}
-	if event != nil {
-		publishProjectEvent(channels, *event)
-	}
-
-	return response, nil
-}
-
-func (h *hub) deleteSyntax(projectID string, req deleteSyntaxRequest) (entityUpdateResponse, error) {
-	projectID = sanitizeProjectID(projectID)
-	id := strings.TrimSpace(req.ID)
-	if id == "" {
-		return entityUpdateResponse{}, errors.New("missing id")
-	}
-
-	var (
-		response    entityUpdateResponse
-		persistCopy *projectState
-		channels    []chan projectEvent
-		event       *projectEvent
-	)
-
-	h.mu.Lock()
-	state, err := h.getOrCreateProjectStateLocked(projectID)
-	if err != nil {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, err
-	}
-
-	if req.BaseVersion == nil {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, errors.New("missing baseVersion")
-	}
-
-	currentVersion := state.SyntaxVersions[id]
-	currentSyntax, hasSyntax := state.Syntaxes[id]
-	if *req.BaseVersion != currentVersion {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, &entityConflictError{
-			ExpectedVersion: *req.BaseVersion,
-			CurrentVersion:  currentVersion,
-			ProjectVersion:  state.Doc.Version,
-			Entity:          "syntax",
-			EntityID:        id,
-			EntityDeleted:   !hasSyntax,
-			UpdatedAt:       state.Doc.UpdatedAt,
-			Payload:         cloneRawMessage(currentSyntax),
-		}
-	}
-
-	if hasSyntax {
-		delete(state.Syntaxes, id)
-		delete(state.SyntaxVersions, id)
-		if err := applyProjectMutation(state, projectID); err != nil {
-			h.mu.Unlock()
-			return entityUpdateResponse{}, err
-		}
-		persistCopy = cloneProjectStateForPersist(state)
-		channels = collectSubscriberChannels(state)
-		event = &projectEvent{
-			Type:            "syntax_delete",
-			ClientID:        req.ClientID,
-			Entity:          "syntax",
-			EntityID:        id,
-			EntityVersion:   currentVersion,
-			EntityDeleted:   true,
-			projectDocument: state.Doc,
-		}
-	}
-
-	response = entityUpdateResponse{
-		Project:        projectID,
-		Entity:         "syntax",
-		EntityID:       id,
-		Version:        currentVersion,
-		ProjectVersion: state.Doc.Version,
-		Deleted:        true,
-		UpdatedAt:      state.Doc.UpdatedAt,
-	}
-	h.mu.Unlock()
-
-	if persistCopy != nil {
-		if err := h.saveProjectStateToDisk(projectID, persistCopy); err != nil {
-			return entityUpdateResponse{}, err
-		}
-	}
-	if event != nil {
-		publishProjectEvent(channels, *event)
-	}
-
-	return response, nil
-}
-
-func (h *hub) updateMetrics(projectID string, req updateMetricsRequest) (entityUpdateResponse, error) {
-	projectID = sanitizeProjectID(projectID)
-	metricsRaw, err := normalizedRawObject(req.Metrics, "metrics")
-	if err != nil {
-		return entityUpdateResponse{}, err
-	}
-
-	var (
-		response    entityUpdateResponse
-		persistCopy *projectState
-		channels    []chan projectEvent
-		event       *projectEvent
-	)
-
-	h.mu.Lock()
-	state, err := h.getOrCreateProjectStateLocked(projectID)
-	if err != nil {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, err
-	}
-
-	if req.BaseVersion == nil {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, errors.New("missing baseVersion")
-	}
-
-	currentVersion := state.MetricsVersion
-	if *req.BaseVersion != currentVersion {
-		h.mu.Unlock()
-		return entityUpdateResponse{}, &entityConflictError{
-			ExpectedVersion: *req.BaseVersion,
-			CurrentVersion:  currentVersion,
-			ProjectVersion:  state.Doc.Version,
-			Entity:          "metrics",
-			EntityID:        "",
-			EntityDeleted:   false,
-			UpdatedAt:       state.Doc.UpdatedAt,
-			Payload:         cloneRawMessage(state.Metrics),
-		}
-	}
-
-	nextVersion := currentVersion
-	if string(state.Metrics) != string(metricsRaw) {
-		if nextVersion < 1 {
-			nextVersion = 1
-		} else {
-			nextVersion++
-		}
-		state.Metrics = metricsRaw
-		state.MetricsVersion = nextVersion
-		if err := applyProjectMutation(state, projectID); err != nil {
-			h.mu.Unlock()
-			return entityUpdateResponse{}, err
-		}
-		persistCopy = cloneProjectStateForPersist(state)
-		channels = collectSubscriberChannels(state)
-		event = &projectEvent{
-			Type:            "metrics_update",
-			ClientID:        req.ClientID,
-			Entity:          "metrics",
-			EntityVersion:   nextVersion,
-			Payload:         cloneRawMessage(metricsRaw),
-			projectDocument: state.Doc,
-		}
-	}
-
-	response = entityUpdateResponse{
-		Project:        projectID,
-		Entity:         "metrics",
-		Version:        nextVersion,
-		ProjectVersion: state.Doc.Version,
-		UpdatedAt:      state.Doc.UpdatedAt,
-		Payload:        cloneRawMessage(state.Metrics),
-	}
-	h.mu.Unlock()
-
-	if persistCopy != nil {
-		if err := h.saveProjectStateToDisk(projectID, persistCopy); err != nil {
-			return entityUpdateResponse{}, err
-		}
-	}
-	if event != nil {
-		publishProjectEvent(channels, *event)
-	}
-
-	return response, nil
-}
-
-type server struct {
-	hub         *hub
-	allowOrigin string
-	uiDir       string
-	uiFS        fs.FS
-	appVersion  string
-	appSHA      string
-}
-
-func resolveGitSHA() string {
-	if sha := resolveGitSHAFromBuildInfo(); sha != "" {
-		return sha
-	}
-	if sha := resolveGitSHAFromGit(); sha != "" {
-		return sha
-	}
-	return "unknown"
-}
+	return "unknown"
+}
 
 func resolveGitSHAFromBuildInfo() string {
 	buildInfo, ok := debug.ReadBuildInfo()
@@ -2080,6 +1754,7 @@ func (s *server) handleProject(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
 			return
 		}
+		req.DryRun = isDryRun(r)
 
 		doc, err := s.hub.updateProject(projectID, req)
 		if err != nil {
@@ -2090,9 +1765,18 @@ func (s *server) handleProject(w http.ResponseWriter, r *http.Request) {
 				_ = json.NewEncoder(w).Encode(conflictErr.Current)
 				return
 			}
+			var validationErr *validationError
+			if errors.As(err, &validationErr) {
+				writeValidationError(w, validationErr)
+				return
+			}
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		if req.DryRun {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(doc)
 	default:
@@ -2153,6 +1837,20 @@ func (s *server) handleRevisions(w http.ResponseWriter, r *http.Request) {
 
 	switch r.Method {
 	case http.MethodGet:
+		if tag := strings.TrimSpace(r.URL.Query().Get("tag")); tag != "" {
+			revision, err := s.hub.findTaggedRevision(projectID, tag)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if revision == nil {
+				http.Error(w, "tag not found", http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(revisionMetaFromDocument(*revision))
+			return
+		}
 		resp, err := s.hub.getRevisions(projectID)
 		if err != nil {
 			if errors.Is(err, os.ErrNotExist) {
@@ -2179,145 +1877,30 @@ func (s *server) handleRevisions(w http.ResponseWriter, r *http.Request) {
 				http.Error(w, "project not found", http.StatusNotFound)
 				return
 			}
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(resp)
-	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
-	}
-}
-
-func (s *server) handleRevisionRevert(w http.ResponseWriter, r *http.Request) {
-	s.writeCORS(w, r)
-	if r.Method == http.MethodOptions {
-		w.WriteHeader(http.StatusNoContent)
-		return
-	}
-	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		return
-	}
-
-	projectID := sanitizeProjectID(r.URL.Query().Get("project"))
-	if projectID == "" {
-		projectID = "default"
-	}
-
-	defer func() {
-		_ = r.Body.Close()
-	}()
-	var req revertRevisionRequest
-	if err := decodeRequestBody(w, r, &req); err != nil {
-		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
-		return
-	}
-
-	resp, err := s.hub.revertRevision(projectID, req.ID, req.ClientID)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			http.Error(w, "revision not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
-}
-
-func (s *server) handleGlyph(w http.ResponseWriter, r *http.Request) {
-	s.writeCORS(w, r)
-	if r.Method == http.MethodOptions {
-		w.WriteHeader(http.StatusNoContent)
-		return
-	}
-
-	projectID := sanitizeProjectID(r.URL.Query().Get("project"))
-	if projectID == "" {
-		projectID = "default"
-	}
-
-	switch r.Method {
-	case http.MethodPut:
-		defer func() {
-			_ = r.Body.Close()
-		}()
-		var req updateGlyphRequest
-		if err := decodeRequestBody(w, r, &req); err != nil {
-			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
-			return
-		}
-		resp, err := s.hub.updateGlyph(projectID, req)
-		if err != nil {
-			var conflictErr *entityConflictError
-			if errors.As(err, &conflictErr) {
-				writeEntityConflict(w, projectID, conflictErr)
-				return
-			}
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(resp)
-	case http.MethodDelete:
-		defer func() {
-			_ = r.Body.Close()
-		}()
-		var req deleteGlyphRequest
-		if err := decodeRequestBody(w, r, &req); err != nil {
-			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
-			return
-		}
-		resp, err := s.hub.deleteGlyph(projectID, req)
-		if err != nil {
-			var conflictErr *entityConflictError
-			if errors.As(err, &conflictErr) {
-				writeEntityConflict(w, projectID, conflictErr)
+			var lintErr *lintFailedError
+			if errors.As(err, &lintErr) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_ = json.NewEncoder(w).Encode(lintErr.Report)
 				return
 			}
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeRevisionError(w, err)
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(resp)
-	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
-	}
-}
-
-func (s *server) handleSyntax(w http.ResponseWriter, r *http.Request) {
-	s.writeCORS(w, r)
-	if r.Method == http.MethodOptions {
-		w.WriteHeader(http.StatusNoContent)
-		return
-	}
-
-	projectID := sanitizeProjectID(r.URL.Query().Get("project"))
-	if projectID == "" {
-		projectID = "default"
-	}
-
-	switch r.Method {
 	case http.MethodPut:
 		defer func() {
 			_ = r.Body.Close()
 		}()
-		var req updateSyntaxRequest
+		var req tagRevisionRequest
 		if err := decodeRequestBody(w, r, &req); err != nil {
 			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
 			return
 		}
-		resp, err := s.hub.updateSyntax(projectID, req)
+		resp, err := s.hub.tagRevision(projectID, req)
 		if err != nil {
-			var conflictErr *entityConflictError
-			if errors.As(err, &conflictErr) {
-				writeEntityConflict(w, projectID, conflictErr)
-				return
-			}
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeRevisionError(w, err)
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -2326,35 +1909,28 @@ func (s *server) handleSyntax(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			_ = r.Body.Close()
 		}()
-		var req deleteSyntaxRequest
+		var req deleteRevisionRequest
 		if err := decodeRequestBody(w, r, &req); err != nil {
 			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
 			return
 		}
-		resp, err := s.hub.deleteSyntax(projectID, req)
-		if err != nil {
-			var conflictErr *entityConflictError
-			if errors.As(err, &conflictErr) {
-				writeEntityConflict(w, projectID, conflictErr)
-				return
-			}
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		if err := s.hub.deleteRevision(projectID, req.ID); err != nil {
+			writeRevisionError(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(resp)
+		w.WriteHeader(http.StatusNoContent)
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
 	}
 }
 
-func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+func (s *server) handleRevisionRevert(w http.ResponseWriter, r *http.Request) {
 	s.writeCORS(w, r)
 	if r.Method == http.MethodOptions {
 		w.WriteHeader(http.StatusNoContent)
 		return
 	}
-	if r.Method != http.MethodPut {
+	if r.Method != http.MethodPost {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
@@ -2367,21 +1943,30 @@ func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	defer func() {
 		_ = r.Body.Close()
 	}()
-	var req updateMetricsRequest
+	var req revertRevisionRequest
 	if err := decodeRequestBody(w, r, &req); err != nil {
 		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
 		return
 	}
-	resp, err := s.hub.updateMetrics(projectID, req)
+
+	var (
+		resp projectResponse
+		err  error
+	)
+	if req.partial() {
+		resp, err = s.hub.restoreRevisionEntities(projectID, req)
+	} else {
+		resp, err = s.hub.revertRevision(projectID, req.ID, req.ClientID)
+	}
 	if err != nil {
-		var conflictErr *entityConflictError
-		if errors.As(err, &conflictErr) {
-			writeEntityConflict(w, projectID, conflictErr)
+		if errors.Is(err, os.ErrNotExist) {
+			http.Error(w, "revision not found", http.StatusNotFound)
 			return
 		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(resp)
 }
@@ -2610,10 +2195,22 @@ func (s *server) routes() http.Handler {
 	mux.HandleFunc("/api/project-version", s.handleProjectVersion)
 	mux.HandleFunc("/api/revisions", s.handleRevisions)
 	mux.HandleFunc("/api/revisions/revert", s.handleRevisionRevert)
-	mux.HandleFunc("/api/glyph", s.handleGlyph)
-	mux.HandleFunc("/api/syntax", s.handleSyntax)
-	mux.HandleFunc("/api/metrics", s.handleMetrics)
+	mux.HandleFunc("/api/revisions/diff", s.handleRevisionDiff)
+	mux.HandleFunc("/api/history", s.handleHistory)
+	mux.HandleFunc("/api/branches", s.handleBranches)
+	mux.HandleFunc("/api/branches/merge", s.handleBranchMerge)
+	for _, kind := range entityKinds {
+		mux.HandleFunc("/api/"+kind.Name, s.handleEntity(kind))
+	}
 	mux.HandleFunc("/api/events", s.handleEvents)
+	mux.HandleFunc("/api/export", s.handleExport)
+	mux.HandleFunc("/api/convert", s.handleConvert)
+	mux.HandleFunc("/api/banner", s.handleBanner)
+	mux.HandleFunc("/api/import", s.handleImport)
+	mux.HandleFunc("/api/features", s.handleFeatures)
+	mux.HandleFunc("/api/lint", s.handleLint)
+	mux.HandleFunc("/api/compose", s.handleCompose)
+	mux.HandleFunc("/api/coverage", s.handleCoverage)
 
 	if s.uiFS != nil {
 		mux.HandleFunc("/", s.handleUI)
@@ -2714,17 +2311,30 @@ func runCLI(args []string) error {
 		return nil
 	case args[0] == "serve":
 		return serveCommand(args[1:])
+	case args[0] == "build":
+		return buildCommand(args[1:])
+	case args[0] == "woff":
+		return woffCommand(args[1:])
+	case args[0] == "import":
+		return importCommand(args[1:])
+	case args[0] == "features":
+		return featuresCommand(args[1:])
 	default:
 		return serveCommand(args)
 	}
 }
 
 func printUsage() {
-	fmt.Print(`chirone serves the embedded Chirone web app and collaboration API.
+	fmt.Print(`chirone serves the embedded Chirone web app and collaboration API,
+and builds font files from stored projects.
 
 Usage:
   chirone
   chirone serve [flags]
+  chirone build [build flags]
+  chirone woff [woff flags] font.ttf...
+  chirone import [import flags] font.ufo...
+  chirone features [features flags]
   chirone version
 
 Flags:
@@ -2736,6 +2346,48 @@ Flags:
         CORS allowed origin (or * for all) (default "*")
   --ui-dir string
         optional directory to serve static UI files from instead of embedded assets
+
+Build flags:
+  --data-dir string
+        directory where project snapshots are stored (default "./data")
+  --project string
+        project to build (default "default")
+  --syntax string
+        syntax id or name to build (default: all syntaxes)
+  --format string
+        comma-separated font formats: ttf, otf, woff, woff2, bdf, pcf, psf, psf.gz, flf, gfx, go, ufo, ufoz (default "ttf")
+  --out string
+        directory where font files are written (default "./fonts")
+  --metadata string
+        JSON file with font metadata (default: the project metadata)
+  --scale int
+        pixels per grid cell for bitmap formats (default 1)
+  --fill string
+        character drawn for non-void cells in FIGlet fonts (default "#")
+  --go-package string
+        package name of generated Go bitmap tables (default "fonts")
+
+Woff flags:
+  --format string
+        comma-separated web font formats: woff, woff2 (default "woff,woff2")
+  --out string
+        directory where web fonts are written (default: next to each input)
+
+Import flags:
+  --data-dir string
+        directory where project snapshots are stored (default "./data")
+  --project string
+        project to restore (default "default")
+
+Features flags:
+  --data-dir string
+        directory where project snapshots are stored (default "./data")
+  --project string
+        project to read (default "default")
+  --out string
+        file where the feature file is written (default: stdout)
+  --metadata string
+        JSON file with font metadata (default: the project metadata)
 `)
 }
 
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// saveTestGlyphs saves the glyphs as the whole glyph list of a project.
+func saveTestGlyphs(t *testing.T, h *hub, projectID string, glyphs ...glyphDocument) projectDocument {
+	t.Helper()
+	raw, err := json.Marshal(glyphs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, _, err := h.getProject(projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved, err := h.updateProject(projectID, updateProjectRequest{
+		BaseVersion:     &doc.Version,
+		projectSnapshot: projectSnapshot{Glyphs: raw},
+	})
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	return saved
+}
+
+func createTestRevision(t *testing.T, h *hub, projectID, message string, tags ...string) revisionMeta {
+	t.Helper()
+	created, err := h.createRevision(projectID, createRevisionRequest{Message: message, Tags: tags})
+	if err != nil {
+		t.Fatalf("create revision %q: %v", message, err)
+	}
+	return created.Revision
+}
+
+// projectGlyphStructures returns the structures of the project glyphs by id.
+func projectGlyphStructures(t *testing.T, h *hub, projectID string) map[string]string {
+	t.Helper()
+	doc, _, err := h.getProject(projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	glyphs, err := decodeGlyphDocuments(doc.Glyphs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	structures := map[string]string{}
+	for _, glyph := range glyphs {
+		structures[glyph.ID] = glyph.Structure
+	}
+	return structures
+}
+
+func TestRestoreRevisionEntities(t *testing.T) {
+	h := newHub(t.TempDir())
+	saveTestGlyphs(t, h, "p",
+		glyphDocument{ID: "1", Name: "e", Structure: "##"},
+		glyphDocument{ID: "2", Name: "o", Structure: "##"},
+	)
+	createTestRevision(t, h, "p", "first", "v1")
+	saved := saveTestGlyphs(t, h, "p",
+		glyphDocument{ID: "1", Name: "e", Structure: "#"},
+		glyphDocument{ID: "2", Name: "o", Structure: "#"},
+		glyphDocument{ID: "3", Name: "z", Structure: "#"},
+	)
+
+	// e comes back, z was added after the revision and goes, o keeps its edit.
+	response, err := h.restoreRevisionEntities("p", revertRevisionRequest{ID: "v1", Glyphs: []string{"1", "3", "1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Version != saved.Version+1 {
+		t.Errorf("version = %d, want %d", response.Version, saved.Version+1)
+	}
+	want := map[string]string{"1": "##", "2": "#"}
+	got := projectGlyphStructures(t, h, "p")
+	if len(got) != len(want) {
+		t.Fatalf("glyphs = %v, want %v", got, want)
+	}
+	for id, structure := range want {
+		if got[id] != structure {
+			t.Errorf("glyph %s = %q, want %q", id, got[id], structure)
+		}
+	}
+
+	again, err := h.restoreRevisionEntities("p", revertRevisionRequest{ID: "v1", Glyphs: []string{"1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Version != response.Version {
+		t.Errorf("restoring an unchanged glyph wrote version %d", again.Version)
+	}
+	if _, err := h.restoreRevisionEntities("p", revertRevisionRequest{ID: "v1", Glyphs: []string{"9"}}); err == nil {
+		t.Error("restoring a glyph in neither side did not fail")
+	}
+}
+
+func TestCreateRevisionRequiresCleanLint(t *testing.T) {
+	h := newHub(t.TempDir())
+	// a.ss01 has no a to be an alternate of.
+	saveTestGlyphs(t, h, "p", glyphDocument{ID: "1", Name: "a.ss01", Structure: "#"})
+
+	_, err := h.createRevision("p", createRevisionRequest{Message: "gated", RequireCleanLint: true})
+	var lintErr *lintFailedError
+	if !errors.As(err, &lintErr) || lintErr.Report.Errors != 1 || lintErr.Report.Findings[0].Code != "missing-base" {
+		t.Fatalf("err = %v, want the missing base reported", err)
+	}
+	recorder := serveTestRequest(t, h, http.MethodPost, "/api/revisions?project=p", `{"message": "gated", "requireCleanLint": true}`)
+	var report lintReport
+	if recorder.Code != http.StatusUnprocessableEntity || json.Unmarshal(recorder.Body.Bytes(), &report) != nil || report.Clean {
+		t.Fatalf("status = %d: %s, want the lint report", recorder.Code, recorder.Body)
+	}
+	if revisions, err := h.getRevisions("p"); err != nil || len(revisions.Revisions) != 0 {
+		t.Fatalf("revisions = %+v, %v; want none", revisions, err)
+	}
+
+	// Without the gate, or once the errors are fixed, the revision is taken.
+	createTestRevision(t, h, "p", "ungated")
+	saveTestGlyphs(t, h, "p",
+		glyphDocument{ID: "1", Name: "a.ss01", Structure: "#"},
+		glyphDocument{ID: "2", Name: "a", Structure: "#"},
+	)
+	if _, err := h.createRevision("p", createRevisionRequest{Message: "clean", RequireCleanLint: true}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMetadataWrite(t *testing.T) {
+	h := newHub(t.TempDir())
+	events := make(chan projectEvent, 4)
+	if _, _, err := h.subscribe("p", events); err != nil {
+		t.Fatal(err)
+	}
+	defer h.unsubscribe("p", events)
+
+	recorder := serveTestRequest(t, h, http.MethodPut, "/api/metadata?project=p",
+		`{"clientId": "c1", "baseVersion": 0, "metadata": {"familyName": "Meta"}}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", recorder.Code, recorder.Body)
+	}
+	var metadata fontMetadata
+	select {
+	case event := <-events:
+		if event.Type != "metadata_update" || event.Entity != "metadata" || event.ClientID != "c1" || event.EntityVersion != 1 {
+			t.Errorf("event = %+v", event)
+		}
+		if err := json.Unmarshal(event.Payload, &metadata); err != nil || metadata.FamilyName != "Meta" {
+			t.Errorf("event payload = %s", event.Payload)
+		}
+	default:
+		t.Fatal("no event for the metadata write")
+	}
+
+	// A client that has not seen version 1 gets it back instead.
+	recorder = serveTestRequest(t, h, http.MethodPut, "/api/metadata?project=p",
+		`{"clientId": "c2", "baseVersion": 0, "metadata": {"familyName": "Stale"}}`)
+	var conflict entityUpdateResponse
+	if recorder.Code != http.StatusConflict || json.Unmarshal(recorder.Body.Bytes(), &conflict) != nil {
+		t.Fatalf("status = %d: %s, want a conflict", recorder.Code, recorder.Body)
+	}
+	if err := json.Unmarshal(conflict.Payload, &metadata); err != nil || conflict.Version != 1 || metadata.FamilyName != "Meta" {
+		t.Errorf("conflict = %+v, want version 1 with the stored metadata", conflict)
+	}
+	select {
+	case event := <-events:
+		t.Errorf("the rejected write sent %+v", event)
+	default:
+	}
+}
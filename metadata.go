@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// fontMetadata mirrors FontMetadata in src/lib/GTL/metadata.ts.
+type fontMetadata struct {
+	Name            string `json:"name"`
+	FamilyName      string `json:"familyName"`
+	Version         string `json:"version"`
+	CreatedDate     string `json:"createdDate"`
+	Designer        string `json:"designer"`
+	Manufacturer    string `json:"manufacturer"`
+	DesignerURL     string `json:"designerURL"`
+	ManufacturerURL string `json:"manufacturerURL"`
+	License         string `json:"license"`
+	VendorID        string `json:"vendorID"`
+	GlyphOrder      string `json:"glyphOrder"`
+}
+
+var (
+	isoDatePattern     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	vendorStripPattern = regexp.MustCompile(`[^A-Z0-9]`)
+)
+
+func metadataString(values map[string]any, key, fallback string) string {
+	value, ok := values[key].(string)
+	if !ok {
+		return fallback
+	}
+	return strings.TrimSpace(value)
+}
+
+func metadataVendorID(values map[string]any, fallback string) string {
+	value, ok := values["vendorID"].(string)
+	if !ok {
+		return fallback
+	}
+	normalized := vendorStripPattern.ReplaceAllString(strings.ToUpper(strings.TrimSpace(value)), "")
+	if normalized == "" {
+		return fallback
+	}
+	if len(normalized) > 4 {
+		normalized = normalized[:4]
+	}
+	return normalized
+}
+
+func metadataDate(values map[string]any, fallback string) string {
+	value, ok := values["createdDate"].(string)
+	if !ok {
+		return fallback
+	}
+	trimmed := strings.TrimSpace(value)
+	if !isoDatePattern.MatchString(trimmed) {
+		return fallback
+	}
+	if _, err := time.Parse("2006-01-02", trimmed); err != nil {
+		return fallback
+	}
+	return trimmed
+}
+
+// normalizeFontMetadata is the Go counterpart of normalizeFontMetadata in
+// metadata.ts. Anything that is not a JSON object yields the defaults.
+func normalizeFontMetadata(raw json.RawMessage) fontMetadata {
+	values := map[string]any{}
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &values)
+	}
+	today := time.Now().Format("2006-01-02")
+	return fontMetadata{
+		Name:            metadataString(values, "name", ""),
+		FamilyName:      metadataString(values, "familyName", "GTL"),
+		Version:         metadataString(values, "version", "Version 1.0"),
+		CreatedDate:     metadataDate(values, today),
+		Designer:        metadataString(values, "designer", ""),
+		Manufacturer:    metadataString(values, "manufacturer", ""),
+		DesignerURL:     metadataString(values, "designerURL", ""),
+		ManufacturerURL: metadataString(values, "manufacturerURL", "https://sssuper.io"),
+		License:         metadataString(values, "license", ""),
+		VendorID:        metadataVendorID(values, "SSSU"),
+		GlyphOrder:      metadataString(values, "glyphOrder", ""),
+	}
+}
+
+// metadataWithCreatedDate fills in createdDate when raw metadata lacks a
+// valid one, so rebuilding unchanged data yields identical font files.
+func metadataWithCreatedDate(raw json.RawMessage, date string) json.RawMessage {
+	values := map[string]any{}
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &values)
+	}
+	if metadataDate(values, "") != "" {
+		return raw
+	}
+	if values == nil {
+		values = map[string]any{}
+	}
+	values["createdDate"] = date
+	out, err := json.Marshal(values)
+	if err != nil {
+		return raw
+	}
+	return out
+}
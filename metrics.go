@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// fontMetrics mirrors FontMetrics in src/lib/GTL/metrics.ts. Every value but
+// UPM is expressed in grid cells.
+type fontMetrics struct {
+	UPM       int `json:"UPM"`
+	Height    int `json:"height"`
+	Baseline  int `json:"baseline"`
+	Descender int `json:"descender"`
+	Ascender  int `json:"ascender"`
+	CapHeight int `json:"capHeight"`
+	XHeight   int `json:"xHeight"`
+}
+
+const (
+	defaultMetricsHeight    = 5
+	defaultMetricsDescender = 1
+	defaultMetricsUPM       = 5 * 5 * 5 * 2 * 2 * 5
+	maxSafeInteger          = 1<<53 - 1
+)
+
+// jsRound reproduces Math.round, which rounds halves towards +Infinity.
+func jsRound(value float64) float64 {
+	return math.Floor(value + 0.5)
+}
+
+func toDiscrete(value, min, max float64) float64 {
+	return math.Min(max, math.Max(min, jsRound(value)))
+}
+
+func snapUPMToDiscreteCellGrid(upm, height float64) float64 {
+	safeHeight := math.Max(1, jsRound(height))
+	safeUPM := math.Max(safeHeight, jsRound(upm))
+	snapped := jsRound(safeUPM/safeHeight) * safeHeight
+	return math.Max(safeHeight, snapped)
+}
+
+type verticalMetrics struct {
+	height    float64
+	descender float64
+	ascender  float64
+	capHeight float64
+	xHeight   float64
+}
+
+func estimateVerticalMetrics(height, descender float64) verticalMetrics {
+	safeHeight := math.Max(1, jsRound(height))
+	safeDescender := toDiscrete(descender, 0, math.Max(0, safeHeight-1))
+	ascender := math.Max(1, safeHeight-safeDescender)
+	capHeight := toDiscrete(jsRound(ascender*0.9), 1, ascender)
+	xHeight := toDiscrete(jsRound(capHeight*0.7), 1, capHeight)
+	return verticalMetrics{
+		height:    safeHeight,
+		descender: safeDescender,
+		ascender:  ascender,
+		capHeight: capHeight,
+		xHeight:   xHeight,
+	}
+}
+
+func finiteMetricValue(values map[string]any, key string) (float64, bool) {
+	value, ok := values[key].(float64)
+	if !ok || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false
+	}
+	return value, true
+}
+
+// normalizeFontMetrics is the Go counterpart of normalizeFontMetrics in
+// metrics.ts: it snaps UPM to the cell grid and clamps the vertical metrics.
+// Anything that is not a JSON object is treated as missing input.
+func normalizeFontMetrics(raw json.RawMessage) fontMetrics {
+	values := map[string]any{}
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &values)
+	}
+
+	rawAscender, hasAscender := finiteMetricValue(values, "ascender")
+	rawDescender, hasDescender := finiteMetricValue(values, "descender")
+	if !hasDescender {
+		rawDescender, hasDescender = finiteMetricValue(values, "baseline")
+	}
+	rawHeight, hasHeight := finiteMetricValue(values, "height")
+
+	height := float64(defaultMetricsHeight)
+	if hasHeight {
+		height = jsRound(rawHeight)
+	} else if hasAscender && hasDescender {
+		height = math.Max(1, jsRound(rawAscender+rawDescender))
+	}
+	height = math.Max(1, height)
+
+	descender := float64(defaultMetricsDescender)
+	if hasDescender {
+		descender = rawDescender
+	}
+	descender = toDiscrete(descender, 0, math.Max(0, height-1))
+	vertical := estimateVerticalMetrics(height, descender)
+
+	capHeight, ok := finiteMetricValue(values, "capHeight")
+	if !ok {
+		capHeight = vertical.capHeight
+	}
+	capHeight = toDiscrete(capHeight, 1, vertical.ascender)
+
+	xHeight, ok := finiteMetricValue(values, "xHeight")
+	if !ok {
+		xHeight = jsRound(capHeight * 0.7)
+	}
+	xHeight = toDiscrete(xHeight, 1, capHeight)
+
+	upm, ok := finiteMetricValue(values, "UPM")
+	if !ok {
+		upm = defaultMetricsUPM
+	}
+	requestedUPM := math.Max(height, toDiscrete(upm, height, maxSafeInteger))
+
+	return fontMetrics{
+		UPM:       int(snapUPMToDiscreteCellGrid(requestedUPM, vertical.height)),
+		Height:    int(vertical.height),
+		Baseline:  int(vertical.descender),
+		Descender: int(vertical.descender),
+		Ascender:  int(vertical.ascender),
+		CapHeight: int(capHeight),
+		XHeight:   int(xHeight),
+	}
+}
+
+func (m fontMetrics) unitsPerCell() int {
+	safeHeight := math.Max(1, float64(m.Height))
+	return int(jsRound(float64(m.UPM) / safeHeight))
+}
+
+func (m fontMetrics) cellsToUnits(cells float64) int {
+	return int(jsRound(float64(m.unitsPerCell()) * cells))
+}
+
+// metricsKeys are the fields of FontMetrics in metrics.ts.
+var metricsKeys = []string{"UPM", "height", "baseline", "descender", "ascender", "capHeight", "xHeight"}
+
+// validateMetrics rejects metrics whose known fields are not numbers, which
+// normalizeFontMetrics would silently replace with defaults.
+func validateMetrics(raw json.RawMessage) error {
+	problems := &validationError{Entity: "metrics"}
+	values := map[string]any{}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		problems.add("", "must be a JSON object")
+		return problems
+	}
+	for _, key := range metricsKeys {
+		value, ok := values[key]
+		if !ok || value == nil {
+			continue
+		}
+		if _, isNumber := value.(float64); !isNumber {
+			problems.add(key, "must be a number")
+		}
+	}
+	return problems.result()
+}
+
+// normalizedMetricsRaw stores metrics the way every client normalizes them,
+// so a write from an outdated client cannot spread unsnapped values.
+func normalizedMetricsRaw(raw json.RawMessage) (json.RawMessage, error) {
+	object, err := normalizedRawObject(raw, "metrics")
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := json.Marshal(normalizeFontMetrics(object))
+	if err != nil {
+		return nil, err
+	}
+	return normalizedRawObject(normalized, "metrics")
+}
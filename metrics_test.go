@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNormalizeFontMetrics(t *testing.T) {
+	defaults := fontMetrics{UPM: 2500, Height: 5, Baseline: 1, Descender: 1, Ascender: 4, CapHeight: 4, XHeight: 3}
+	tests := []struct {
+		name string
+		raw  string
+		want fontMetrics
+	}{
+		{"missing fields", `{}`, defaults},
+		{"not an object", `[5]`, defaults},
+		{"wrong types", `{"height": "7", "descender": null}`, defaults},
+		{"baseline as descender", `{"height": 5, "baseline": 2}`,
+			fontMetrics{UPM: 2500, Height: 5, Baseline: 2, Descender: 2, Ascender: 3, CapHeight: 3, XHeight: 2}},
+		{"negative descender", `{"height": 5, "descender": -2}`,
+			fontMetrics{UPM: 2500, Height: 5, Baseline: 0, Descender: 0, Ascender: 5, CapHeight: 5, XHeight: 4}},
+		{"descender above height", `{"height": 3, "descender": 7}`,
+			fontMetrics{UPM: 2499, Height: 3, Baseline: 2, Descender: 2, Ascender: 1, CapHeight: 1, XHeight: 1}},
+		// Without a height it follows from ascender and descender, and the
+		// ascender is then what the height leaves above the descender.
+		{"ascender below descender", `{"ascender": 1, "descender": 3}`,
+			fontMetrics{UPM: 2500, Height: 4, Baseline: 3, Descender: 3, Ascender: 1, CapHeight: 1, XHeight: 1}},
+		{"non-integer values", `{"UPM": 1001.7, "height": 5.6, "descender": 1.4, "capHeight": 9.2, "xHeight": 2.5}`,
+			fontMetrics{UPM: 1002, Height: 6, Baseline: 1, Descender: 1, Ascender: 5, CapHeight: 5, XHeight: 3}},
+		{"UPM below height", `{"UPM": 2, "height": 8, "descender": 2}`,
+			fontMetrics{UPM: 8, Height: 8, Baseline: 2, Descender: 2, Ascender: 6, CapHeight: 5, XHeight: 4}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := normalizeFontMetrics(json.RawMessage(test.raw)); got != test.want {
+				t.Errorf("normalizeFontMetrics(%s) = %+v, want %+v", test.raw, got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateMetrics(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{`{}`, ""},
+		{`{"height": 5.5, "descender": null, "unknown": "x"}`, ""},
+		{`{"height": "5", "UPM": true}`, "invalid metrics: UPM: must be a number; height: must be a number"},
+		{`[5]`, "invalid metrics: must be a JSON object"},
+	}
+	for _, test := range tests {
+		if got := validationMessages(t, validateMetrics(json.RawMessage(test.raw))); got != test.want {
+			t.Errorf("validateMetrics(%s) = %q, want %q", test.raw, got, test.want)
+		}
+	}
+}
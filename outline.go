@@ -0,0 +1,470 @@
+package main
+
+import (
+	"math"
+	"strings"
+)
+
+// Outline geometry for the server-side font compiler. Coordinates follow the
+// paper.js space used by createFont.ts: "top" is the smaller y, which ends up
+// at the bottom of the glyph once the outline is written to the font.
+
+type outlinePoint struct {
+	X float64
+	Y float64
+}
+
+func (p outlinePoint) add(q outlinePoint) outlinePoint {
+	return outlinePoint{p.X + q.X, p.Y + q.Y}
+}
+
+func (p outlinePoint) sub(q outlinePoint) outlinePoint {
+	return outlinePoint{p.X - q.X, p.Y - q.Y}
+}
+
+func (p outlinePoint) mul(f float64) outlinePoint {
+	return outlinePoint{p.X * f, p.Y * f}
+}
+
+// outlineSegment is a line (Cubic=false) or a cubic Bézier ending at To.
+type outlineSegment struct {
+	Cubic bool
+	C1    outlinePoint
+	C2    outlinePoint
+	To    outlinePoint
+}
+
+// outlineContour is a closed path starting at Start.
+type outlineContour struct {
+	Start    outlinePoint
+	Segments []outlineSegment
+}
+
+func (c *outlineContour) lineTo(p outlinePoint) {
+	c.Segments = append(c.Segments, outlineSegment{To: p})
+}
+
+func (c *outlineContour) cubicTo(c1, c2, p outlinePoint) {
+	c.Segments = append(c.Segments, outlineSegment{Cubic: true, C1: c1, C2: c2, To: p})
+}
+
+func (c *outlineContour) mapPoints(fn func(outlinePoint) outlinePoint) {
+	c.Start = fn(c.Start)
+	for i := range c.Segments {
+		c.Segments[i].C1 = fn(c.Segments[i].C1)
+		c.Segments[i].C2 = fn(c.Segments[i].C2)
+		c.Segments[i].To = fn(c.Segments[i].To)
+	}
+}
+
+type outlineRect struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+func (r outlineRect) topLeft() outlinePoint     { return outlinePoint{r.X, r.Y} }
+func (r outlineRect) topRight() outlinePoint    { return outlinePoint{r.X + r.Width, r.Y} }
+func (r outlineRect) bottomLeft() outlinePoint  { return outlinePoint{r.X, r.Y + r.Height} }
+func (r outlineRect) bottomRight() outlinePoint { return outlinePoint{r.X + r.Width, r.Y + r.Height} }
+func (r outlineRect) center() outlinePoint {
+	return outlinePoint{r.X + r.Width/2, r.Y + r.Height/2}
+}
+func (r outlineRect) topCenter() outlinePoint  { return outlinePoint{r.X + r.Width/2, r.Y} }
+func (r outlineRect) leftCenter() outlinePoint { return outlinePoint{r.X, r.Y + r.Height/2} }
+
+type outlineTransform struct {
+	ScaleX   float64
+	ScaleY   float64
+	Rotation float64
+}
+
+const (
+	transformEpsilon         = 1e-8
+	cardinalRotationStep     = 90
+	cardinalRotationEpsilon  = 1e-7
+	ellipseKappa             = 0.5522847498307936
+	defaultCurveSquaring     = 0.56
+	contourContainmentSample = 0.5
+)
+
+func normalizeTransformScale(value float64) float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 1
+	}
+	if math.Abs(value-1) <= transformEpsilon {
+		return 1
+	}
+	if math.Abs(value) <= transformEpsilon {
+		return 0
+	}
+	return value
+}
+
+func normalizeTransformRotation(value float64) float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0
+	}
+	snapped := jsRound(value/cardinalRotationStep) * cardinalRotationStep
+	if math.Abs(value-snapped) <= cardinalRotationEpsilon {
+		return snapped
+	}
+	return value
+}
+
+func (t outlineTransform) isIdentity() bool {
+	return t.ScaleX == 1 && t.ScaleY == 1 && t.Rotation == 0
+}
+
+func scaleContour(c *outlineContour, sx, sy float64, center outlinePoint) {
+	c.mapPoints(func(p outlinePoint) outlinePoint {
+		return outlinePoint{center.X + (p.X-center.X)*sx, center.Y + (p.Y-center.Y)*sy}
+	})
+}
+
+func rotateContour(c *outlineContour, degrees float64, center outlinePoint) {
+	radians := degrees * math.Pi / 180
+	cos := math.Cos(radians)
+	sin := math.Sin(radians)
+	c.mapPoints(func(p outlinePoint) outlinePoint {
+		dx := p.X - center.X
+		dy := p.Y - center.Y
+		return outlinePoint{center.X + dx*cos - dy*sin, center.Y + dx*sin + dy*cos}
+	})
+}
+
+func translateContour(c *outlineContour, dx, dy float64) {
+	c.mapPoints(func(p outlinePoint) outlinePoint {
+		return outlinePoint{p.X + dx, p.Y + dy}
+	})
+}
+
+func applyOutlineTransform(contours []outlineContour, t outlineTransform, center outlinePoint) {
+	if t.isIdentity() {
+		return
+	}
+	for i := range contours {
+		if t.ScaleX != 1 || t.ScaleY != 1 {
+			scaleContour(&contours[i], t.ScaleX, t.ScaleY, center)
+		}
+		if t.Rotation != 0 {
+			rotateContour(&contours[i], t.Rotation, center)
+		}
+	}
+}
+
+// signedArea uses the exact Bézier area formula from paper.js, so a positive
+// value means clockwise in paper space (counter-clockwise in font space).
+func (c outlineContour) signedArea() float64 {
+	area := 0.0
+	current := c.Start
+	segments := c.Segments
+	last := c.Start
+	if len(segments) > 0 {
+		last = segments[len(segments)-1].To
+	}
+	if last != c.Start {
+		segments = append(append([]outlineSegment(nil), segments...), outlineSegment{To: c.Start})
+	}
+	for _, segment := range segments {
+		x0, y0 := current.X, current.Y
+		x3, y3 := segment.To.X, segment.To.Y
+		x1, y1, x2, y2 := x0, y0, x3, y3
+		if segment.Cubic {
+			x1, y1 = segment.C1.X, segment.C1.Y
+			x2, y2 = segment.C2.X, segment.C2.Y
+		}
+		area += 3 * ((y3-y0)*(x1+x2) - (x3-x0)*(y1+y2) + y1*(x0-x2) - x1*(y0-y2) + y3*(x2+x0/3) - x3*(y2+y0/3)) / 20
+		current = segment.To
+	}
+	return area
+}
+
+func (c outlineContour) reversed() outlineContour {
+	points := make([]outlinePoint, 0, len(c.Segments)+1)
+	points = append(points, c.Start)
+	for _, segment := range c.Segments {
+		points = append(points, segment.To)
+	}
+	out := outlineContour{Start: points[len(points)-1]}
+	for i := len(c.Segments) - 1; i >= 0; i-- {
+		segment := c.Segments[i]
+		target := points[i]
+		if segment.Cubic {
+			out.cubicTo(segment.C2, segment.C1, target)
+		} else {
+			out.lineTo(target)
+		}
+	}
+	return out
+}
+
+// flatten approximates the contour with a polygon, for containment tests.
+func (c outlineContour) flatten() []outlinePoint {
+	points := []outlinePoint{c.Start}
+	current := c.Start
+	for _, segment := range c.Segments {
+		if !segment.Cubic {
+			points = append(points, segment.To)
+			current = segment.To
+			continue
+		}
+		const steps = 8
+		for i := 1; i <= steps; i++ {
+			points = append(points, cubicPointAt(current, segment.C1, segment.C2, segment.To, float64(i)/steps))
+		}
+		current = segment.To
+	}
+	return points
+}
+
+func cubicPointAt(p0, p1, p2, p3 outlinePoint, t float64) outlinePoint {
+	mt := 1 - t
+	a := mt * mt * mt
+	b := 3 * mt * mt * t
+	cc := 3 * mt * t * t
+	d := t * t * t
+	return outlinePoint{
+		a*p0.X + b*p1.X + cc*p2.X + d*p3.X,
+		a*p0.Y + b*p1.Y + cc*p2.Y + d*p3.Y,
+	}
+}
+
+func polygonContains(polygon []outlinePoint, p outlinePoint) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a := polygon[i]
+		b := polygon[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// reorientContours is the counterpart of paper's reorient(nonZero, clockwise):
+// outer contours get the requested direction and every nested level
+// alternates, so holes keep punching through with the non-zero rule.
+func reorientContours(contours []outlineContour, clockwise bool) []outlineContour {
+	if len(contours) <= 1 {
+		for i := range contours {
+			if (contours[i].signedArea() >= 0) != clockwise {
+				contours[i] = contours[i].reversed()
+			}
+		}
+		return contours
+	}
+
+	polygons := make([][]outlinePoint, len(contours))
+	for i, contour := range contours {
+		polygons[i] = contour.flatten()
+	}
+	for i := range contours {
+		probe := polygons[i][0]
+		if len(polygons[i]) > 1 {
+			probe = polygons[i][0].add(polygons[i][1].sub(polygons[i][0]).mul(contourContainmentSample))
+		}
+		depth := 0
+		for j := range contours {
+			if i != j && polygonContains(polygons[j], probe) {
+				depth++
+			}
+		}
+		want := clockwise
+		if depth%2 == 1 {
+			want = !clockwise
+		}
+		if (contours[i].signedArea() >= 0) != want {
+			contours[i] = contours[i].reversed()
+		}
+	}
+	return contours
+}
+
+func outlineBounds(contours []outlineContour) (outlineRect, bool) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	include := func(p outlinePoint) {
+		minX = math.Min(minX, p.X)
+		minY = math.Min(minY, p.Y)
+		maxX = math.Max(maxX, p.X)
+		maxY = math.Max(maxY, p.Y)
+	}
+	for _, contour := range contours {
+		include(contour.Start)
+		current := contour.Start
+		for _, segment := range contour.Segments {
+			include(segment.To)
+			if segment.Cubic {
+				for _, t := range cubicExtremaParams(current, segment.C1, segment.C2, segment.To) {
+					include(cubicPointAt(current, segment.C1, segment.C2, segment.To, t))
+				}
+			}
+			current = segment.To
+		}
+	}
+	if math.IsInf(minX, 1) {
+		return outlineRect{}, false
+	}
+	return outlineRect{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}, true
+}
+
+// cubicExtremaParams returns the parameters in (0, 1) where the curve has a
+// horizontal or vertical tangent.
+func cubicExtremaParams(p0, p1, p2, p3 outlinePoint) []float64 {
+	params := []float64{}
+	solve := func(a0, a1, a2, a3 float64) {
+		a := -a0 + 3*a1 - 3*a2 + a3
+		b := 2 * (a0 - 2*a1 + a2)
+		c := a1 - a0
+		if math.Abs(a) < 1e-12 {
+			if math.Abs(b) > 1e-12 {
+				params = append(params, -c/b)
+			}
+			return
+		}
+		disc := b*b - 4*a*c
+		if disc < 0 {
+			return
+		}
+		sq := math.Sqrt(disc)
+		params = append(params, (-b+sq)/(2*a), (-b-sq)/(2*a))
+	}
+	solve(p0.X, p1.X, p2.X, p3.X)
+	solve(p0.Y, p1.Y, p2.Y, p3.Y)
+	out := params[:0]
+	for _, t := range params {
+		if t > 0 && t < 1 {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Shapes, ported from src/lib/GTL/shapes.ts.
+
+func rectangleContour(box outlineRect) outlineContour {
+	contour := outlineContour{Start: box.bottomLeft()}
+	contour.lineTo(box.topLeft())
+	contour.lineTo(box.topRight())
+	contour.lineTo(box.bottomRight())
+	return contour
+}
+
+func circleContour(box outlineRect) outlineContour {
+	radius := math.Min(box.Width, box.Height) / 2
+	center := box.center()
+	k := ellipseKappa * radius
+	left := outlinePoint{center.X - radius, center.Y}
+	top := outlinePoint{center.X, center.Y - radius}
+	right := outlinePoint{center.X + radius, center.Y}
+	bottom := outlinePoint{center.X, center.Y + radius}
+
+	contour := outlineContour{Start: left}
+	contour.cubicTo(outlinePoint{left.X, left.Y - k}, outlinePoint{top.X - k, top.Y}, top)
+	contour.cubicTo(outlinePoint{top.X + k, top.Y}, outlinePoint{right.X, right.Y - k}, right)
+	contour.cubicTo(outlinePoint{right.X, right.Y + k}, outlinePoint{bottom.X + k, bottom.Y}, bottom)
+	contour.cubicTo(outlinePoint{bottom.X - k, bottom.Y}, outlinePoint{left.X, left.Y + k}, left)
+	return contour
+}
+
+func orientContourInBox(contour *outlineContour, box outlineRect, value orientation) {
+	if value == orientationNE || value == orientationNW {
+		scaleContour(contour, 1, -1, box.center())
+	}
+	if value == orientationNW || value == orientationSW {
+		scaleContour(contour, -1, 1, box.center())
+	}
+}
+
+func quarterContour(box outlineRect, squaring float64, negative bool, value orientation) outlineContour {
+	m := box.topRight()
+	corner := box.bottomLeft()
+	if negative {
+		corner = box.topRight()
+	}
+	a := box.topLeft()
+	ah := a.add(m.sub(a).mul(squaring))
+	b := box.bottomRight()
+	bh := b.add(m.sub(b).mul(squaring))
+
+	contour := outlineContour{Start: a}
+	contour.cubicTo(ah, bh, b)
+	contour.lineTo(corner)
+	orientContourInBox(&contour, box, value)
+	return contour
+}
+
+func triangleContour(box outlineRect, value orientation) outlineContour {
+	contour := outlineContour{Start: box.topLeft()}
+	contour.lineTo(box.bottomRight())
+	contour.lineTo(box.bottomLeft())
+	orientContourInBox(&contour, box, value)
+	return contour
+}
+
+func ellipseContours(box outlineRect, squaring float64, negative bool) []outlineContour {
+	basePoints := map[orientation]outlinePoint{
+		orientationSE: box.topCenter(),
+		orientationSW: box.topLeft(),
+		orientationNE: box.center(),
+		orientationNW: box.leftCenter(),
+	}
+	contours := make([]outlineContour, 0, len(orientations))
+	for _, value := range orientations {
+		origin := basePoints[value]
+		quarterBox := outlineRect{X: origin.X, Y: origin.Y, Width: box.Width / 2, Height: box.Height / 2}
+		contours = append(contours, quarterContour(quarterBox, squaring, negative, value))
+	}
+	return contours
+}
+
+// drawRuleShape mirrors drawPath in drawGlyph.ts for one sub-box. Every item
+// of the result is one paper.js path item: a set of contours that is
+// reoriented as a whole, so compound SVG paths keep their holes.
+func drawRuleShape(box outlineRect, rule syntaxRule, props *propEvaluator) [][]outlineContour {
+	shape := rule.Shape
+	switch shape.Kind {
+	case shapeRectangle:
+		return [][]outlineContour{{rectangleContour(box)}}
+	case shapeCircle:
+		return [][]outlineContour{{circleContour(box)}}
+	case shapeEllipse:
+		quarters := ellipseContours(box,
+			props.number(shape, "squaring", defaultCurveSquaring),
+			props.boolean(shape, "negative", false),
+		)
+		items := make([][]outlineContour, 0, len(quarters))
+		for _, quarter := range quarters {
+			items = append(items, []outlineContour{quarter})
+		}
+		return items
+	case shapeQuarter:
+		return [][]outlineContour{{quarterContour(box,
+			props.number(shape, "squaring", defaultCurveSquaring),
+			props.boolean(shape, "negative", false),
+			props.orientation(shape, "orientation", orientationNE),
+		)}}
+	case shapeTriangle:
+		return [][]outlineContour{{triangleContour(box, props.orientation(shape, "orientation", orientationNE))}}
+	case shapeSVG:
+		source := strings.TrimSpace(props.string(shape, "path", ""))
+		if source == "" {
+			// An empty SVG source falls back to a full cell, like the client.
+			return [][]outlineContour{{rectangleContour(box)}}
+		}
+		return svgPathItems(box, source, props.boolean(shape, "negative", false))
+	default:
+		// Void and unknown kinds draw nothing.
+		return nil
+	}
+}
+
+func shapeTransform(shape ruleShape, props *propEvaluator) outlineTransform {
+	return outlineTransform{
+		ScaleX:   normalizeTransformScale(props.number(shape, "scale_x", 1)),
+		ScaleY:   normalizeTransformScale(props.number(shape, "scale_y", 1)),
+		Rotation: normalizeTransformRotation(props.number(shape, "rotation", 0)),
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// XML property lists, as used by UFO. Dicts are written in the order they
+// are built, so generated files diff cleanly; reading yields map[string]any,
+// []any, string, int64, float64 and bool values.
+
+type plistEntry struct {
+	Key   string
+	Value any
+}
+
+type plistDict []plistEntry
+
+const plistHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+`
+
+func encodePlist(value any) ([]byte, error) {
+	var out bytes.Buffer
+	out.WriteString(plistHeader)
+	if err := writePlistValue(&out, value, 0); err != nil {
+		return nil, err
+	}
+	out.WriteString("</plist>\n")
+	return out.Bytes(), nil
+}
+
+func writePlistString(out *bytes.Buffer, tag, value string, depth int) {
+	out.WriteString(strings.Repeat("\t", depth) + "<" + tag + ">")
+	_ = xml.EscapeText(out, []byte(value))
+	out.WriteString("</" + tag + ">\n")
+}
+
+func writePlistValue(out *bytes.Buffer, value any, depth int) error {
+	indent := strings.Repeat("\t", depth)
+	switch v := value.(type) {
+	case plistDict:
+		if len(v) == 0 {
+			out.WriteString(indent + "<dict/>\n")
+			return nil
+		}
+		out.WriteString(indent + "<dict>\n")
+		for _, entry := range v {
+			writePlistString(out, "key", entry.Key, depth+1)
+			if err := writePlistValue(out, entry.Value, depth+1); err != nil {
+				return fmt.Errorf("%s: %w", entry.Key, err)
+			}
+		}
+		out.WriteString(indent + "</dict>\n")
+	case []any:
+		if len(v) == 0 {
+			out.WriteString(indent + "<array/>\n")
+			return nil
+		}
+		out.WriteString(indent + "<array>\n")
+		for _, item := range v {
+			if err := writePlistValue(out, item, depth+1); err != nil {
+				return err
+			}
+		}
+		out.WriteString(indent + "</array>\n")
+	case []string:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+		return writePlistValue(out, items, depth)
+	case string:
+		writePlistString(out, "string", v, depth)
+	case int:
+		out.WriteString(indent + "<integer>" + strconv.Itoa(v) + "</integer>\n")
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return errors.New("plist reals must be finite")
+		}
+		out.WriteString(indent + "<real>" + strconv.FormatFloat(v, 'g', -1, 64) + "</real>\n")
+	case bool:
+		if v {
+			out.WriteString(indent + "<true/>\n")
+		} else {
+			out.WriteString(indent + "<false/>\n")
+		}
+	default:
+		return fmt.Errorf("unsupported plist value %T", value)
+	}
+	return nil
+}
+
+func decodePlist(data []byte) (any, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("plist has no root element")
+			}
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local != "plist" {
+				return nil, fmt.Errorf("expected <plist>, got <%s>", start.Name.Local)
+			}
+			value, end, err := readPlistValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if end {
+				return nil, errors.New("plist is empty")
+			}
+			return value, nil
+		}
+	}
+}
+
+// readPlistValue reads the next element; end reports the closing tag of the
+// enclosing dict, array or plist instead.
+func readPlistValue(decoder *xml.Decoder) (value any, end bool, err error) {
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, false, err
+		}
+		switch t := token.(type) {
+		case xml.EndElement:
+			return nil, true, nil
+		case xml.StartElement:
+			value, err := readPlistElement(decoder, t)
+			return value, false, err
+		}
+	}
+}
+
+func readPlistElement(decoder *xml.Decoder, start xml.StartElement) (any, error) {
+	switch start.Name.Local {
+	case "dict":
+		dict := map[string]any{}
+		for {
+			key, end, err := readPlistValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if end {
+				return dict, nil
+			}
+			name, ok := key.(plistKey)
+			if !ok {
+				return nil, errors.New("plist dict entries must start with <key>")
+			}
+			value, end, err := readPlistValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if end {
+				return nil, fmt.Errorf("plist key %q has no value", string(name))
+			}
+			dict[string(name)] = value
+		}
+	case "array":
+		items := []any{}
+		for {
+			item, end, err := readPlistValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if end {
+				return items, nil
+			}
+			items = append(items, item)
+		}
+	case "true", "false":
+		if err := decoder.Skip(); err != nil {
+			return nil, err
+		}
+		return start.Name.Local == "true", nil
+	}
+
+	var text string
+	if err := decoder.DecodeElement(&text, &start); err != nil {
+		return nil, err
+	}
+	switch start.Name.Local {
+	case "key":
+		return plistKey(text), nil
+	case "string", "date", "data":
+		return text, nil
+	case "integer":
+		value, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid plist integer %q", text)
+		}
+		return value, nil
+	case "real":
+		value, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid plist real %q", text)
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unsupported plist element <%s>", start.Name.Local)
+	}
+}
+
+// plistKey keeps <key> apart from <string> while a dict is read.
+type plistKey string
+
+// jsonToPlist converts a JSON document into plist values. Object keys are
+// sorted, whole numbers become integers and nulls are dropped, since plists
+// have no null.
+func jsonToPlist(raw json.RawMessage) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return plistFromJSONValue(value), nil
+}
+
+func plistFromJSONValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key, item := range v {
+			if item != nil {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		dict := make(plistDict, 0, len(keys))
+		for _, key := range keys {
+			dict = append(dict, plistEntry{key, plistFromJSONValue(v[key])})
+		}
+		return dict
+	case []any:
+		items := make([]any, 0, len(v))
+		for _, item := range v {
+			if item != nil {
+				items = append(items, plistFromJSONValue(item))
+			}
+		}
+		return items
+	case json.Number:
+		if integer, err := strconv.Atoi(v.String()); err == nil {
+			return integer
+		}
+		float, _ := v.Float64()
+		return float
+	default:
+		return v
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+// PSF2 console fonts (setfont, the Linux virtual console) use one fixed cell
+// for every glyph. The cell covers the font ascent and descent, grown to fit
+// any glyph that pokes out, and the Unicode table maps code points to slots.
+
+const (
+	psf2Magic          = 0x864ab572
+	psf2HeaderSize     = 32
+	psf2HasUnicode     = 0x01
+	psf2Separator      = 0xff
+	psf2ConsoleGlyphs  = 256
+	psf2ExtendedGlyphs = 512
+)
+
+// psfSlots assigns console slots: Latin-1 code points keep their own slot so
+// the font also works without the Unicode table, the remaining encoded glyphs
+// fill the free slots in font order. Unencoded glyphs are unreachable on the
+// console and are left out.
+func (f *bitmapFont) psfSlots() []*bitmapGlyph {
+	encoded := []*bitmapGlyph{}
+	for i := range f.Glyphs {
+		if f.Glyphs[i].HasUnicode {
+			encoded = append(encoded, &f.Glyphs[i])
+		}
+	}
+	count := psf2ConsoleGlyphs
+	if len(encoded) > psf2ConsoleGlyphs {
+		count = max(psf2ExtendedGlyphs, len(encoded))
+	}
+	slots := make([]*bitmapGlyph, count)
+	rest := []*bitmapGlyph{}
+	for _, glyph := range encoded {
+		if glyph.Unicode < psf2ConsoleGlyphs && slots[glyph.Unicode] == nil {
+			slots[glyph.Unicode] = glyph
+			continue
+		}
+		rest = append(rest, glyph)
+	}
+	next := 0
+	for _, glyph := range rest {
+		for slots[next] != nil {
+			next++
+		}
+		slots[next] = glyph
+	}
+	return slots
+}
+
+func (f *bitmapFont) encodePSF2() []byte {
+	top, bottom, width := f.Ascent, -f.Descent, 1
+	for _, glyph := range f.Glyphs {
+		if glyph.Height > 0 {
+			top = max(top, glyph.OffsetY+glyph.Height)
+			bottom = min(bottom, glyph.OffsetY)
+		}
+		width = max(width, glyph.Width)
+	}
+	height := max(1, top-bottom)
+	rowBytes := (width + 7) / 8
+	slots := f.psfSlots()
+
+	le := binary.LittleEndian
+	out := le.AppendUint32(nil, psf2Magic)
+	out = le.AppendUint32(out, 0) // version
+	out = le.AppendUint32(out, psf2HeaderSize)
+	out = le.AppendUint32(out, psf2HasUnicode)
+	out = le.AppendUint32(out, uint32(len(slots)))
+	out = le.AppendUint32(out, uint32(height*rowBytes))
+	out = le.AppendUint32(out, uint32(height))
+	out = le.AppendUint32(out, uint32(width))
+
+	for _, glyph := range slots {
+		cell := make([]byte, height*rowBytes)
+		if glyph != nil {
+			firstRow := top - (glyph.OffsetY + glyph.Height)
+			for row := 0; row < glyph.Height; row++ {
+				copy(cell[(firstRow+row)*rowBytes:], packBitmapRow(*glyph, row, rowBytes))
+			}
+		}
+		out = append(out, cell...)
+	}
+	for _, glyph := range slots {
+		if glyph != nil {
+			out = utf8.AppendRune(out, glyph.Unicode)
+		}
+		out = append(out, psf2Separator)
+	}
+	return out
+}
+
+func (f *bitmapFont) encodePSF2Gzip() ([]byte, error) {
+	var out bytes.Buffer
+	writer, err := gzip.NewWriterLevel(&out, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(f.encodePSF2()); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
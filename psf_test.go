@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"testing"
+)
+
+func TestEncodePSF2(t *testing.T) {
+	font := testBitmapFont(t)
+	data := font.encodePSF2()
+	le := binary.LittleEndian
+	header := make([]uint32, 8)
+	for i := range header {
+		header[i] = le.Uint32(data[4*i:])
+	}
+	// The cell is 3 pixels wide and 5 high: the ascent of 4 and the
+	// descent of 1.
+	want := []uint32{psf2Magic, 0, psf2HeaderSize, psf2HasUnicode, 256, 5, 5, 3}
+	for i := range want {
+		if header[i] != want[i] {
+			t.Fatalf("header = %v, want %v", header, want)
+		}
+	}
+
+	cell := func(slot int) []byte {
+		return data[psf2HeaderSize+5*slot : psf2HeaderSize+5*(slot+1)]
+	}
+	if got, want := cell('A'), []byte{0, 0xe0, 0xa0, 0xe0, 0xa0}; !bytes.Equal(got, want) {
+		t.Errorf("A = %x, want %x", got, want)
+	}
+	if got, want := cell('.'), []byte{0, 0, 0, 0, 0x80}; !bytes.Equal(got, want) {
+		t.Errorf(". = %x, want %x", got, want)
+	}
+	if got := cell('B'); !bytes.Equal(got, make([]byte, 5)) {
+		t.Errorf("B = %x, want an empty cell", got)
+	}
+
+	table := bytes.Split(data[psf2HeaderSize+5*256:], []byte{psf2Separator})
+	if len(table) != 257 || len(table[256]) != 0 {
+		t.Fatalf("unicode table has %d entries, want 256", len(table)-1)
+	}
+	for slot, entry := range table[:256] {
+		want := ""
+		switch slot {
+		case 'A':
+			want = "A"
+		case '.':
+			want = "."
+		}
+		if string(entry) != want {
+			t.Errorf("unicode entry of slot %d = %q, want %q", slot, entry, want)
+		}
+	}
+
+	compressed, err := font.encodePSF2Gzip()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unpacked, err := io.ReadAll(reader); err != nil || !bytes.Equal(unpacked, data) {
+		t.Errorf("psf.gz does not unpack to the PSF2 font (%v)", err)
+	}
+}
+
+func TestPSF2MapsGlyphListNames(t *testing.T) {
+	snapshot := testSnapshot(t,
+		glyphDocument{ID: "1", Name: "eacute", Structure: "#"},
+		glyphDocument{ID: "2", Name: "Scaron", Structure: "#"},
+	)
+	font, err := compileProjectBitmapFont(snapshot, "regular", json.RawMessage(`{"familyName": "Test"}`), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := font.encodePSF2()
+	cellSize := int(binary.LittleEndian.Uint32(data[20:]))
+	table := bytes.Split(data[psf2HeaderSize+cellSize*256:], []byte{psf2Separator})
+	// eacute keeps its Latin-1 slot, Scaron takes the first free one.
+	for slot, want := range map[int]string{0xe9: "é", 0: "Š"} {
+		if string(table[slot]) != want {
+			t.Errorf("unicode entry of slot %d = %q, want %q", slot, table[slot], want)
+		}
+	}
+}
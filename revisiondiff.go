@@ -0,0 +1,418 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+)
+
+// A revision diff compares two snapshots, each a revision or the current
+// project, entity by entity. Glyph structures are compared cell by cell and
+// component by component, syntaxes rule by rule; every change also lists the
+// top-level fields that differ.
+
+const currentRevisionID = "current"
+
+type revisionDiffResponse struct {
+	Project string       `json:"project"`
+	From    revisionMeta `json:"from"`
+	To      revisionMeta `json:"to"`
+	// Kinds lists the entity kinds with changes, in registry order.
+	Kinds []entityKindDiff `json:"kinds"`
+}
+
+type entityKindDiff struct {
+	Kind    string         `json:"kind"`
+	Added   []entityRef    `json:"added"`
+	Removed []entityRef    `json:"removed"`
+	Changed []entityChange `json:"changed"`
+}
+
+type entityRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type entityChange struct {
+	entityRef
+	Fields     []string         `json:"fields"`
+	Structure  *structureDiff   `json:"structure,omitempty"`
+	Components *componentDiff   `json:"components,omitempty"`
+	Rules      []syntaxRuleDiff `json:"rules,omitempty"`
+}
+
+// structureDiff lists the cells of a glyph body that differ, from the
+// bottom-left corner as in structureCells. Missing cells read as blanks.
+type structureDiff struct {
+	Cells []structureCellChange `json:"cells"`
+}
+
+type structureCellChange struct {
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// componentDiff pairs components by name: a name on both sides whose
+// placement differs is changed, the others are added or removed.
+type componentDiff struct {
+	Added   []glyphComponentRef `json:"added,omitempty"`
+	Removed []glyphComponentRef `json:"removed,omitempty"`
+	Changed []componentChange   `json:"changed,omitempty"`
+}
+
+type componentChange struct {
+	From glyphComponentRef `json:"from"`
+	To   glyphComponentRef `json:"to"`
+}
+
+type syntaxRuleDiff struct {
+	Symbol string `json:"symbol"`
+	// Change is added, removed or changed.
+	Change string `json:"change"`
+	// Kind is the shape kind of the rule, before the change for removed
+	// rules; PreviousKind is set when a change replaced it.
+	Kind         shapeKind `json:"kind"`
+	PreviousKind shapeKind `json:"previousKind,omitempty"`
+	// Props names the shape props that differ, with unused when the rule
+	// was marked or unmarked as unused.
+	Props []string `json:"props,omitempty"`
+}
+
+// loadDiffSide returns the snapshot of a revision, or of the project for
+// "current".
+func (h *hub) loadDiffSide(projectID, revisionID string) (revisionMeta, projectSnapshot, error) {
+	if revisionID == currentRevisionID {
+		doc, ok, err := h.getProject(projectID)
+		if err != nil {
+			return revisionMeta{}, projectSnapshot{}, err
+		}
+		if !ok {
+			return revisionMeta{}, projectSnapshot{}, os.ErrNotExist
+		}
+		return revisionMeta{ID: currentRevisionID, Version: doc.Version, CreatedAt: doc.UpdatedAt}, doc.projectSnapshot, nil
+	}
+	revision, err := h.loadRevisionDocument(projectID, revisionID)
+	if err != nil {
+		return revisionMeta{}, projectSnapshot{}, err
+	}
+	return revisionMetaFromDocument(*revision), revision.projectSnapshot, nil
+}
+
+// diffRevisions compares from and to. An empty from stands for the latest
+// revision, an empty to for the current project.
+func (h *hub) diffRevisions(projectID, fromID, toID string) (revisionDiffResponse, error) {
+	projectID = sanitizeProjectID(projectID)
+	if toID == "" {
+		toID = currentRevisionID
+	}
+	if fromID == "" {
+		revisions, err := h.listRevisionDocuments(projectID)
+		if err != nil {
+			return revisionDiffResponse{}, err
+		}
+		if len(revisions) == 0 {
+			return revisionDiffResponse{}, errors.New("the project has no revisions, name one in from")
+		}
+		fromID = revisions[0].ID
+	}
+
+	fromMeta, fromSnapshot, err := h.loadDiffSide(projectID, fromID)
+	if err != nil {
+		return revisionDiffResponse{}, err
+	}
+	toMeta, toSnapshot, err := h.loadDiffSide(projectID, toID)
+	if err != nil {
+		return revisionDiffResponse{}, err
+	}
+
+	response := revisionDiffResponse{Project: projectID, From: fromMeta, To: toMeta, Kinds: []entityKindDiff{}}
+	for _, kind := range entityKinds {
+		fromRaw, toRaw := *kind.snapshotField(&fromSnapshot), *kind.snapshotField(&toSnapshot)
+		// As in reverts, a kind missing from a revision is not a change.
+		if kind.Optional && (len(fromRaw) == 0 || len(toRaw) == 0) {
+			continue
+		}
+		fromItems, err := kind.parseRevision(fromRaw)
+		if err != nil {
+			return revisionDiffResponse{}, err
+		}
+		toItems, err := kind.parseRevision(toRaw)
+		if err != nil {
+			return revisionDiffResponse{}, err
+		}
+		if diff := diffEntityKind(kind, fromItems, toItems); len(diff.Added)+len(diff.Removed)+len(diff.Changed) > 0 {
+			response.Kinds = append(response.Kinds, diff)
+		}
+	}
+	return response, nil
+}
+
+func diffEntityKind(kind *entityKind, fromItems, toItems map[string]json.RawMessage) entityKindDiff {
+	diff := entityKindDiff{Kind: kind.Name, Added: []entityRef{}, Removed: []entityRef{}, Changed: []entityChange{}}
+	for id, toRaw := range toItems {
+		fromRaw, existed := fromItems[id]
+		if !existed {
+			diff.Added = append(diff.Added, entityRef{ID: id, Name: entityDisplayName(toRaw, id)})
+			continue
+		}
+		if rawJSONEqual(fromRaw, toRaw) {
+			continue
+		}
+		change := entityChange{entityRef: entityRef{ID: id, Name: entityDisplayName(toRaw, id)}, Fields: changedObjectFields(fromRaw, toRaw)}
+		switch kind {
+		case glyphKind:
+			change.Structure, change.Components = diffGlyphStructures(fromRaw, toRaw)
+		case syntaxKind:
+			change.Rules = diffSyntaxRules(fromRaw, toRaw)
+		}
+		if kind.Singleton {
+			change.Name = kind.Name
+		}
+		diff.Changed = append(diff.Changed, change)
+	}
+	for id, fromRaw := range fromItems {
+		if _, stillPresent := toItems[id]; !stillPresent {
+			diff.Removed = append(diff.Removed, entityRef{ID: id, Name: entityDisplayName(fromRaw, id)})
+		}
+	}
+
+	sortRefs := func(refs []entityRef) {
+		sort.Slice(refs, func(i, j int) bool {
+			if refs[i].Name != refs[j].Name {
+				return refs[i].Name < refs[j].Name
+			}
+			return refs[i].ID < refs[j].ID
+		})
+	}
+	sortRefs(diff.Added)
+	sortRefs(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		if diff.Changed[i].Name != diff.Changed[j].Name {
+			return diff.Changed[i].Name < diff.Changed[j].Name
+		}
+		return diff.Changed[i].ID < diff.Changed[j].ID
+	})
+	return diff
+}
+
+// changedObjectFields lists the sorted top-level keys whose values differ
+// between two JSON objects.
+func changedObjectFields(fromRaw, toRaw json.RawMessage) []string {
+	var fromFields, toFields map[string]json.RawMessage
+	_ = json.Unmarshal(fromRaw, &fromFields)
+	_ = json.Unmarshal(toRaw, &toFields)
+	fields := []string{}
+	for key, toValue := range toFields {
+		if fromValue, ok := fromFields[key]; !ok || !rawJSONEqual(fromValue, toValue) {
+			fields = append(fields, key)
+		}
+	}
+	for key := range fromFields {
+		if _, ok := toFields[key]; !ok {
+			fields = append(fields, key)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func diffGlyphStructures(fromRaw, toRaw json.RawMessage) (*structureDiff, *componentDiff) {
+	fromGlyph, fromErr := decodeGlyphDocument(fromRaw)
+	toGlyph, toErr := decodeGlyphDocument(toRaw)
+	if fromErr != nil || toErr != nil || fromGlyph.Structure == toGlyph.Structure {
+		return nil, nil
+	}
+	fromParsed, toParsed := parseGlyphStructure(fromGlyph.Structure), parseGlyphStructure(toGlyph.Structure)
+
+	var cells *structureDiff
+	if changes := diffStructureBodies(fromParsed.Body, toParsed.Body); len(changes) > 0 {
+		cells = &structureDiff{Cells: changes}
+	}
+	var components *componentDiff
+	if diff := diffGlyphComponents(fromParsed.Components, toParsed.Components); len(diff.Added)+len(diff.Removed)+len(diff.Changed) > 0 {
+		components = &diff
+	}
+	return cells, components
+}
+
+func diffStructureBodies(fromBody, toBody string) []structureCellChange {
+	type cellKey struct{ x, y int }
+	symbols := func(body string) map[cellKey]string {
+		out := map[cellKey]string{}
+		if body == "" {
+			return out
+		}
+		for _, cell := range structureCells(body) {
+			if strings.TrimSpace(cell.Symbol) != "" {
+				out[cellKey{cell.X, cell.Y}] = cell.Symbol
+			}
+		}
+		return out
+	}
+	fromCells, toCells := symbols(fromBody), symbols(toBody)
+
+	changes := []structureCellChange{}
+	for key, to := range toCells {
+		if from := fromCells[key]; from != to {
+			changes = append(changes, structureCellChange{X: key.x, Y: key.y, From: blankCell(from), To: to})
+		}
+	}
+	for key, from := range fromCells {
+		if _, ok := toCells[key]; !ok {
+			changes = append(changes, structureCellChange{X: key.x, Y: key.y, From: from, To: " "})
+		}
+	}
+	// Top row first, as the body reads.
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Y != changes[j].Y {
+			return changes[i].Y > changes[j].Y
+		}
+		return changes[i].X < changes[j].X
+	})
+	return changes
+}
+
+func blankCell(symbol string) string {
+	if symbol == "" {
+		return " "
+	}
+	return symbol
+}
+
+func diffGlyphComponents(fromComponents, toComponents []glyphComponentRef) componentDiff {
+	diff := componentDiff{}
+	remaining := append([]glyphComponentRef(nil), fromComponents...)
+	unmatched := []glyphComponentRef{}
+	for _, component := range toComponents {
+		index := -1
+		for i, candidate := range remaining {
+			if candidate == component {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			unmatched = append(unmatched, component)
+			continue
+		}
+		remaining = append(remaining[:index], remaining[index+1:]...)
+	}
+	for _, component := range unmatched {
+		index := -1
+		for i, candidate := range remaining {
+			if candidate.Name == component.Name {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			diff.Added = append(diff.Added, component)
+			continue
+		}
+		diff.Changed = append(diff.Changed, componentChange{From: remaining[index], To: component})
+		remaining = append(remaining[:index], remaining[index+1:]...)
+	}
+	diff.Removed = remaining
+	if len(diff.Removed) == 0 {
+		diff.Removed = nil
+	}
+	return diff
+}
+
+func diffSyntaxRules(fromRaw, toRaw json.RawMessage) []syntaxRuleDiff {
+	fromSyntax, fromErr := decodeSyntaxDocument(fromRaw)
+	toSyntax, toErr := decodeSyntaxDocument(toRaw)
+	if fromErr != nil || toErr != nil {
+		return nil
+	}
+	fromRules := make(map[string]syntaxRule, len(fromSyntax.Rules))
+	for _, rule := range fromSyntax.Rules {
+		if _, exists := fromRules[rule.Symbol]; !exists {
+			fromRules[rule.Symbol] = rule
+		}
+	}
+
+	diffs := []syntaxRuleDiff{}
+	seen := map[string]struct{}{}
+	for _, rule := range toSyntax.Rules {
+		if _, duplicate := seen[rule.Symbol]; duplicate {
+			continue
+		}
+		seen[rule.Symbol] = struct{}{}
+		previous, existed := fromRules[rule.Symbol]
+		if !existed {
+			diffs = append(diffs, syntaxRuleDiff{Symbol: rule.Symbol, Change: "added", Kind: rule.Shape.Kind})
+			continue
+		}
+		entry := syntaxRuleDiff{Symbol: rule.Symbol, Change: "changed", Kind: rule.Shape.Kind, Props: changedRuleProps(previous, rule)}
+		if previous.Shape.Kind != rule.Shape.Kind {
+			entry.PreviousKind = previous.Shape.Kind
+		}
+		if entry.PreviousKind != "" || len(entry.Props) > 0 {
+			diffs = append(diffs, entry)
+		}
+	}
+	for _, rule := range fromSyntax.Rules {
+		if _, stillPresent := seen[rule.Symbol]; !stillPresent {
+			seen[rule.Symbol] = struct{}{}
+			diffs = append(diffs, syntaxRuleDiff{Symbol: rule.Symbol, Change: "removed", Kind: rule.Shape.Kind})
+		}
+	}
+	return diffs
+}
+
+func changedRuleProps(from, to syntaxRule) []string {
+	props := []string{}
+	for key, value := range to.Shape.Props {
+		if previous, ok := from.Shape.Props[key]; !ok || !rawJSONEqual(previous, value) {
+			props = append(props, key)
+		}
+	}
+	for key := range from.Shape.Props {
+		if _, ok := to.Shape.Props[key]; !ok {
+			props = append(props, key)
+		}
+	}
+	sort.Strings(props)
+	if from.Unused != to.Unused {
+		props = append(props, "unused")
+	}
+	return props
+}
+
+func (s *server) handleRevisionDiff(w http.ResponseWriter, r *http.Request) {
+	s.writeCORS(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	projectID := sanitizeProjectID(query.Get("project"))
+	if projectID == "" {
+		projectID = "default"
+	}
+
+	resp, err := s.hub.diffRevisions(projectID, strings.TrimSpace(query.Get("from")), strings.TrimSpace(query.Get("to")))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			http.Error(w, "revision not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("cannot diff revisions: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
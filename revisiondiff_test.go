@@ -0,0 +1,58 @@
+package main
+
+import "testing"
+
+func TestDiffRevisions(t *testing.T) {
+	h := newHub(t.TempDir())
+	saveTestGlyphs(t, h, "p",
+		glyphDocument{ID: "1", Name: "e", Structure: "##\n##"},
+		glyphDocument{ID: "2", Name: "o", Structure: "##"},
+	)
+	first := createTestRevision(t, h, "p", "first", "v1")
+	saveTestGlyphs(t, h, "p",
+		glyphDocument{ID: "1", Name: "e", Structure: "##\n# "},
+		glyphDocument{ID: "3", Name: "z", Structure: "##"},
+	)
+
+	for _, from := range []string{"", first.ID, "v1"} {
+		diff, err := h.diffRevisions("p", from, "")
+		if err != nil {
+			t.Fatalf("from %q: %v", from, err)
+		}
+		if diff.From.ID != first.ID || diff.To.ID != currentRevisionID {
+			t.Errorf("from %q compared %s with %s", from, diff.From.ID, diff.To.ID)
+		}
+		if len(diff.Kinds) != 1 || diff.Kinds[0].Kind != glyphKind.Name {
+			t.Fatalf("from %q: kinds = %+v, want glyphs only", from, diff.Kinds)
+		}
+		glyphs := diff.Kinds[0]
+		if len(glyphs.Added) != 1 || glyphs.Added[0].Name != "z" {
+			t.Errorf("added = %+v, want z", glyphs.Added)
+		}
+		if len(glyphs.Removed) != 1 || glyphs.Removed[0].Name != "o" {
+			t.Errorf("removed = %+v, want o", glyphs.Removed)
+		}
+		if len(glyphs.Changed) != 1 || glyphs.Changed[0].Name != "e" {
+			t.Fatalf("changed = %+v, want e", glyphs.Changed)
+		}
+		change := glyphs.Changed[0]
+		if len(change.Fields) != 1 || change.Fields[0] != "structure" {
+			t.Errorf("changed fields = %v, want structure", change.Fields)
+		}
+		want := structureCellChange{X: 1, Y: 0, From: "#", To: " "}
+		if change.Structure == nil || len(change.Structure.Cells) != 1 || change.Structure.Cells[0] != want {
+			t.Errorf("structure diff = %+v, want %+v", change.Structure, want)
+		}
+	}
+
+	diff, err := h.diffRevisions("p", first.ID, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Kinds) != 0 {
+		t.Errorf("a revision differs from itself: %+v", diff.Kinds)
+	}
+	if _, err := newHub(t.TempDir()).diffRevisions("p", "", ""); err == nil {
+		t.Error("diffing a project without revisions did not fail")
+	}
+}
@@ -0,0 +1,723 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf16"
+)
+
+// Binary writer for compiledFont. TrueType output stores quadratic glyf
+// outlines, OpenType output stores CFF charstrings like opentype.js does.
+
+const (
+	sfntVersionTrueType = 0x00010000
+	sfntVersionCFF      = 0x4F54544F // "OTTO"
+	sfntEpochOffset     = 2082844800 // seconds between 1904-01-01 and 1970-01-01
+	headMagicNumber     = 0x5F0F3CF5
+	headChecksumMagic   = 0xB1B0AFBA
+	os2FsSelectionReg   = 1 << 6
+	os2DefaultWeight    = 400
+	os2DefaultWidth     = 5
+	nameLanguageEnglish = 0x0409
+)
+
+var fontRevisionPattern = regexp.MustCompile(`\d+(\.\d+)?`)
+
+var be = binary.BigEndian
+
+type sfntBox struct {
+	XMin, YMin, XMax, YMax int
+	Empty                  bool
+}
+
+func (b *sfntBox) include(x, y int) {
+	if b.Empty {
+		*b = sfntBox{XMin: x, YMin: y, XMax: x, YMax: y}
+		return
+	}
+	b.XMin = min(b.XMin, x)
+	b.YMin = min(b.YMin, y)
+	b.XMax = max(b.XMax, x)
+	b.YMax = max(b.YMax, y)
+}
+
+func (b *sfntBox) union(other sfntBox) {
+	if other.Empty {
+		return
+	}
+	b.include(other.XMin, other.YMin)
+	b.include(other.XMax, other.YMax)
+}
+
+func roundOutlinePoint(p outlinePoint) outlinePoint {
+	return outlinePoint{jsRound(p.X), jsRound(p.Y)}
+}
+
+// roundFontContours snaps every point to the unit grid and drops segments
+// that collapse to nothing, including an explicit closing line.
+func roundFontContours(contours []outlineContour) []outlineContour {
+	out := make([]outlineContour, 0, len(contours))
+	for _, contour := range contours {
+		rounded := outlineContour{Start: roundOutlinePoint(contour.Start)}
+		current := rounded.Start
+		for _, segment := range contour.Segments {
+			to := roundOutlinePoint(segment.To)
+			if !segment.Cubic {
+				if to != current {
+					rounded.lineTo(to)
+				}
+				current = to
+				continue
+			}
+			c1 := roundOutlinePoint(segment.C1)
+			c2 := roundOutlinePoint(segment.C2)
+			if c1 == current && c2 == current && to == current {
+				continue
+			}
+			rounded.cubicTo(c1, c2, to)
+			current = to
+		}
+		if n := len(rounded.Segments); n > 0 && !rounded.Segments[n-1].Cubic && rounded.Segments[n-1].To == rounded.Start {
+			rounded.Segments = rounded.Segments[:n-1]
+		}
+		if len(rounded.Segments) == 0 {
+			continue
+		}
+		out = append(out, rounded)
+	}
+	return out
+}
+
+func contourBox(contours []outlineContour) sfntBox {
+	box := sfntBox{Empty: true}
+	bounds, ok := outlineBounds(contours)
+	if !ok {
+		return box
+	}
+	box.include(int(math.Floor(bounds.X)), int(math.Floor(bounds.Y)))
+	box.include(int(math.Ceil(bounds.X+bounds.Width)), int(math.Ceil(bounds.Y+bounds.Height)))
+	return box
+}
+
+var postScriptGlyphNamePattern = regexp.MustCompile(`^[A-Za-z_.][A-Za-z0-9_.]{0,62}$`)
+
+// sfntGlyphNames returns names that are safe for post and CFF charsets.
+// Names outside the PostScript alphabet fall back to uniXXXX or glyphN.
+func sfntGlyphNames(glyphs []fontGlyph) []string {
+	names := make([]string, len(glyphs))
+	used := map[string]struct{}{}
+	for i, glyph := range glyphs {
+		name := glyph.Name
+		if !postScriptGlyphNamePattern.MatchString(name) {
+			switch {
+			case glyph.HasUnicode && glyph.Unicode <= 0xffff:
+				name = fmt.Sprintf("uni%04X", glyph.Unicode)
+			case glyph.HasUnicode:
+				name = fmt.Sprintf("u%05X", glyph.Unicode)
+			default:
+				name = fmt.Sprintf("glyph%d", i)
+			}
+		}
+		if _, taken := used[name]; taken {
+			name = fmt.Sprintf("%s.%d", name, i)
+		}
+		used[name] = struct{}{}
+		names[i] = name
+	}
+	return names
+}
+
+// encodeSFNT serializes the font as TrueType or CFF-flavoured OpenType.
+func (f *compiledFont) encodeSFNT(format fontFormat) ([]byte, error) {
+	if len(f.Glyphs) > 0xffff {
+		return nil, fmt.Errorf("too many glyphs: %d", len(f.Glyphs))
+	}
+	contours := make([][]outlineContour, len(f.Glyphs))
+	boxes := make([]sfntBox, len(f.Glyphs))
+	for i, glyph := range f.Glyphs {
+		contours[i] = roundFontContours(glyph.Contours)
+		boxes[i] = contourBox(contours[i])
+	}
+	names := sfntGlyphNames(f.Glyphs)
+
+	tables := map[string][]byte{}
+	var version uint32
+	switch format {
+	case fontFormatTTF:
+		version = sfntVersionTrueType
+		glyf, loca, maxp, ttBoxes := encodeGlyfTables(contours)
+		boxes = ttBoxes
+		tables["glyf"] = glyf
+		tables["loca"] = loca
+		tables["maxp"] = maxp
+		tables["post"] = f.encodePostTable(names)
+	case fontFormatOTF:
+		version = sfntVersionCFF
+		tables["CFF "] = f.encodeCFFTable(contours, boxes, names)
+		tables["maxp"] = encodeMaxpCFF(len(f.Glyphs))
+		tables["post"] = f.encodePostHeader(3, len(f.Glyphs))
+	default:
+		return nil, fmt.Errorf("unsupported font format %q", format)
+	}
+
+	fontBox := sfntBox{Empty: true}
+	for _, box := range boxes {
+		fontBox.union(box)
+	}
+	if fontBox.Empty {
+		fontBox = sfntBox{}
+	}
+
+	tables["head"] = f.encodeHeadTable(fontBox)
+	tables["hhea"] = f.encodeHheaTable(boxes)
+	tables["hmtx"] = f.encodeHmtxTable(boxes)
+	tables["OS/2"] = f.encodeOS2Table()
+	tables["cmap"] = f.encodeCmapTable()
+	tables["name"] = f.encodeNameTable()
+	if gsub := f.Features.encodeGSUBTable(); gsub != nil {
+		tables["GSUB"] = gsub
+	}
+	gpos, err := f.Features.encodeGPOSTable()
+	if err != nil {
+		return nil, err
+	}
+	if gpos != nil {
+		tables["GPOS"] = gpos
+	}
+	if gdef := f.Features.encodeGDEFTable(len(f.Glyphs)); gdef != nil {
+		tables["GDEF"] = gdef
+	}
+	return assembleSFNT(version, tables), nil
+}
+
+func sfntChecksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += be.Uint32(word[:])
+	}
+	return sum
+}
+
+func padTo4(data []byte) []byte {
+	for len(data)%4 != 0 {
+		data = append(data, 0)
+	}
+	return data
+}
+
+// sfntSearchParams returns searchRange, entrySelector and rangeShift for n
+// entries of the given size.
+func sfntSearchParams(n, size int) (uint16, uint16, uint16) {
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= n {
+		entrySelector++
+	}
+	searchRange := (1 << entrySelector) * size
+	if n == 0 {
+		searchRange = 0
+	}
+	return uint16(searchRange), uint16(entrySelector), uint16(n*size - searchRange)
+}
+
+func assembleSFNT(version uint32, tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	headerSize := 12 + 16*len(tags)
+	out := make([]byte, 0, headerSize)
+	out = be.AppendUint32(out, version)
+	out = be.AppendUint16(out, uint16(len(tags)))
+	searchRange, entrySelector, rangeShift := sfntSearchParams(len(tags), 16)
+	out = be.AppendUint16(out, searchRange)
+	out = be.AppendUint16(out, entrySelector)
+	out = be.AppendUint16(out, rangeShift)
+
+	offset := headerSize
+	headOffset := -1
+	for _, tag := range tags {
+		data := tables[tag]
+		if tag == "head" {
+			headOffset = offset
+		}
+		out = append(out, tag...)
+		out = be.AppendUint32(out, sfntChecksum(data))
+		out = be.AppendUint32(out, uint32(offset))
+		out = be.AppendUint32(out, uint32(len(data)))
+		offset += len(padTo4(append([]byte(nil), data...)))
+	}
+	for _, tag := range tags {
+		out = append(out, padTo4(append([]byte(nil), tables[tag]...))...)
+	}
+	if headOffset >= 0 {
+		be.PutUint32(out[headOffset+8:], headChecksumMagic-sfntChecksum(out))
+	}
+	return out
+}
+
+func sfntFixed(value float64) uint32 {
+	return uint32(int32(math.Round(value * 65536)))
+}
+
+func sfntLongDateTime(t time.Time) uint64 {
+	return uint64(t.Unix() + sfntEpochOffset)
+}
+
+func (f *compiledFont) fontRevision() float64 {
+	match := fontRevisionPattern.FindString(f.Names.Version)
+	value, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return 1
+	}
+	return value
+}
+
+func (f *compiledFont) encodeHeadTable(box sfntBox) []byte {
+	out := make([]byte, 0, 54)
+	out = be.AppendUint32(out, 0x00010000)
+	out = be.AppendUint32(out, sfntFixed(f.fontRevision()))
+	out = be.AppendUint32(out, 0) // checkSumAdjustment, patched by assembleSFNT
+	out = be.AppendUint32(out, headMagicNumber)
+	// Baseline at y=0, left sidebearing at x=0, integer scaling.
+	out = be.AppendUint16(out, 0x000B)
+	out = be.AppendUint16(out, uint16(f.UPM))
+	out = be.AppendUint64(out, sfntLongDateTime(f.Created))
+	out = be.AppendUint64(out, sfntLongDateTime(f.Created))
+	out = be.AppendUint16(out, uint16(int16(box.XMin)))
+	out = be.AppendUint16(out, uint16(int16(box.YMin)))
+	out = be.AppendUint16(out, uint16(int16(box.XMax)))
+	out = be.AppendUint16(out, uint16(int16(box.YMax)))
+	out = be.AppendUint16(out, 0) // macStyle
+	out = be.AppendUint16(out, 3) // lowestRecPPEM
+	out = be.AppendUint16(out, 2) // fontDirectionHint
+	out = be.AppendUint16(out, 1) // indexToLocFormat: long offsets
+	out = be.AppendUint16(out, 0) // glyphDataFormat
+	return out
+}
+
+func (f *compiledFont) encodeHheaTable(boxes []sfntBox) []byte {
+	advanceMax := 0
+	minLSB, minRSB, maxExtent := math.MaxInt, math.MaxInt, math.MinInt
+	for i, glyph := range f.Glyphs {
+		advanceMax = max(advanceMax, glyph.Advance)
+		if boxes[i].Empty {
+			continue
+		}
+		minLSB = min(minLSB, boxes[i].XMin)
+		minRSB = min(minRSB, glyph.Advance-boxes[i].XMax)
+		maxExtent = max(maxExtent, boxes[i].XMax)
+	}
+	if maxExtent == math.MinInt {
+		minLSB, minRSB, maxExtent = 0, 0, 0
+	}
+
+	out := make([]byte, 0, 36)
+	out = be.AppendUint32(out, 0x00010000)
+	out = be.AppendUint16(out, uint16(int16(f.Ascender)))
+	out = be.AppendUint16(out, uint16(int16(f.Descender)))
+	out = be.AppendUint16(out, 0) // lineGap
+	out = be.AppendUint16(out, uint16(advanceMax))
+	out = be.AppendUint16(out, uint16(int16(minLSB)))
+	out = be.AppendUint16(out, uint16(int16(minRSB)))
+	out = be.AppendUint16(out, uint16(int16(maxExtent)))
+	out = be.AppendUint16(out, 1) // caretSlopeRise
+	out = be.AppendUint16(out, 0) // caretSlopeRun
+	out = be.AppendUint16(out, 0) // caretOffset
+	out = append(out, make([]byte, 8)...)
+	out = be.AppendUint16(out, 0) // metricDataFormat
+	out = be.AppendUint16(out, uint16(len(f.Glyphs)))
+	return out
+}
+
+func (f *compiledFont) encodeHmtxTable(boxes []sfntBox) []byte {
+	out := make([]byte, 0, 4*len(f.Glyphs))
+	for i, glyph := range f.Glyphs {
+		lsb := 0
+		if !boxes[i].Empty {
+			lsb = boxes[i].XMin
+		}
+		out = be.AppendUint16(out, uint16(glyph.Advance))
+		out = be.AppendUint16(out, uint16(int16(lsb)))
+	}
+	return out
+}
+
+func encodeMaxpCFF(numGlyphs int) []byte {
+	out := be.AppendUint32(nil, 0x00005000)
+	return be.AppendUint16(out, uint16(numGlyphs))
+}
+
+// os2UnicodeRanges lists the ulUnicodeRange bits for the blocks pixel fonts
+// usually reach; codepoints outside the BMP set bit 57.
+var os2UnicodeRanges = []struct {
+	bit        int
+	start, end rune
+}{
+	{0, 0x0000, 0x007F}, {1, 0x0080, 0x00FF}, {2, 0x0100, 0x017F}, {3, 0x0180, 0x024F},
+	{4, 0x0250, 0x02AF}, {5, 0x02B0, 0x02FF}, {6, 0x0300, 0x036F}, {7, 0x0370, 0x03FF},
+	{9, 0x0400, 0x052F}, {10, 0x0530, 0x058F}, {11, 0x0590, 0x05FF}, {13, 0x0600, 0x06FF},
+	{24, 0x0E00, 0x0E7F}, {29, 0x1E00, 0x1EFF}, {30, 0x1F00, 0x1FFF}, {31, 0x2000, 0x206F},
+	{32, 0x2070, 0x209F}, {33, 0x20A0, 0x20CF}, {35, 0x2100, 0x214F}, {36, 0x2150, 0x218F},
+	{37, 0x2190, 0x21FF}, {38, 0x2200, 0x22FF}, {39, 0x2300, 0x23FF}, {40, 0x2400, 0x243F},
+	{42, 0x2460, 0x24FF}, {43, 0x2500, 0x257F}, {44, 0x2580, 0x259F}, {45, 0x25A0, 0x25FF},
+	{46, 0x2600, 0x26FF}, {47, 0x2700, 0x27BF}, {48, 0x3000, 0x303F}, {49, 0x3040, 0x309F},
+	{50, 0x30A0, 0x30FF}, {59, 0x4E00, 0x9FFF}, {60, 0xE000, 0xF8FF}, {62, 0xFB00, 0xFB4F},
+	{63, 0xFB50, 0xFDFF}, {65, 0xFE20, 0xFE2F}, {68, 0xFF00, 0xFFEF}, {69, 0xFFF0, 0xFFFF},
+}
+
+func (f *compiledFont) encodeOS2Table() []byte {
+	var unicodeRange [4]uint32
+	firstChar, lastChar := rune(-1), rune(0)
+	advanceSum, advanceCount := 0, 0
+	for _, glyph := range f.Glyphs {
+		if glyph.Advance > 0 {
+			advanceSum += glyph.Advance
+			advanceCount++
+		}
+		if !glyph.HasUnicode {
+			continue
+		}
+		codepoint := glyph.Unicode
+		if firstChar < 0 || codepoint < firstChar {
+			firstChar = codepoint
+		}
+		lastChar = max(lastChar, codepoint)
+		if codepoint > 0xffff {
+			unicodeRange[57/32] |= 1 << (57 % 32)
+			continue
+		}
+		for _, block := range os2UnicodeRanges {
+			if codepoint >= block.start && codepoint <= block.end {
+				unicodeRange[block.bit/32] |= 1 << (block.bit % 32)
+				break
+			}
+		}
+	}
+	firstChar = max(firstChar, 0)
+	avgWidth := 0
+	if advanceCount > 0 {
+		avgWidth = int(jsRound(float64(advanceSum) / float64(advanceCount)))
+	}
+	maxContext := 0
+	for _, ligature := range f.Features.Ligatures {
+		maxContext = max(maxContext, len(ligature.Components))
+	}
+	if maxContext == 0 && len(f.Features.StylisticSets) > 0 {
+		maxContext = 1
+	}
+
+	scaled := func(ratio float64) uint16 {
+		return uint16(int16(jsRound(float64(f.UPM) * ratio)))
+	}
+	out := make([]byte, 0, 96)
+	out = be.AppendUint16(out, 3) // version
+	out = be.AppendUint16(out, uint16(int16(avgWidth)))
+	out = be.AppendUint16(out, os2DefaultWeight)
+	out = be.AppendUint16(out, os2DefaultWidth)
+	out = be.AppendUint16(out, 0)            // fsType: installable
+	out = be.AppendUint16(out, scaled(0.65)) // ySubscriptXSize
+	out = be.AppendUint16(out, scaled(0.6))  // ySubscriptYSize
+	out = be.AppendUint16(out, 0)            // ySubscriptXOffset
+	out = be.AppendUint16(out, scaled(0.075))
+	out = be.AppendUint16(out, scaled(0.65)) // ySuperscriptXSize
+	out = be.AppendUint16(out, scaled(0.6))  // ySuperscriptYSize
+	out = be.AppendUint16(out, 0)            // ySuperscriptXOffset
+	out = be.AppendUint16(out, scaled(0.35))
+	out = be.AppendUint16(out, scaled(0.05)) // yStrikeoutSize
+	out = be.AppendUint16(out, scaled(0.25)) // yStrikeoutPosition
+	out = be.AppendUint16(out, 0)            // sFamilyClass
+	out = append(out, make([]byte, 10)...)   // panose
+	for _, word := range unicodeRange {
+		out = be.AppendUint32(out, word)
+	}
+	out = append(out, f.VendorID...)
+	out = be.AppendUint16(out, os2FsSelectionReg)
+	out = be.AppendUint16(out, uint16(min(firstChar, 0xffff)))
+	out = be.AppendUint16(out, uint16(min(lastChar, 0xffff)))
+	out = be.AppendUint16(out, uint16(int16(f.Ascender)))
+	out = be.AppendUint16(out, uint16(int16(f.Descender)))
+	out = be.AppendUint16(out, 0) // sTypoLineGap
+	out = be.AppendUint16(out, uint16(max(f.Ascender, 0)))
+	out = be.AppendUint16(out, uint16(max(-f.Descender, 0)))
+	out = be.AppendUint32(out, 1) // ulCodePageRange1: Latin 1
+	out = be.AppendUint32(out, 0)
+	out = be.AppendUint16(out, uint16(int16(f.XHeight)))
+	out = be.AppendUint16(out, uint16(int16(f.CapHeight)))
+	out = be.AppendUint16(out, 0)  // usDefaultChar
+	out = be.AppendUint16(out, 32) // usBreakChar
+	out = be.AppendUint16(out, uint16(maxContext))
+	return out
+}
+
+type cmapMapping struct {
+	codepoint rune
+	glyph     int
+}
+
+func (f *compiledFont) cmapMappings() []cmapMapping {
+	mappings := []cmapMapping{}
+	for i, glyph := range f.Glyphs {
+		if glyph.HasUnicode {
+			mappings = append(mappings, cmapMapping{codepoint: glyph.Unicode, glyph: i})
+		}
+	}
+	sort.Slice(mappings, func(i, j int) bool { return mappings[i].codepoint < mappings[j].codepoint })
+	return mappings
+}
+
+func encodeCmapFormat4(mappings []cmapMapping) []byte {
+	type segment struct {
+		start, end rune
+		delta      int
+	}
+	segments := []segment{}
+	for _, mapping := range mappings {
+		if mapping.codepoint > 0xffff {
+			break
+		}
+		if n := len(segments); n > 0 {
+			last := &segments[n-1]
+			if mapping.codepoint == last.end+1 && int(mapping.codepoint)+last.delta == mapping.glyph {
+				last.end = mapping.codepoint
+				continue
+			}
+		}
+		segments = append(segments, segment{start: mapping.codepoint, end: mapping.codepoint, delta: mapping.glyph - int(mapping.codepoint)})
+	}
+	if n := len(segments); n == 0 || segments[n-1].end != 0xffff {
+		segments = append(segments, segment{start: 0xffff, end: 0xffff, delta: 1})
+	}
+
+	segCount := len(segments)
+	out := make([]byte, 0, 16+8*segCount)
+	out = be.AppendUint16(out, 4)
+	out = be.AppendUint16(out, uint16(16+8*segCount))
+	out = be.AppendUint16(out, 0) // language
+	searchRange, entrySelector, rangeShift := sfntSearchParams(segCount, 2)
+	out = be.AppendUint16(out, uint16(segCount*2))
+	out = be.AppendUint16(out, searchRange)
+	out = be.AppendUint16(out, entrySelector)
+	out = be.AppendUint16(out, rangeShift)
+	for _, s := range segments {
+		out = be.AppendUint16(out, uint16(s.end))
+	}
+	out = be.AppendUint16(out, 0) // reservedPad
+	for _, s := range segments {
+		out = be.AppendUint16(out, uint16(s.start))
+	}
+	for _, s := range segments {
+		out = be.AppendUint16(out, uint16(s.delta))
+	}
+	for range segments {
+		out = be.AppendUint16(out, 0) // idRangeOffset
+	}
+	return out
+}
+
+func encodeCmapFormat12(mappings []cmapMapping) []byte {
+	type group struct {
+		start, end rune
+		glyph      int
+	}
+	groups := []group{}
+	for _, mapping := range mappings {
+		if n := len(groups); n > 0 {
+			last := &groups[n-1]
+			if mapping.codepoint == last.end+1 && mapping.glyph == last.glyph+int(last.end-last.start)+1 {
+				last.end = mapping.codepoint
+				continue
+			}
+		}
+		groups = append(groups, group{start: mapping.codepoint, end: mapping.codepoint, glyph: mapping.glyph})
+	}
+	out := make([]byte, 0, 16+12*len(groups))
+	out = be.AppendUint16(out, 12)
+	out = be.AppendUint16(out, 0)
+	out = be.AppendUint32(out, uint32(16+12*len(groups)))
+	out = be.AppendUint32(out, 0) // language
+	out = be.AppendUint32(out, uint32(len(groups)))
+	for _, g := range groups {
+		out = be.AppendUint32(out, uint32(g.start))
+		out = be.AppendUint32(out, uint32(g.end))
+		out = be.AppendUint32(out, uint32(g.glyph))
+	}
+	return out
+}
+
+// encodeCmapTable writes Unicode BMP subtables for the Unicode and Windows
+// platforms, plus full-repertoire subtables when a codepoint is above U+FFFF.
+func (f *compiledFont) encodeCmapTable() []byte {
+	mappings := f.cmapMappings()
+	format4 := encodeCmapFormat4(mappings)
+	var format12 []byte
+	if n := len(mappings); n > 0 && mappings[n-1].codepoint > 0xffff {
+		format12 = encodeCmapFormat12(mappings)
+	}
+
+	headerSize := 4 + 8*2
+	if format12 != nil {
+		headerSize += 8 * 2
+	}
+	format4Offset := uint32(headerSize)
+	format12Offset := format4Offset + uint32(len(format4))
+
+	type record struct {
+		platform, encoding uint16
+		offset             uint32
+	}
+	records := []record{{0, 3, format4Offset}}
+	if format12 != nil {
+		records = append(records, record{0, 4, format12Offset})
+	}
+	records = append(records, record{3, 1, format4Offset})
+	if format12 != nil {
+		records = append(records, record{3, 10, format12Offset})
+	}
+
+	out := be.AppendUint16(nil, 0)
+	out = be.AppendUint16(out, uint16(len(records)))
+	for _, r := range records {
+		out = be.AppendUint16(out, r.platform)
+		out = be.AppendUint16(out, r.encoding)
+		out = be.AppendUint32(out, r.offset)
+	}
+	out = append(out, format4...)
+	out = append(out, format12...)
+	return out
+}
+
+type nameRecord struct {
+	platform, encoding, language, nameID uint16
+	value                                []byte
+}
+
+func isASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeNameTable writes the same name IDs opentype.js fills in, minus the
+// trademark and description entries createFont.ts deletes. Empty optional
+// entries are left out.
+func (f *compiledFont) encodeNameTable() []byte {
+	uniqueID := f.Names.FullName
+	if f.Names.Manufacturer != "" {
+		uniqueID = f.Names.Manufacturer + ":" + f.Names.FullName
+	}
+	version := f.Names.Version
+	if version == "" {
+		version = "Version 1.0"
+	}
+	entries := []struct {
+		id    uint16
+		value string
+	}{
+		{1, f.Names.FamilyName},
+		{2, f.Names.StyleName},
+		{3, uniqueID},
+		{4, f.Names.FullName},
+		{5, version},
+		{6, f.Names.PostScriptName},
+		{8, f.Names.Manufacturer},
+		{9, f.Names.Designer},
+		{11, f.Names.ManufacturerURL},
+		{12, f.Names.DesignerURL},
+		{13, f.Names.License},
+	}
+
+	records := []nameRecord{}
+	for _, entry := range entries {
+		if entry.value == "" {
+			continue
+		}
+		if isASCII(entry.value) {
+			records = append(records, nameRecord{1, 0, 0, entry.id, []byte(entry.value)})
+		}
+		utf16Value := []byte{}
+		for _, unit := range utf16.Encode([]rune(entry.value)) {
+			utf16Value = be.AppendUint16(utf16Value, unit)
+		}
+		records = append(records, nameRecord{3, 1, nameLanguageEnglish, entry.id, utf16Value})
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.platform != b.platform {
+			return a.platform < b.platform
+		}
+		if a.encoding != b.encoding {
+			return a.encoding < b.encoding
+		}
+		if a.language != b.language {
+			return a.language < b.language
+		}
+		return a.nameID < b.nameID
+	})
+
+	storageOffset := 6 + 12*len(records)
+	out := be.AppendUint16(nil, 0)
+	out = be.AppendUint16(out, uint16(len(records)))
+	out = be.AppendUint16(out, uint16(storageOffset))
+	storage := []byte{}
+	for _, r := range records {
+		out = be.AppendUint16(out, r.platform)
+		out = be.AppendUint16(out, r.encoding)
+		out = be.AppendUint16(out, r.language)
+		out = be.AppendUint16(out, r.nameID)
+		out = be.AppendUint16(out, uint16(len(r.value)))
+		out = be.AppendUint16(out, uint16(len(storage)))
+		storage = append(storage, r.value...)
+	}
+	return append(out, storage...)
+}
+
+func (f *compiledFont) encodePostHeader(version float64, numGlyphs int) []byte {
+	fixedPitch := uint32(1)
+	for _, glyph := range f.Glyphs[1:] {
+		if glyph.Advance != f.Glyphs[1].Advance {
+			fixedPitch = 0
+			break
+		}
+	}
+	if len(f.Glyphs) < 2 {
+		fixedPitch = 0
+	}
+	out := be.AppendUint32(nil, sfntFixed(version))
+	out = be.AppendUint32(out, 0) // italicAngle
+	out = be.AppendUint16(out, 0) // underlinePosition
+	out = be.AppendUint16(out, 0) // underlineThickness
+	out = be.AppendUint32(out, fixedPitch)
+	return append(out, make([]byte, 16)...)
+}
+
+// encodePostTable writes a format 2 post table so TrueType fonts keep their
+// glyph names. Only .notdef uses the standard Macintosh name set.
+func (f *compiledFont) encodePostTable(names []string) []byte {
+	out := f.encodePostHeader(2, len(names))
+	out = be.AppendUint16(out, uint16(len(names)))
+	custom := []byte{}
+	next := 258
+	for i, name := range names {
+		if i == 0 && name == ".notdef" {
+			out = be.AppendUint16(out, 0)
+			continue
+		}
+		out = be.AppendUint16(out, uint16(next))
+		next++
+		custom = append(custom, byte(len(name)))
+		custom = append(custom, name...)
+	}
+	return append(out, custom...)
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"strconv"
+)
+
+// CFF (version 1) table with one font, Type 2 charstrings and no
+// subroutines. Every charstring carries its advance as the width operand so
+// the Private DICT can keep defaultWidthX and nominalWidthX at zero.
+
+const (
+	cffStandardStrings = 391
+	cffMaxNameLength   = 63
+
+	cffOpVersion     = 0
+	cffOpFullName    = 2
+	cffOpFamilyName  = 3
+	cffOpFontBBox    = 5
+	cffOpCharset     = 15
+	cffOpCharStrings = 17
+	cffOpPrivate     = 18
+	cffOpDefaultW    = 20
+	cffOpNominalW    = 21
+	cffOpEscape      = 12
+	cffOpFontMatrix  = 7
+
+	type2OpRLineTo   = 5
+	type2OpRRCurveTo = 8
+	type2OpEndChar   = 14
+	type2OpRMoveTo   = 21
+)
+
+// appendCFFInteger encodes an integer operand; charstrings and DICTs share
+// the compact forms and differ only in the 32-bit escape.
+func appendCFFInteger(out []byte, value int, dict bool) []byte {
+	switch {
+	case value >= -107 && value <= 107:
+		return append(out, byte(value+139))
+	case value >= 108 && value <= 1131:
+		value -= 108
+		return append(out, byte(value>>8+247), byte(value))
+	case value >= -1131 && value <= -108:
+		value = -value - 108
+		return append(out, byte(value>>8+251), byte(value))
+	case value >= -32768 && value <= 32767:
+		out = append(out, 28)
+		return be.AppendUint16(out, uint16(int16(value)))
+	case dict:
+		return appendCFFDictInt32(out, value)
+	default:
+		// Type 2 has no 32-bit integer; coordinates never get this large.
+		out = append(out, 28)
+		return be.AppendUint16(out, uint16(int16(min(max(value, -32768), 32767))))
+	}
+}
+
+// appendCFFDictInt32 always uses the five byte form, so offsets can be laid
+// out before they are known.
+func appendCFFDictInt32(out []byte, value int) []byte {
+	out = append(out, 29)
+	return be.AppendUint32(out, uint32(int32(value)))
+}
+
+func appendCFFReal(out []byte, value float64) []byte {
+	text := strconv.FormatFloat(value, 'E', -1, 64)
+	nibbles := []byte{}
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c >= '0' && c <= '9':
+			nibbles = append(nibbles, c-'0')
+		case c == '.':
+			nibbles = append(nibbles, 0xa)
+		case c == '-' && i == 0:
+			nibbles = append(nibbles, 0xe)
+		case c == 'E':
+			if i+1 < len(text) && text[i+1] == '-' {
+				nibbles = append(nibbles, 0xc)
+			} else {
+				nibbles = append(nibbles, 0xb)
+			}
+			i++
+		}
+	}
+	nibbles = append(nibbles, 0xf)
+	if len(nibbles)%2 == 1 {
+		nibbles = append(nibbles, 0xf)
+	}
+	out = append(out, 30)
+	for i := 0; i < len(nibbles); i += 2 {
+		out = append(out, nibbles[i]<<4|nibbles[i+1])
+	}
+	return out
+}
+
+func encodeCFFIndex(items [][]byte) []byte {
+	out := be.AppendUint16(nil, uint16(len(items)))
+	if len(items) == 0 {
+		return out
+	}
+	total := 1
+	for _, item := range items {
+		total += len(item)
+	}
+	offSize := 1
+	for limit := 0xff; total > limit; limit = limit<<8 | 0xff {
+		offSize++
+	}
+	out = append(out, byte(offSize))
+	appendOffset := func(value int) {
+		for shift := (offSize - 1) * 8; shift >= 0; shift -= 8 {
+			out = append(out, byte(value>>shift))
+		}
+	}
+	offset := 1
+	appendOffset(offset)
+	for _, item := range items {
+		offset += len(item)
+		appendOffset(offset)
+	}
+	for _, item := range items {
+		out = append(out, item...)
+	}
+	return out
+}
+
+func encodeType2Charstring(advance int, contours []outlineContour) []byte {
+	out := appendCFFInteger(nil, advance, false)
+	if len(contours) == 0 {
+		return append(out, type2OpEndChar)
+	}
+	current := outlinePoint{}
+	delta := func(p outlinePoint) {
+		out = appendCFFInteger(out, int(p.X-current.X), false)
+		out = appendCFFInteger(out, int(p.Y-current.Y), false)
+		current = p
+	}
+	for _, contour := range contours {
+		delta(contour.Start)
+		out = append(out, type2OpRMoveTo)
+		for _, segment := range contour.Segments {
+			if segment.Cubic {
+				delta(segment.C1)
+				delta(segment.C2)
+				delta(segment.To)
+				out = append(out, type2OpRRCurveTo)
+				continue
+			}
+			delta(segment.To)
+			out = append(out, type2OpRLineTo)
+		}
+	}
+	return append(out, type2OpEndChar)
+}
+
+// encodeCFFTable expects contours that were already rounded to integers.
+func (f *compiledFont) encodeCFFTable(glyphContours [][]outlineContour, boxes []sfntBox, names []string) []byte {
+	stringItems := [][]byte{}
+	sid := func(value string) int {
+		stringItems = append(stringItems, []byte(value))
+		return cffStandardStrings + len(stringItems) - 1
+	}
+	versionSID := sid(f.Names.Version)
+	fullNameSID := sid(f.Names.FullName)
+	familySID := sid(f.Names.FamilyName)
+	charset := []byte{0}
+	for _, name := range names[1:] {
+		charset = be.AppendUint16(charset, uint16(sid(name)))
+	}
+
+	charstrings := make([][]byte, len(f.Glyphs))
+	for i, glyph := range f.Glyphs {
+		charstrings[i] = encodeType2Charstring(glyph.Advance, glyphContours[i])
+	}
+
+	fontBox := sfntBox{Empty: true}
+	for _, box := range boxes {
+		fontBox.union(box)
+	}
+	if fontBox.Empty {
+		fontBox = sfntBox{}
+	}
+
+	private := appendCFFInteger(nil, 0, true)
+	private = append(private, cffOpDefaultW)
+	private = appendCFFInteger(private, 0, true)
+	private = append(private, cffOpNominalW)
+
+	topDict := func(charsetOffset, charstringsOffset, privateOffset int) []byte {
+		out := appendCFFInteger(nil, versionSID, true)
+		out = append(out, cffOpVersion)
+		out = appendCFFInteger(out, fullNameSID, true)
+		out = append(out, cffOpFullName)
+		out = appendCFFInteger(out, familySID, true)
+		out = append(out, cffOpFamilyName)
+		for _, value := range []int{fontBox.XMin, fontBox.YMin, fontBox.XMax, fontBox.YMax} {
+			out = appendCFFInteger(out, value, true)
+		}
+		out = append(out, cffOpFontBBox)
+		scale := 1 / float64(max(f.UPM, 1))
+		for _, value := range []float64{scale, 0, 0, scale, 0, 0} {
+			if value == 0 {
+				out = appendCFFInteger(out, 0, true)
+			} else {
+				out = appendCFFReal(out, value)
+			}
+		}
+		out = append(out, cffOpEscape, cffOpFontMatrix)
+		out = appendCFFDictInt32(out, charsetOffset)
+		out = append(out, cffOpCharset)
+		out = appendCFFDictInt32(out, charstringsOffset)
+		out = append(out, cffOpCharStrings)
+		out = appendCFFDictInt32(out, len(private))
+		out = appendCFFDictInt32(out, privateOffset)
+		return append(out, cffOpPrivate)
+	}
+
+	postScriptName := f.Names.PostScriptName
+	if len(postScriptName) > cffMaxNameLength {
+		postScriptName = postScriptName[:cffMaxNameLength]
+	}
+	header := []byte{1, 0, 4, 4}
+	nameIndex := encodeCFFIndex([][]byte{[]byte(postScriptName)})
+	stringIndex := encodeCFFIndex(stringItems)
+	globalSubrs := encodeCFFIndex(nil)
+	charstringIndex := encodeCFFIndex(charstrings)
+
+	// The Top DICT has a fixed size because its offsets use the 5 byte form.
+	topDictSize := len(encodeCFFIndex([][]byte{topDict(0, 0, 0)}))
+	charsetOffset := len(header) + len(nameIndex) + topDictSize + len(stringIndex) + len(globalSubrs)
+	charstringsOffset := charsetOffset + len(charset)
+	privateOffset := charstringsOffset + len(charstringIndex)
+
+	out := append([]byte{}, header...)
+	out = append(out, nameIndex...)
+	out = append(out, encodeCFFIndex([][]byte{topDict(charsetOffset, charstringsOffset, privateOffset)})...)
+	out = append(out, stringIndex...)
+	out = append(out, globalSubrs...)
+	out = append(out, charset...)
+	out = append(out, charstringIndex...)
+	out = append(out, private...)
+	return out
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"math"
+)
+
+// TrueType outlines: cubic segments are split into quadratic curves within
+// glyfCurveTolerance units, and contours are reversed because glyf expects
+// outer contours clockwise.
+
+const (
+	glyfCurveTolerance = 0.5
+	glyfMaxCurveSplits = 16
+	glyfFlagOnCurve    = 1 << 0
+	glyfFlagXShort     = 1 << 1
+	glyfFlagYShort     = 1 << 2
+	glyfFlagXSame      = 1 << 4
+	glyfFlagYSame      = 1 << 5
+)
+
+type glyfPoint struct {
+	X, Y    int
+	OnCurve bool
+}
+
+func splitCubic(p0, p1, p2, p3 outlinePoint, t float64) ([4]outlinePoint, [4]outlinePoint) {
+	lerp := func(a, b outlinePoint) outlinePoint { return a.add(b.sub(a).mul(t)) }
+	p01, p12, p23 := lerp(p0, p1), lerp(p1, p2), lerp(p2, p3)
+	p012, p123 := lerp(p01, p12), lerp(p12, p23)
+	mid := lerp(p012, p123)
+	return [4]outlinePoint{p0, p01, p012, mid}, [4]outlinePoint{mid, p123, p23, p3}
+}
+
+// cubicToQuadratics returns (control, end) pairs approximating the cubic.
+func cubicToQuadratics(p0, p1, p2, p3 outlinePoint) [][2]outlinePoint {
+	third := p3.sub(p2.mul(3)).add(p1.mul(3)).sub(p0)
+	deviation := math.Sqrt(3) / 36 * math.Hypot(third.X, third.Y)
+	pieces := int(math.Ceil(math.Cbrt(deviation / glyfCurveTolerance)))
+	pieces = min(max(pieces, 1), glyfMaxCurveSplits)
+
+	out := make([][2]outlinePoint, 0, pieces)
+	rest := [4]outlinePoint{p0, p1, p2, p3}
+	for i := 0; i < pieces; i++ {
+		piece := rest
+		if i < pieces-1 {
+			piece, rest = splitCubic(rest[0], rest[1], rest[2], rest[3], 1/float64(pieces-i))
+		}
+		control := piece[1].add(piece[2]).mul(3).sub(piece[0]).sub(piece[3]).mul(0.25)
+		out = append(out, [2]outlinePoint{control, piece[3]})
+	}
+	return out
+}
+
+func glyfContourPoints(contour outlineContour) []glyfPoint {
+	reversed := contour.reversed()
+	toPoint := func(p outlinePoint, onCurve bool) glyfPoint {
+		return glyfPoint{X: int(jsRound(p.X)), Y: int(jsRound(p.Y)), OnCurve: onCurve}
+	}
+	points := []glyfPoint{toPoint(reversed.Start, true)}
+	push := func(point glyfPoint) {
+		if last := points[len(points)-1]; point.OnCurve && last.OnCurve && last.X == point.X && last.Y == point.Y {
+			return
+		}
+		points = append(points, point)
+	}
+	current := reversed.Start
+	for _, segment := range reversed.Segments {
+		if !segment.Cubic {
+			push(toPoint(segment.To, true))
+			current = segment.To
+			continue
+		}
+		for _, quad := range cubicToQuadratics(current, segment.C1, segment.C2, segment.To) {
+			push(toPoint(quad[0], false))
+			push(toPoint(quad[1], true))
+		}
+		current = segment.To
+	}
+	if n := len(points); n > 1 && points[n-1].OnCurve && points[n-1].X == points[0].X && points[n-1].Y == points[0].Y {
+		points = points[:n-1]
+	}
+	return points
+}
+
+func encodeGlyfGlyph(contours [][]glyfPoint) ([]byte, sfntBox) {
+	box := sfntBox{Empty: true}
+	total := 0
+	for _, contour := range contours {
+		for _, point := range contour {
+			box.include(point.X, point.Y)
+		}
+		total += len(contour)
+	}
+	if total == 0 {
+		return nil, box
+	}
+
+	out := be.AppendUint16(nil, uint16(len(contours)))
+	out = be.AppendUint16(out, uint16(int16(box.XMin)))
+	out = be.AppendUint16(out, uint16(int16(box.YMin)))
+	out = be.AppendUint16(out, uint16(int16(box.XMax)))
+	out = be.AppendUint16(out, uint16(int16(box.YMax)))
+	end := -1
+	for _, contour := range contours {
+		end += len(contour)
+		out = be.AppendUint16(out, uint16(end))
+	}
+	out = be.AppendUint16(out, 0) // instructionLength
+
+	flags := make([]byte, 0, total)
+	xs, ys := []byte{}, []byte{}
+	previousX, previousY := 0, 0
+	for _, contour := range contours {
+		for _, point := range contour {
+			var flag byte
+			if point.OnCurve {
+				flag |= glyfFlagOnCurve
+			}
+			dx, dy := point.X-previousX, point.Y-previousY
+			switch {
+			case dx == 0:
+				flag |= glyfFlagXSame
+			case dx >= -255 && dx <= 255:
+				flag |= glyfFlagXShort
+				if dx > 0 {
+					flag |= glyfFlagXSame
+				}
+				xs = append(xs, byte(abs(dx)))
+			default:
+				xs = be.AppendUint16(xs, uint16(int16(dx)))
+			}
+			switch {
+			case dy == 0:
+				flag |= glyfFlagYSame
+			case dy >= -255 && dy <= 255:
+				flag |= glyfFlagYShort
+				if dy > 0 {
+					flag |= glyfFlagYSame
+				}
+				ys = append(ys, byte(abs(dy)))
+			default:
+				ys = be.AppendUint16(ys, uint16(int16(dy)))
+			}
+			flags = append(flags, flag)
+			previousX, previousY = point.X, point.Y
+		}
+	}
+	out = append(out, flags...)
+	out = append(out, xs...)
+	out = append(out, ys...)
+	return out, box
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// encodeGlyfTables returns glyf, loca (long format) and a version 1.0 maxp,
+// along with the bounding box of every glyph.
+func encodeGlyfTables(glyphContours [][]outlineContour) ([]byte, []byte, []byte, []sfntBox) {
+	glyf := []byte{}
+	loca := be.AppendUint32(nil, 0)
+	boxes := make([]sfntBox, len(glyphContours))
+	maxPoints, maxContours := 0, 0
+	for i, contours := range glyphContours {
+		points := make([][]glyfPoint, 0, len(contours))
+		total := 0
+		for _, contour := range contours {
+			if contourPoints := glyfContourPoints(contour); len(contourPoints) > 1 {
+				points = append(points, contourPoints)
+				total += len(contourPoints)
+			}
+		}
+		data, box := encodeGlyfGlyph(points)
+		boxes[i] = box
+		glyf = append(glyf, padTo4(data)...)
+		loca = be.AppendUint32(loca, uint32(len(glyf)))
+		maxPoints = max(maxPoints, total)
+		maxContours = max(maxContours, len(points))
+	}
+
+	maxp := be.AppendUint32(nil, 0x00010000)
+	maxp = be.AppendUint16(maxp, uint16(len(glyphContours)))
+	maxp = be.AppendUint16(maxp, uint16(maxPoints))
+	maxp = be.AppendUint16(maxp, uint16(maxContours))
+	maxp = be.AppendUint16(maxp, 0) // maxCompositePoints
+	maxp = be.AppendUint16(maxp, 0) // maxCompositeContours
+	maxp = be.AppendUint16(maxp, 2) // maxZones
+	maxp = append(maxp, make([]byte, 16)...)
+	return glyf, loca, maxp, boxes
+}
@@ -0,0 +1,409 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// GPOS with the kern feature as one pair adjustment lookup: glyph pairs in
+// PairPos format 1 subtables, then class rules in format 2 ones. Shapers
+// only stop at a format 1 subtable that lists the pair, so glyph pairs keep
+// precedence over the classes they belong to; among rules of one kind the
+// first wins. Anchors add the mark and mkmk features, with GDEF telling
+// marks from bases.
+
+const (
+	gposLookupPair      = 2
+	gposLookupMarkBase  = 4
+	gposLookupMarkMark  = 6
+	gposLookupExtension = 9
+	gposValueXAdvance   = 0x0004
+
+	gdefClassBase     = 1
+	gdefClassLigature = 2
+	gdefClassMark     = 3
+)
+
+func encodePairAdjustment(kerning []fontKerning) ([][]byte, error) {
+	glyphRules, classRules := []fontKerning{}, []fontKerning{}
+	for _, rule := range kerning {
+		if rule.Class {
+			classRules = append(classRules, rule)
+		} else {
+			glyphRules = append(glyphRules, rule)
+		}
+	}
+	subtables, err := encodeGlyphPairs(glyphRules)
+	if err != nil {
+		return nil, err
+	}
+	classSubtables, err := encodeClassPairs(classRules)
+	if err != nil {
+		return nil, err
+	}
+	return append(subtables, classSubtables...), nil
+}
+
+// encodeGlyphPairs writes PairPos format 1 subtables, starting a new one
+// before the offsets of the current one would overflow.
+func encodeGlyphPairs(kerning []fontKerning) ([][]byte, error) {
+	values := map[[2]int]int{}
+	seconds := map[int][]int{}
+	for _, rule := range kerning {
+		for _, left := range rule.Left {
+			for _, right := range rule.Right {
+				pair := [2]int{left, right}
+				if _, exists := values[pair]; exists {
+					continue
+				}
+				values[pair] = rule.Value
+				seconds[left] = append(seconds[left], right)
+			}
+		}
+	}
+	covered := make([]int, 0, len(seconds))
+	for glyph := range seconds {
+		covered = append(covered, glyph)
+	}
+	sort.Ints(covered)
+
+	subtables := [][]byte{}
+	for start := 0; start < len(covered); {
+		// The header and the coverage header, then per first glyph its
+		// offset, coverage entry and pair set.
+		size, end := 14, start
+		for end < len(covered) {
+			next := size + 6 + 4*len(seconds[covered[end]])
+			if next > maxOffset16 {
+				break
+			}
+			size, end = next, end+1
+		}
+		if end == start {
+			return nil, fmt.Errorf("glyph %d has too many kerning pairs for one subtable", covered[start])
+		}
+		subtables = append(subtables, encodePairSets(covered[start:end], seconds, values))
+		start = end
+	}
+	return subtables, nil
+}
+
+func encodePairSets(covered []int, seconds map[int][]int, values map[[2]int]int) []byte {
+	headerSize := 10 + 2*len(covered)
+	setData := []byte{}
+	setOffsets := make([]int, len(covered))
+	for i, glyph := range covered {
+		setOffsets[i] = headerSize + len(setData)
+		rights := seconds[glyph]
+		sort.Ints(rights)
+		setData = be.AppendUint16(setData, uint16(len(rights)))
+		for _, right := range rights {
+			setData = be.AppendUint16(setData, uint16(right))
+			setData = be.AppendUint16(setData, uint16(int16(values[[2]int{glyph, right}])))
+		}
+	}
+
+	out := be.AppendUint16(nil, 1)
+	out = be.AppendUint16(out, uint16(headerSize+len(setData)))
+	out = be.AppendUint16(out, gposValueXAdvance)
+	out = be.AppendUint16(out, 0) // valueFormat2
+	out = be.AppendUint16(out, uint16(len(covered)))
+	for _, offset := range setOffsets {
+		out = be.AppendUint16(out, uint16(offset))
+	}
+	out = append(out, setData...)
+	return append(out, encodeCoverage(covered)...)
+}
+
+// encodeClassPairs writes PairPos format 2 subtables. Glyphs on the same
+// side of the same rules share a class, so classes never overlap; the first
+// classes go to each subtable until its offsets would overflow.
+func encodeClassPairs(kerning []fontKerning) ([][]byte, error) {
+	lefts, leftGlyphs := kerningClasses(kerning, func(rule fontKerning) []int { return rule.Left })
+	rights, rightGlyphs := kerningClasses(kerning, func(rule fontKerning) []int { return rule.Right })
+	if len(lefts) == 0 {
+		return nil, nil
+	}
+
+	// Right class 0 holds every glyph on no right side.
+	rightClasses := map[int]int{}
+	for class, glyphs := range rightGlyphs {
+		for _, glyph := range glyphs {
+			rightClasses[glyph] = class + 1
+		}
+	}
+	classDef2 := encodeClassDef(rightClasses)
+	class2Count := len(rights) + 1
+	row := func(left []int) []byte {
+		out := be.AppendUint16(nil, 0)
+		for _, right := range rights {
+			value := 0
+			if rule, ok := firstCommonRule(left, right); ok {
+				value = kerning[rule].Value
+			}
+			out = be.AppendUint16(out, uint16(int16(value)))
+		}
+		return out
+	}
+
+	subtables := [][]byte{}
+	for start := 0; start < len(lefts); {
+		// Every glyph costs a coverage entry and at most one class range.
+		size, end := 16+4+len(classDef2)+4, start
+		for end < len(lefts) {
+			next := size + 2*class2Count + 8*len(leftGlyphs[end])
+			if next > maxOffset16 {
+				break
+			}
+			size, end = next, end+1
+		}
+		if end == start {
+			return nil, fmt.Errorf("kerning classes need more than %d bytes in one subtable", maxOffset16)
+		}
+
+		// The first class of the subtable is class 0, which needs no range.
+		covered := []int{}
+		leftClasses := map[int]int{}
+		records := []byte{}
+		for class := start; class < end; class++ {
+			for _, glyph := range leftGlyphs[class] {
+				covered = append(covered, glyph)
+				leftClasses[glyph] = class - start
+			}
+			records = append(records, row(lefts[class])...)
+		}
+		sort.Ints(covered)
+		coverage := encodeCoverage(covered)
+		classDef1 := encodeClassDef(leftClasses)
+
+		coverageOffset := 16 + len(records)
+		classDef1Offset := coverageOffset + len(coverage)
+		classDef2Offset := classDef1Offset + len(classDef1)
+		out := be.AppendUint16(nil, 2)
+		out = be.AppendUint16(out, uint16(coverageOffset))
+		out = be.AppendUint16(out, gposValueXAdvance)
+		out = be.AppendUint16(out, 0) // valueFormat2
+		out = be.AppendUint16(out, uint16(classDef1Offset))
+		out = be.AppendUint16(out, uint16(classDef2Offset))
+		out = be.AppendUint16(out, uint16(end-start))
+		out = be.AppendUint16(out, uint16(class2Count))
+		out = append(out, records...)
+		out = append(out, coverage...)
+		out = append(out, classDef1...)
+		subtables = append(subtables, append(out, classDef2...))
+		start = end
+	}
+	return subtables, nil
+}
+
+// kerningClasses groups the glyphs of one side by the rules they are in.
+// It returns, per class in order of its lowest glyph, the rule indexes and
+// the glyphs.
+func kerningClasses(kerning []fontKerning, side func(fontKerning) []int) ([][]int, [][]int) {
+	rulesByGlyph := map[int][]int{}
+	for i, rule := range kerning {
+		for _, glyph := range side(rule) {
+			if rules := rulesByGlyph[glyph]; len(rules) == 0 || rules[len(rules)-1] != i {
+				rulesByGlyph[glyph] = append(rules, i)
+			}
+		}
+	}
+	glyphs := make([]int, 0, len(rulesByGlyph))
+	for glyph := range rulesByGlyph {
+		glyphs = append(glyphs, glyph)
+	}
+	sort.Ints(glyphs)
+
+	classByKey := map[string]int{}
+	classRules, classGlyphs := [][]int{}, [][]int{}
+	for _, glyph := range glyphs {
+		key := fmt.Sprint(rulesByGlyph[glyph])
+		class, exists := classByKey[key]
+		if !exists {
+			class = len(classRules)
+			classByKey[key] = class
+			classRules = append(classRules, rulesByGlyph[glyph])
+			classGlyphs = append(classGlyphs, nil)
+		}
+		classGlyphs[class] = append(classGlyphs[class], glyph)
+	}
+	return classRules, classGlyphs
+}
+
+// firstCommonRule returns the lowest rule index in both sorted lists.
+func firstCommonRule(a, b []int) (int, bool) {
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			return a[i], true
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return 0, false
+}
+
+// encodeClassDef writes ClassDef format 2 with one range per run of
+// consecutive glyphs in the same class. Glyphs of class 0 are left out.
+func encodeClassDef(classes map[int]int) []byte {
+	glyphs := make([]int, 0, len(classes))
+	for glyph, class := range classes {
+		if class != 0 {
+			glyphs = append(glyphs, glyph)
+		}
+	}
+	sort.Ints(glyphs)
+
+	ranges := []byte{}
+	rangeCount := 0
+	for start := 0; start < len(glyphs); {
+		end := start
+		for end+1 < len(glyphs) && glyphs[end+1] == glyphs[end]+1 && classes[glyphs[end+1]] == classes[glyphs[start]] {
+			end++
+		}
+		ranges = be.AppendUint16(ranges, uint16(glyphs[start]))
+		ranges = be.AppendUint16(ranges, uint16(glyphs[end]))
+		ranges = be.AppendUint16(ranges, uint16(classes[glyphs[start]]))
+		rangeCount++
+		start = end + 1
+	}
+	out := be.AppendUint16(nil, 2)
+	out = be.AppendUint16(out, uint16(rangeCount))
+	return append(out, ranges...)
+}
+
+func encodeAnchor(anchor fontAnchor) []byte {
+	out := be.AppendUint16(nil, 1)
+	out = be.AppendUint16(out, uint16(int16(anchor.X)))
+	return be.AppendUint16(out, uint16(int16(anchor.Y)))
+}
+
+// encodeMarkAttachment writes MarkBasePos or, with marks as bases,
+// MarkMarkPos format 1, which share their layout. It returns nil when no
+// glyph takes marks.
+func encodeMarkAttachment(marks fontMarks, bases map[int][]fontAnchor) ([]byte, error) {
+	if len(marks.Marks) == 0 || len(bases) == 0 {
+		return nil, nil
+	}
+	classIndex := map[string]int{}
+	for i, name := range marks.Classes {
+		classIndex[name] = i
+	}
+	markGlyphs := make([]int, 0, len(marks.Marks))
+	for glyph := range marks.Marks {
+		markGlyphs = append(markGlyphs, glyph)
+	}
+	sort.Ints(markGlyphs)
+	baseGlyphs := make([]int, 0, len(bases))
+	for glyph := range bases {
+		baseGlyphs = append(baseGlyphs, glyph)
+	}
+	sort.Ints(baseGlyphs)
+
+	markArray := be.AppendUint16(nil, uint16(len(markGlyphs)))
+	markAnchors := []byte{}
+	for _, glyph := range markGlyphs {
+		anchor := marks.Marks[glyph]
+		markArray = be.AppendUint16(markArray, uint16(classIndex[anchor.Name]))
+		markArray = be.AppendUint16(markArray, uint16(2+4*len(markGlyphs)+len(markAnchors)))
+		markAnchors = append(markAnchors, encodeAnchor(anchor)...)
+	}
+	markArray = append(markArray, markAnchors...)
+
+	classCount := len(marks.Classes)
+	baseArray := be.AppendUint16(nil, uint16(len(baseGlyphs)))
+	baseAnchors := []byte{}
+	anchorsStart := 2 + 2*classCount*len(baseGlyphs)
+	for _, glyph := range baseGlyphs {
+		offsets := make([]int, classCount)
+		for _, anchor := range bases[glyph] {
+			offsets[classIndex[anchor.Name]] = anchorsStart + len(baseAnchors)
+			baseAnchors = append(baseAnchors, encodeAnchor(anchor)...)
+		}
+		for _, offset := range offsets {
+			baseArray = be.AppendUint16(baseArray, uint16(offset))
+		}
+	}
+	baseArray = append(baseArray, baseAnchors...)
+
+	markCoverage := encodeCoverage(markGlyphs)
+	markArrayOffset := 12
+	baseArrayOffset := markArrayOffset + len(markArray)
+	markCoverageOffset := baseArrayOffset + len(baseArray)
+	baseCoverageOffset := markCoverageOffset + len(markCoverage)
+	// The offsets inside the arrays are smaller than this one.
+	if baseCoverageOffset > maxOffset16 {
+		return nil, fmt.Errorf("%d marks on %d glyphs need more than %d bytes in one subtable", len(markGlyphs), len(baseGlyphs), maxOffset16)
+	}
+
+	out := be.AppendUint16(nil, 1)
+	out = be.AppendUint16(out, uint16(markCoverageOffset))
+	out = be.AppendUint16(out, uint16(baseCoverageOffset))
+	out = be.AppendUint16(out, uint16(classCount))
+	out = be.AppendUint16(out, uint16(markArrayOffset))
+	out = be.AppendUint16(out, uint16(baseArrayOffset))
+	out = append(out, markArray...)
+	out = append(out, baseArray...)
+	out = append(out, markCoverage...)
+	return append(out, encodeCoverage(baseGlyphs)...), nil
+}
+
+// encodeGPOSTable returns nil when there is nothing to position.
+func (features fontFeatures) encodeGPOSTable() ([]byte, error) {
+	list := []layoutFeature{}
+	if len(features.Kerning) > 0 {
+		subtables, err := encodePairAdjustment(features.Kerning)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, layoutFeature{"kern", subtables, gposLookupPair})
+	}
+	for _, attachment := range []struct {
+		tag   string
+		bases map[int][]fontAnchor
+		kind  uint16
+	}{
+		{"mark", features.Marks.Bases, gposLookupMarkBase},
+		{"mkmk", features.Marks.MarkBases, gposLookupMarkMark},
+	} {
+		lookup, err := encodeMarkAttachment(features.Marks, attachment.bases)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", attachment.tag, err)
+		}
+		if lookup != nil {
+			list = append(list, layoutFeature{attachment.tag, [][]byte{lookup}, attachment.kind})
+		}
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return encodeLayoutTable(list, gposLookupExtension), nil
+}
+
+// encodeGDEFTable classes the glyphs of fonts with marks, so shapers skip
+// marks when they look for a base. Other fonts get no GDEF.
+func (features fontFeatures) encodeGDEFTable(glyphCount int) []byte {
+	if len(features.Marks.Marks) == 0 {
+		return nil
+	}
+	classes := make(map[int]int, glyphCount)
+	for glyph := 1; glyph < glyphCount; glyph++ {
+		classes[glyph] = gdefClassBase
+	}
+	for _, ligature := range features.Ligatures {
+		classes[ligature.Glyph] = gdefClassLigature
+	}
+	for glyph := range features.Marks.Marks {
+		classes[glyph] = gdefClassMark
+	}
+
+	out := be.AppendUint16(nil, 1) // majorVersion
+	out = be.AppendUint16(out, 0)
+	out = be.AppendUint16(out, 12) // glyphClassDef right after the header
+	out = be.AppendUint16(out, 0)  // attachList
+	out = be.AppendUint16(out, 0)  // ligCaretList
+	out = be.AppendUint16(out, 0)  // markAttachClassDef
+	return append(out, encodeClassDef(classes)...)
+}
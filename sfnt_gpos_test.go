@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+)
+
+// compileKernedFont compiles one-cell glyphs with the given kerning and
+// returns the parsed font with its glyph indexes by name.
+func compileKernedFont(t *testing.T, names []string, kerning []kerningDocument) (*sfnt.Font, map[string]sfnt.GlyphIndex) {
+	t.Helper()
+	glyphs := make([]glyphDocument, len(names))
+	for i, name := range names {
+		glyphs[i] = glyphDocument{ID: fmt.Sprintf("%05d", i), Name: name, Structure: "#"}
+	}
+	snapshot := testSnapshot(t, glyphs...)
+	raw, err := json.Marshal(kerning)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot.Kerning = raw
+	f := compileTestFont(t, snapshot, fontFormatTTF)
+
+	var b sfnt.Buffer
+	indexes := map[string]sfnt.GlyphIndex{}
+	for i := 0; i < f.NumGlyphs(); i++ {
+		name, err := f.GlyphName(&b, sfnt.GlyphIndex(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		indexes[name] = sfnt.GlyphIndex(i)
+	}
+	return f, indexes
+}
+
+// kern returns the kerning of a pair in font units, 0 when there is none.
+func kern(t *testing.T, f *sfnt.Font, indexes map[string]sfnt.GlyphIndex, left, right string) int {
+	t.Helper()
+	var b sfnt.Buffer
+	value, err := f.Kern(&b, indexes[left], indexes[right], unscaled(f), font.HintingNone)
+	if err == sfnt.ErrNotFound {
+		return 0
+	}
+	if err != nil {
+		t.Fatalf("Kern(%s, %s): %v", left, right, err)
+	}
+	return int(value)
+}
+
+func TestKerningParsesBack(t *testing.T) {
+	f, indexes := compileKernedFont(t, []string{"A", "B", "V", "W", "o"}, []kerningDocument{
+		{ID: "1", Left: kerningSide{"A", "B"}, Right: kerningSide{"V", "W"}, Value: -0.5},
+		{ID: "2", Left: kerningSide{"A"}, Right: kerningSide{"V"}, Value: -1},
+		// Shadowed by the first class rule for B V.
+		{ID: "3", Left: kerningSide{"B", "o"}, Right: kerningSide{"V"}, Value: 0.25},
+		{ID: "4", Left: kerningSide{"V"}, Right: kerningSide{"o"}, Value: -0.25},
+	})
+	for _, pair := range []struct {
+		left, right string
+		want        int
+	}{
+		{"A", "V", -200},
+		{"A", "W", -100},
+		{"B", "V", -100},
+		{"B", "W", -100},
+		{"o", "V", 50},
+		{"o", "W", 0},
+		{"V", "o", -50},
+		{"V", "A", 0},
+		{"A", "o", 0},
+	} {
+		if got := kern(t, f, indexes, pair.left, pair.right); got != pair.want {
+			t.Errorf("kern %s %s = %d, want %d", pair.left, pair.right, got, pair.want)
+		}
+	}
+}
+
+// TestLargeKerningParsesBack needs more than one subtable of each format,
+// which only fit in the lookup list through extension lookups.
+func TestLargeKerningParsesBack(t *testing.T) {
+	const glyphSide, classes = 130, 200
+	names := []string{}
+	kerning := []kerningDocument{}
+	for i := 0; i < glyphSide; i++ {
+		names = append(names, fmt.Sprintf("g%03d", i))
+	}
+	for i := 0; i < glyphSide; i++ {
+		for j := 0; j < glyphSide; j++ {
+			kerning = append(kerning, kerningDocument{
+				ID:    fmt.Sprintf("g%03d-%03d", i, j),
+				Left:  kerningSide{names[i]},
+				Right: kerningSide{names[j]},
+				Value: -float64((i+j)%4+1) / 20,
+			})
+		}
+	}
+	for i := 0; i < classes; i++ {
+		left := []string{fmt.Sprintf("l%03da", i), fmt.Sprintf("l%03db", i)}
+		right := []string{fmt.Sprintf("r%03da", i), fmt.Sprintf("r%03db", i)}
+		names = append(names, left...)
+		names = append(names, right...)
+		kerning = append(kerning, kerningDocument{
+			ID:    fmt.Sprintf("c%03d", i),
+			Left:  left,
+			Right: right,
+			Value: float64(i%5+1) / 20,
+		})
+	}
+	f, indexes := compileKernedFont(t, names, kerning)
+
+	for _, pair := range [][2]int{{0, 0}, {0, 129}, {64, 65}, {129, 0}, {129, 129}} {
+		left, right := names[pair[0]], names[pair[1]]
+		want := -((pair[0]+pair[1])%4 + 1) * 10
+		if got := kern(t, f, indexes, left, right); got != want {
+			t.Errorf("kern %s %s = %d, want %d", left, right, got, want)
+		}
+	}
+	for _, i := range []int{0, 99, 100, 199} {
+		left, right := fmt.Sprintf("l%03db", i), fmt.Sprintf("r%03da", i)
+		want := (i%5 + 1) * 10
+		if got := kern(t, f, indexes, left, right); got != want {
+			t.Errorf("kern %s %s = %d, want %d", left, right, got, want)
+		}
+		if got := kern(t, f, indexes, left, fmt.Sprintf("r%03da", (i+1)%classes)); got != 0 {
+			t.Errorf("kern %s against another class = %d, want 0", left, got)
+		}
+	}
+}
+
+func TestPairAdjustmentSplitsSubtables(t *testing.T) {
+	rights := make([]int, 20000)
+	for i := range rights {
+		rights[i] = i + 1
+	}
+	if _, err := encodePairAdjustment([]fontKerning{{Left: []int{1}, Right: rights, Value: -10}}); err == nil {
+		t.Error("one glyph with 20000 pairs did not overflow its subtable")
+	}
+
+	kerning := []fontKerning{}
+	for left := 1; left <= 130; left++ {
+		kerning = append(kerning, fontKerning{Left: []int{left}, Right: rights[:130], Value: -10})
+	}
+	subtables, err := encodePairAdjustment(kerning)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subtables) < 2 {
+		t.Fatalf("%d subtables for 16900 pairs, want more than one", len(subtables))
+	}
+	for i, subtable := range subtables {
+		if len(subtable) > maxOffset16 {
+			t.Errorf("subtable %d is %d bytes", i, len(subtable))
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"sort"
+)
+
+// GSUB with one lookup per feature, registered under DFLT/dflt in
+// alphabetical feature order, as applyOpenTypeFeatures does through
+// opentype.js. GPOS shares the layout of the lists.
+
+const (
+	gsubLookupSingle    = 1
+	gsubLookupLigature  = 4
+	gsubLookupExtension = 7
+
+	// maxOffset16 is the farthest an Offset16 reaches.
+	maxOffset16 = 0xffff
+)
+
+type layoutFeature struct {
+	tag       string
+	subtables [][]byte
+	kind      uint16
+}
+
+func encodeCoverage(glyphs []int) []byte {
+	out := be.AppendUint16(nil, 1)
+	out = be.AppendUint16(out, uint16(len(glyphs)))
+	for _, glyph := range glyphs {
+		out = be.AppendUint16(out, uint16(glyph))
+	}
+	return out
+}
+
+func encodeSingleSubstitution(substitutions []fontSingleSubstitution) []byte {
+	byGlyph := map[int]int{}
+	for _, substitution := range substitutions {
+		if _, exists := byGlyph[substitution.From]; !exists {
+			byGlyph[substitution.From] = substitution.To
+		}
+	}
+	covered := make([]int, 0, len(byGlyph))
+	for glyph := range byGlyph {
+		covered = append(covered, glyph)
+	}
+	sort.Ints(covered)
+
+	headerSize := 6 + 2*len(covered)
+	out := be.AppendUint16(nil, 2)
+	out = be.AppendUint16(out, uint16(headerSize))
+	out = be.AppendUint16(out, uint16(len(covered)))
+	for _, glyph := range covered {
+		out = be.AppendUint16(out, uint16(byGlyph[glyph]))
+	}
+	return append(out, encodeCoverage(covered)...)
+}
+
+func encodeLigatureSubstitution(ligatures []fontLigature) []byte {
+	sets := map[int][]fontLigature{}
+	for _, ligature := range ligatures {
+		first := ligature.Components[0]
+		sets[first] = append(sets[first], ligature)
+	}
+	covered := make([]int, 0, len(sets))
+	for glyph := range sets {
+		covered = append(covered, glyph)
+	}
+	sort.Ints(covered)
+
+	headerSize := 6 + 2*len(covered)
+	setData := []byte{}
+	setOffsets := make([]int, len(covered))
+	for i, glyph := range covered {
+		set := sets[glyph]
+		setOffsets[i] = headerSize + len(setData)
+		table := be.AppendUint16(nil, uint16(len(set)))
+		ligatureOffset := 2 + 2*len(set)
+		body := []byte{}
+		for _, ligature := range set {
+			table = be.AppendUint16(table, uint16(ligatureOffset+len(body)))
+			body = be.AppendUint16(body, uint16(ligature.Glyph))
+			body = be.AppendUint16(body, uint16(len(ligature.Components)))
+			for _, component := range ligature.Components[1:] {
+				body = be.AppendUint16(body, uint16(component))
+			}
+		}
+		setData = append(setData, table...)
+		setData = append(setData, body...)
+	}
+
+	out := be.AppendUint16(nil, 1)
+	out = be.AppendUint16(out, uint16(headerSize+len(setData)))
+	out = be.AppendUint16(out, uint16(len(covered)))
+	for _, offset := range setOffsets {
+		out = be.AppendUint16(out, uint16(offset))
+	}
+	out = append(out, setData...)
+	return append(out, encodeCoverage(covered)...)
+}
+
+// encodeGSUBTable returns nil when there is nothing to substitute.
+func (features fontFeatures) encodeGSUBTable() []byte {
+	list := []layoutFeature{}
+	if len(features.Ligatures) > 0 {
+		list = append(list, layoutFeature{"liga", [][]byte{encodeLigatureSubstitution(features.Ligatures)}, gsubLookupLigature})
+	}
+	for tag, substitutions := range features.StylisticSets {
+		if len(substitutions) > 0 {
+			list = append(list, layoutFeature{tag, [][]byte{encodeSingleSubstitution(substitutions)}, gsubLookupSingle})
+		}
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return encodeLayoutTable(list, gsubLookupExtension)
+}
+
+// encodeLayoutTable writes the script, feature and lookup lists of a GSUB or
+// GPOS table with one lookup per feature. extensionKind is the extension
+// lookup type of the table, used when the subtables outgrow 16-bit offsets.
+func encodeLayoutTable(list []layoutFeature, extensionKind uint16) []byte {
+	sort.Slice(list, func(i, j int) bool { return list[i].tag < list[j].tag })
+
+	// ScriptList: DFLT with a default LangSys enabling every feature.
+	scriptList := be.AppendUint16(nil, 1)
+	scriptList = append(scriptList, "DFLT"...)
+	scriptList = be.AppendUint16(scriptList, 8)
+	scriptList = be.AppendUint16(scriptList, 4) // defaultLangSys offset
+	scriptList = be.AppendUint16(scriptList, 0) // langSysCount
+	scriptList = be.AppendUint16(scriptList, 0) // lookupOrder
+	scriptList = be.AppendUint16(scriptList, 0xffff)
+	scriptList = be.AppendUint16(scriptList, uint16(len(list)))
+	for i := range list {
+		scriptList = be.AppendUint16(scriptList, uint16(i))
+	}
+
+	featureList := be.AppendUint16(nil, uint16(len(list)))
+	featureTablesOffset := 2 + 6*len(list)
+	for i, feature := range list {
+		featureList = append(featureList, feature.tag...)
+		featureList = be.AppendUint16(featureList, uint16(featureTablesOffset+6*i))
+	}
+	for i := range list {
+		featureList = be.AppendUint16(featureList, 0) // featureParams
+		featureList = be.AppendUint16(featureList, 1)
+		featureList = be.AppendUint16(featureList, uint16(i))
+	}
+
+	lookupList := encodeLookupList(list, extensionKind)
+
+	out := be.AppendUint32(nil, 0x00010000)
+	out = be.AppendUint16(out, 10)
+	out = be.AppendUint16(out, uint16(10+len(scriptList)))
+	out = be.AppendUint16(out, uint16(10+len(scriptList)+len(featureList)))
+	out = append(out, scriptList...)
+	out = append(out, featureList...)
+	return append(out, lookupList...)
+}
+
+// encodeLookupList writes every lookup with its subtables right after it.
+// When that does not fit in 16-bit offsets, each lookup becomes an
+// extension lookup whose subtables follow all the lookups and are reached
+// through 32-bit offsets.
+func encodeLookupList(list []layoutFeature, extensionKind uint16) []byte {
+	lookupsOffset := 2 + 2*len(list)
+	size := lookupsOffset
+	for _, feature := range list {
+		size += 6 + 2*len(feature.subtables)
+		for _, subtable := range feature.subtables {
+			size += len(subtable)
+		}
+	}
+
+	out := be.AppendUint16(nil, uint16(len(list)))
+	lookups := []byte{}
+	if size <= maxOffset16 {
+		for _, feature := range list {
+			out = be.AppendUint16(out, uint16(lookupsOffset+len(lookups)))
+			lookups = appendLookup(lookups, feature.kind, feature.subtables)
+		}
+		return append(out, lookups...)
+	}
+
+	dataOffset := lookupsOffset
+	for _, feature := range list {
+		dataOffset += 6 + 10*len(feature.subtables)
+	}
+	data := []byte{}
+	for _, feature := range list {
+		start := lookupsOffset + len(lookups)
+		out = be.AppendUint16(out, uint16(start))
+		extensions := make([][]byte, len(feature.subtables))
+		for i, subtable := range feature.subtables {
+			position := start + 6 + 2*len(feature.subtables) + 8*i
+			extension := be.AppendUint16(nil, 1)
+			extension = be.AppendUint16(extension, feature.kind)
+			extensions[i] = be.AppendUint32(extension, uint32(dataOffset+len(data)-position))
+			data = append(data, subtable...)
+		}
+		lookups = appendLookup(lookups, extensionKind, extensions)
+	}
+	out = append(out, lookups...)
+	return append(out, data...)
+}
+
+func appendLookup(out []byte, kind uint16, subtables [][]byte) []byte {
+	out = be.AppendUint16(out, kind)
+	out = be.AppendUint16(out, 0) // lookupFlag
+	out = be.AppendUint16(out, uint16(len(subtables)))
+	offset := 6 + 2*len(subtables)
+	for _, subtable := range subtables {
+		out = be.AppendUint16(out, uint16(offset))
+		offset += len(subtable)
+	}
+	for _, subtable := range subtables {
+		out = append(out, subtable...)
+	}
+	return out
+}
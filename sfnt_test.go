@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// testSyntax draws # as a full cell and leaves spaces empty.
+const testSyntax = `[{
+	"id": "regular",
+	"name": "Regular",
+	"grid": {"rows": 1, "columns": 1},
+	"rules": [
+		{"symbol": " ", "shape": {"kind": "void", "props": {}}},
+		{"symbol": "#", "shape": {"kind": "rectangle", "props": {
+			"scale_x": {"kind": "number", "value": {"kind": "fixed", "data": 1}},
+			"scale_y": {"kind": "number", "value": {"kind": "fixed", "data": 1}},
+			"rotation": {"kind": "number", "value": {"kind": "fixed", "data": 0}}
+		}}}
+	]
+}]`
+
+// testMetrics is 5 cells high with 1 below the baseline, 200 units a cell.
+const testMetrics = `{"UPM": 1000, "height": 5, "descender": 1}`
+
+func testSnapshot(t *testing.T, glyphs ...glyphDocument) projectSnapshot {
+	t.Helper()
+	raw, err := json.Marshal(glyphs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return projectSnapshot{
+		Glyphs:   raw,
+		Syntaxes: json.RawMessage(testSyntax),
+		Metrics:  json.RawMessage(testMetrics),
+	}
+}
+
+func compileTestFont(t *testing.T, snapshot projectSnapshot, format fontFormat) *sfnt.Font {
+	t.Helper()
+	compiled, err := compileProjectFont(snapshot, "regular", json.RawMessage(`{"familyName": "Test", "createdDate": "2024-01-01"}`))
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	data, err := compiled.encode(format)
+	if err != nil {
+		t.Fatalf("encode %s: %v", format, err)
+	}
+	parsed, err := sfnt.Parse(data)
+	if err != nil {
+		t.Fatalf("parse %s: %v", format, err)
+	}
+	return parsed
+}
+
+// unscaled asks for values in font units: a ppem of UPM/64 pixels cancels
+// the 26.6 fixed point scaling.
+func unscaled(f *sfnt.Font) fixed.Int26_6 {
+	return fixed.Int26_6(f.UnitsPerEm())
+}
+
+func TestCompiledFontParsesBack(t *testing.T) {
+	snapshot := testSnapshot(t,
+		glyphDocument{ID: "1", Name: "A", Structure: "###\n# #\n###\n# #"},
+		glyphDocument{ID: "2", Name: ".", Structure: "#"},
+		glyphDocument{ID: "3", Name: "A.ss01", Structure: "# #\n###"},
+	)
+	for _, format := range []fontFormat{fontFormatTTF, fontFormatOTF} {
+		t.Run(string(format), func(t *testing.T) {
+			f := compileTestFont(t, snapshot, format)
+			var b sfnt.Buffer
+			ppem := unscaled(f)
+
+			if got := f.UnitsPerEm(); got != 1000 {
+				t.Errorf("UnitsPerEm = %d, want 1000", got)
+			}
+			if got := f.NumGlyphs(); got != 4 {
+				t.Errorf("NumGlyphs = %d, want 4", got)
+			}
+			if family, err := f.Name(&b, sfnt.NameIDFamily); err != nil || family != "Test" {
+				t.Errorf("family name = %q, %v; want Test", family, err)
+			}
+
+			// Alternates stay out of the cmap.
+			for r, want := range map[rune]sfnt.GlyphIndex{'A': 1, '.': 2, 'B': 0} {
+				got, err := f.GlyphIndex(&b, r)
+				if err != nil {
+					t.Fatalf("GlyphIndex(%q): %v", r, err)
+				}
+				if got != want {
+					t.Errorf("GlyphIndex(%q) = %d, want %d", r, got, want)
+				}
+			}
+
+			for index, want := range map[sfnt.GlyphIndex]fixed.Int26_6{0: 800, 1: 600, 2: 200, 3: 600} {
+				got, err := f.GlyphAdvance(&b, index, ppem, font.HintingNone)
+				if err != nil {
+					t.Fatalf("GlyphAdvance(%d): %v", index, err)
+				}
+				if got != want {
+					t.Errorf("GlyphAdvance(%d) = %d, want %d", index, got, want)
+				}
+			}
+
+			// The period is one cell on the bottom row, which sits on the
+			// descender; y grows downwards.
+			bounds, _, err := f.GlyphBounds(&b, 2, ppem, font.HintingNone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := (fixed.Rectangle26_6{Min: fixed.Point26_6{X: 0, Y: 0}, Max: fixed.Point26_6{X: 200, Y: 200}}); bounds != want {
+				t.Errorf("bounds of . = %v, want %v", bounds, want)
+			}
+
+			metrics, err := f.Metrics(&b, ppem, font.HintingNone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if metrics.Ascent != 800 || metrics.Descent != 200 || metrics.Height != 1000 {
+				t.Errorf("ascent, descent, height = %d, %d, %d; want 800, 200, 1000", metrics.Ascent, metrics.Descent, metrics.Height)
+			}
+			if metrics.CapHeight != 800 || metrics.XHeight != 600 {
+				t.Errorf("cap height, x-height = %d, %d; want 800, 600", metrics.CapHeight, metrics.XHeight)
+			}
+		})
+	}
+}
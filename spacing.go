@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"unicode"
+)
+
+// A glyph is as wide as the first row of its structure. Sidebearings and
+// advance overrides, in cells so they stay on the grid, let narrow
+// punctuation and tight pairs drop empty columns without redrawing them:
+// the left sidebearing moves the drawing right (left when negative) and the
+// right sidebearing adds space after it. An advance replaces the width
+// outright, so it cannot be set with a right sidebearing.
+
+type glyphSpacing struct {
+	// Offset moves the drawing from the origin and Advance is the width,
+	// both in cells.
+	Offset  int
+	Advance int
+}
+
+// spacing applies the overrides of a glyph to a body that is columns cells
+// wide. A negative width collapses to zero.
+func (glyph glyphDocument) spacing(columns int) glyphSpacing {
+	spacing := glyphSpacing{Advance: columns}
+	if glyph.LeftSidebearing != nil {
+		spacing.Offset = *glyph.LeftSidebearing
+		spacing.Advance += spacing.Offset
+	}
+	switch {
+	case glyph.Advance != nil:
+		spacing.Advance = *glyph.Advance
+	case glyph.RightSidebearing != nil:
+		spacing.Advance += *glyph.RightSidebearing
+	}
+	spacing.Advance = max(spacing.Advance, 0)
+	return spacing
+}
+
+// clippedInk reports whether the drawing of body spills past the origin or
+// the advance, so filled cells overlap the neighbouring glyphs.
+func (spacing glyphSpacing) clippedInk(body string) (left, right bool) {
+	first, last := math.MaxInt, -1
+	for _, row := range splitStructureRows(body) {
+		for column, r := range row {
+			if !unicode.IsSpace(r) {
+				first, last = min(first, column), max(last, column)
+			}
+		}
+	}
+	if last < 0 {
+		return false, false
+	}
+	return spacing.Offset+first < 0, spacing.Offset+last+1 > spacing.Advance
+}
+
+// validateGlyphSpacing checks that the overrides present are whole cells.
+// Clipped ink is left to lint, as a tight pair may overlap on purpose.
+func validateGlyphSpacing(problems *validationError, raw json.RawMessage) {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(raw, &fields) != nil {
+		return
+	}
+	for _, key := range []string{"leftSidebearing", "rightSidebearing", "advance"} {
+		value, ok := fields[key]
+		if !ok || isJSONNull(value) {
+			continue
+		}
+		var cells float64
+		if err := json.Unmarshal(value, &cells); err != nil {
+			problems.add(key, "must be a number of grid cells")
+			continue
+		}
+		if cells != math.Trunc(cells) || math.Abs(cells) > math.MaxInt16 {
+			problems.add(key, "must be a whole number of grid cells, got %v", cells)
+			continue
+		}
+		if key == "advance" && cells < 0 {
+			problems.add(key, "must not be negative, got %v", cells)
+		}
+	}
+	if !isJSONNull(fields["advance"]) && !isJSONNull(fields["rightSidebearing"]) {
+		problems.add("advance", "cannot be set together with rightSidebearing")
+	}
+}
+
+// lintGlyphSpacing warns about overrides that cut into filled cells of the
+// resolved bodies. Marks are left out, as they overlap their base by design.
+func lintGlyphSpacing(glyphs []glyphDocument) []lintFinding {
+	findings := []lintFinding{}
+	bodies := resolveGlyphStructures(glyphs, structureResolveOptions{})
+	seen := map[string]struct{}{}
+	for _, glyph := range glyphs {
+		if _, duplicate := seen[glyph.Name]; duplicate {
+			continue
+		}
+		seen[glyph.Name] = struct{}{}
+		if glyph.LeftSidebearing == nil && glyph.RightSidebearing == nil && glyph.Advance == nil {
+			continue
+		}
+		if _, isMark := glyph.markAnchor(); isMark {
+			continue
+		}
+		body := bodies[glyph.Name]
+		spacing := glyph.spacing(structureColumns(body))
+		left, right := spacing.clippedInk(body)
+		side := ""
+		switch {
+		case left && right:
+			side = "both sides"
+		case left:
+			side = "the left"
+		case right:
+			side = "the right"
+		default:
+			continue
+		}
+		findings = append(findings, lintFinding{
+			Severity: lintWarning,
+			Code:     "clipped-ink",
+			Message:  fmt.Sprintf("the spacing of %q cuts into filled cells on %s, which overlap the neighbouring glyphs", glyph.Name, side),
+			Glyph:    glyph.Name,
+			GlyphIDs: []string{glyph.ID},
+		})
+	}
+	return findings
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func intPointer(value int) *int {
+	return &value
+}
+
+func TestGlyphSpacing(t *testing.T) {
+	tests := []struct {
+		name        string
+		glyph       glyphDocument
+		body        string
+		want        glyphSpacing
+		left, right bool
+	}{
+		{"none", glyphDocument{}, "##", glyphSpacing{Offset: 0, Advance: 2}, false, false},
+		{"negative left drops an empty column", glyphDocument{LeftSidebearing: intPointer(-1)}, " #", glyphSpacing{Offset: -1, Advance: 1}, false, false},
+		{"negative left clips ink", glyphDocument{LeftSidebearing: intPointer(-1)}, "##", glyphSpacing{Offset: -1, Advance: 1}, true, false},
+		{"negative right clips ink", glyphDocument{RightSidebearing: intPointer(-1)}, "##", glyphSpacing{Offset: 0, Advance: 1}, false, true},
+		{"negative right drops an empty column", glyphDocument{RightSidebearing: intPointer(-1)}, "# ", glyphSpacing{Offset: 0, Advance: 1}, false, false},
+		{"both negative", glyphDocument{LeftSidebearing: intPointer(-1), RightSidebearing: intPointer(-1)}, "###", glyphSpacing{Offset: -1, Advance: 1}, true, true},
+		{"advance wins over the width", glyphDocument{LeftSidebearing: intPointer(-1), Advance: intPointer(4)}, "##", glyphSpacing{Offset: -1, Advance: 4}, true, false},
+		{"width collapses to zero", glyphDocument{LeftSidebearing: intPointer(-3)}, "#", glyphSpacing{Offset: -3, Advance: 0}, true, false},
+		{"no ink is never clipped", glyphDocument{LeftSidebearing: intPointer(-2)}, "  ", glyphSpacing{Offset: -2, Advance: 0}, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spacing := test.glyph.spacing(structureColumns(test.body))
+			if spacing != test.want {
+				t.Errorf("spacing = %+v, want %+v", spacing, test.want)
+			}
+			left, right := spacing.clippedInk(test.body)
+			if left != test.left || right != test.right {
+				t.Errorf("clippedInk = %v, %v; want %v, %v", left, right, test.left, test.right)
+			}
+		})
+	}
+}
+
+func TestValidateGlyphSpacing(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{`{"advance": 3}`, ""},
+		{`{"rightSidebearing": -1}`, ""},
+		{`{"advance": null, "rightSidebearing": -1}`, ""},
+		{`{"advance": 3, "rightSidebearing": null}`, ""},
+		{`{"advance": 3, "rightSidebearing": -1}`, "cannot be set together with rightSidebearing"},
+		{`{"leftSidebearing": -1.5}`, "must be a whole number of grid cells"},
+		{`{"advance": -1}`, "must not be negative"},
+	}
+	for _, test := range tests {
+		problems := &validationError{Entity: "glyph"}
+		validateGlyphSpacing(problems, json.RawMessage(test.raw))
+		got := ""
+		if err := problems.result(); err != nil {
+			got = err.Error()
+		}
+		if test.want == "" && got != "" || !strings.Contains(got, test.want) {
+			t.Errorf("%s: problems %q, want %q", test.raw, got, test.want)
+		}
+	}
+}
+
+// TestBitmapSidebearings checks that every bitmap format places the bitmap
+// OffsetX pixels right of the origin, so a negative left sidebearing moves
+// it left of the pen position.
+func TestBitmapSidebearings(t *testing.T) {
+	snapshot := testSnapshot(t,
+		// The empty first column is dropped from the advance.
+		glyphDocument{ID: "1", Name: ".", Structure: " #", LeftSidebearing: intPointer(-1)},
+		// The ink hangs one cell left of the origin.
+		glyphDocument{ID: "2", Name: ",", Structure: "#", LeftSidebearing: intPointer(-1), Advance: intPointer(1)},
+	)
+	font, err := compileProjectBitmapFont(snapshot, "regular", json.RawMessage(`{"familyName": "Test"}`), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	glyphs := map[string]int{}
+	for i, glyph := range font.Glyphs {
+		glyphs[glyph.Name] = i
+	}
+
+	period, comma := font.Glyphs[glyphs["."]], font.Glyphs[glyphs[","]]
+	if period.OffsetX != -2 || period.Width != 4 || period.Advance != 2 {
+		t.Errorf("period offset, width, advance = %d, %d, %d; want -2, 4, 2", period.OffsetX, period.Width, period.Advance)
+	}
+	if comma.OffsetX != -2 || comma.Width != 2 || comma.Advance != 2 {
+		t.Errorf("comma offset, width, advance = %d, %d, %d; want -2, 2, 2", comma.OffsetX, comma.Width, comma.Advance)
+	}
+
+	bdf := string(font.encodeBDF())
+	for _, want := range []string{"STARTCHAR .\nENCODING 46\nSWIDTH", "DWIDTH 2 0\nBBX 4 2 -2 -2\n", "STARTCHAR ,\nENCODING 44\nSWIDTH", "DWIDTH 2 0\nBBX 2 2 -2 -2\n"} {
+		if !strings.Contains(bdf, want) {
+			t.Errorf("BDF has no %q:\n%s", want, bdf)
+		}
+	}
+
+	metrics := font.pcfMetrics()
+	for name, want := range map[string]pcfMetric{
+		".": {LeftBearing: -2, RightBearing: 2, Width: 2, Ascent: 0, Descent: 2},
+		",": {LeftBearing: -2, RightBearing: 0, Width: 2, Ascent: 0, Descent: 2},
+	} {
+		if got := metrics[glyphs[name]]; got != want {
+			t.Errorf("PCF metrics of %q = %+v, want %+v", name, got, want)
+		}
+	}
+
+	// GFX crops to the ink, so its offset also counts the empty columns.
+	layout, err := font.gfxLayout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for codepoint, want := range map[rune][3]int{'.': {0, 2, 2}, ',': {-2, 2, 2}} {
+		entry := layout.Glyphs[codepoint-layout.First]
+		if got := [3]int{entry.XOffset, entry.Width, entry.XAdvance}; got != want {
+			t.Errorf("GFX offset, width, advance of %q = %v, want %v", codepoint, got, want)
+		}
+	}
+}
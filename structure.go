@@ -0,0 +1,687 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// This file ports the glyph structure model from src/lib/GTL/structure.ts,
+// drawGlyph.ts and structureTransforms.ts. A structure is an optional YAML-ish
+// frontmatter with a components: block followed by the ASCII-art body.
+
+const (
+	frontmatterSeparator     = "---"
+	defaultComponentPosition = 1
+	componentRotationStep    = 15
+	maxComponentDepth        = 32
+)
+
+var (
+	componentKeyValuePattern = regexp.MustCompile(`^([A-Za-z0-9_-]+)\s*:\s*(.*)$`)
+	componentsHeaderPattern  = regexp.MustCompile(`^components\s*:`)
+	jsIntPrefixPattern       = regexp.MustCompile(`^\s*([+-]?\d+)`)
+	simpleScalarPattern      = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+)
+
+type glyphComponentRef struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	X        int    `json:"x"`
+	Y        int    `json:"y"`
+	Rotation int    `json:"rotation"`
+	Flipped  bool   `json:"flipped,omitempty"`
+	Mirrored bool   `json:"mirrored,omitempty"`
+}
+
+type parsedGlyphStructure struct {
+	Components []glyphComponentRef
+	Body       string
+}
+
+type partialComponentRef struct {
+	name     string
+	symbol   string
+	x        *int
+	y        *int
+	rotation *int
+	flipped  bool
+	mirrored bool
+	// values keeps where each key was written and its raw text, so that
+	// validation can point at it.
+	values map[string]structureValue
+}
+
+// structureValue is a frontmatter value and its 1-based line and column in
+// the raw structure.
+type structureValue struct {
+	Raw    string
+	Line   int
+	Column int
+}
+
+func normalizeLineEndings(value string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(value, "\r\n", "\n"), "\r", "\n")
+}
+
+// parseJSInt reproduces Number.parseInt(value, 10): it reads the leading
+// integer and ignores trailing garbage.
+func parseJSInt(value string) *int {
+	match := jsIntPrefixPattern.FindStringSubmatch(value)
+	if match == nil {
+		return nil
+	}
+	parsed, err := strconv.Atoi(match[1])
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+func sanitizeComponentPosition(value *int) int {
+	if value == nil {
+		return defaultComponentPosition
+	}
+	return *value
+}
+
+func sanitizeComponentRotation(value float64) int {
+	stepped := int(jsRound(value/componentRotationStep)) * componentRotationStep
+	return ((stepped % 360) + 360) % 360
+}
+
+func sanitizeComponentSymbol(value string) string {
+	for _, r := range strings.TrimSpace(value) {
+		return string(r)
+	}
+	return ""
+}
+
+func (p partialComponentRef) sanitize() (glyphComponentRef, bool) {
+	name := strings.TrimSpace(p.name)
+	if name == "" {
+		return glyphComponentRef{}, false
+	}
+	rotation := 0
+	if p.rotation != nil {
+		rotation = sanitizeComponentRotation(float64(*p.rotation))
+	}
+	return glyphComponentRef{
+		Name:     name,
+		Symbol:   sanitizeComponentSymbol(p.symbol),
+		X:        sanitizeComponentPosition(p.x),
+		Y:        sanitizeComponentPosition(p.y),
+		Rotation: rotation,
+		Flipped:  p.flipped,
+		Mirrored: p.mirrored,
+	}, true
+}
+
+func (p *partialComponentRef) set(key, value string) {
+	switch key {
+	case "name":
+		p.name = value
+	case "symbol":
+		p.symbol = value
+	case "x":
+		p.x = parseJSInt(value)
+	case "y":
+		p.y = parseJSInt(value)
+	case "rotation":
+		p.rotation = parseJSInt(value)
+	case "flipped":
+		p.flipped = strings.ToLower(strings.TrimSpace(value)) == "true"
+	case "mirrored":
+		p.mirrored = strings.ToLower(strings.TrimSpace(value)) == "true"
+	}
+}
+
+func parseStructureScalar(rawValue string) string {
+	value := strings.TrimSpace(rawValue)
+	if value == "" {
+		return ""
+	}
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		var parsed string
+		if err := json.Unmarshal([]byte(value), &parsed); err == nil {
+			return parsed
+		}
+		return value[1 : len(value)-1]
+	}
+	if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func parseStructureFrontmatter(lines []string, firstLine int) []glyphComponentRef {
+	components := []glyphComponentRef{}
+	for _, partial := range scanStructureFrontmatter(lines, firstLine) {
+		if sanitized, ok := partial.sanitize(); ok {
+			components = append(components, sanitized)
+		}
+	}
+	return components
+}
+
+// scanStructureFrontmatter reads the component items of the frontmatter
+// lines, the first of which is line firstLine of the structure.
+func scanStructureFrontmatter(lines []string, firstLine int) []partialComponentRef {
+	partials := []partialComponentRef{}
+	var current *partialComponentRef
+	inComponentsSection := false
+
+	flushCurrent := func() {
+		if current != nil {
+			partials = append(partials, *current)
+		}
+		current = nil
+	}
+	// setValue applies a key: value pair starting at byte offset of line.
+	setValue := func(line string, offset, lineNumber int) {
+		match := componentKeyValuePattern.FindStringSubmatch(strings.TrimSpace(line[offset:]))
+		if match == nil {
+			return
+		}
+		valueOffset := offset + len(match[0]) - len(match[2])
+		current.set(match[1], parseStructureScalar(match[2]))
+		current.values[match[1]] = structureValue{
+			Raw:    strings.TrimSpace(match[2]),
+			Line:   lineNumber,
+			Column: utf8.RuneCountInString(line[:valueOffset]) + 1,
+		}
+	}
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if componentsHeaderPattern.MatchString(trimmed) {
+			inComponentsSection = true
+			continue
+		}
+		if !inComponentsSection {
+			continue
+		}
+
+		offset := len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace))
+		if strings.HasPrefix(trimmed, "-") {
+			flushCurrent()
+			current = &partialComponentRef{values: map[string]structureValue{}}
+			rest := line[offset+1:]
+			offset += 1 + len(rest) - len(strings.TrimLeftFunc(rest, unicode.IsSpace))
+			setValue(line, offset, firstLine+i)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+		setValue(line, offset, firstLine+i)
+	}
+	flushCurrent()
+
+	return partials
+}
+
+// frontmatterBounds returns the indexes of the separator lines around the
+// frontmatter, which must open on the first non-blank line and be closed.
+func frontmatterBounds(lines []string) (open, closing int, ok bool) {
+	open = -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			open = i
+			break
+		}
+	}
+	if open == -1 || strings.TrimSpace(lines[open]) != frontmatterSeparator {
+		return 0, 0, false
+	}
+	for i := open + 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == frontmatterSeparator {
+			return open, i, true
+		}
+	}
+	return 0, 0, false
+}
+
+func parseGlyphStructure(raw string) parsedGlyphStructure {
+	normalized := normalizeLineEndings(raw)
+	lines := strings.Split(normalized, "\n")
+	open, closing, ok := frontmatterBounds(lines)
+	if !ok {
+		return parsedGlyphStructure{Body: normalized}
+	}
+	return parsedGlyphStructure{
+		Components: parseStructureFrontmatter(lines[open+1:closing], open+2),
+		Body:       strings.Join(lines[closing+1:], "\n"),
+	}
+}
+
+func formatStructureScalar(value string) string {
+	if value == "" {
+		return `""`
+	}
+	if simpleScalarPattern.MatchString(value) {
+		return value
+	}
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
+
+// serializeGlyphStructure writes components the way the editor does, so
+// structures written by the server diff cleanly against client edits.
+func serializeGlyphStructure(parsed parsedGlyphStructure) string {
+	body := normalizeLineEndings(parsed.Body)
+	if len(parsed.Components) == 0 {
+		return body
+	}
+	lines := []string{frontmatterSeparator, "components:"}
+	for _, component := range parsed.Components {
+		lines = append(lines,
+			"  - name: "+formatStructureScalar(component.Name),
+			"    symbol: "+formatStructureScalar(component.Symbol),
+			"    x: "+strconv.Itoa(component.X),
+			"    y: "+strconv.Itoa(component.Y),
+			"    rotation: "+strconv.Itoa(sanitizeComponentRotation(float64(component.Rotation))),
+		)
+		if component.Flipped {
+			lines = append(lines, "    flipped: true")
+		}
+		if component.Mirrored {
+			lines = append(lines, "    mirrored: true")
+		}
+	}
+	lines = append(lines, frontmatterSeparator)
+	return strings.Join(lines, "\n") + "\n" + body
+}
+
+func splitStructureRows(body string) [][]rune {
+	if body == "" {
+		return nil
+	}
+	lines := strings.Split(normalizeLineEndings(body), "\n")
+	rows := make([][]rune, 0, len(lines))
+	for _, line := range lines {
+		rows = append(rows, []rune(line))
+	}
+	return rows
+}
+
+func structureRowsToBody(rows [][]rune) string {
+	serialized := make([]string, 0, len(rows))
+	for _, row := range rows {
+		serialized = append(serialized, strings.TrimRightFunc(string(row), unicode.IsSpace))
+	}
+	for len(serialized) > 0 && serialized[len(serialized)-1] == "" {
+		serialized = serialized[:len(serialized)-1]
+	}
+	return strings.Join(serialized, "\n")
+}
+
+func structureRowsWidth(rows [][]rune) int {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	return width
+}
+
+func createEmptyStructureRows(height, width int) [][]rune {
+	if height <= 0 || width <= 0 {
+		return nil
+	}
+	rows := make([][]rune, height)
+	for i := range rows {
+		rows[i] = []rune(strings.Repeat(" ", width))
+	}
+	return rows
+}
+
+// structureCell is one symbol of a body, addressed from the bottom-left corner
+// like structureToArray in drawGlyph.ts.
+type structureCell struct {
+	X      int
+	Y      int
+	Symbol string
+}
+
+func structureCells(body string) []structureCell {
+	lines := strings.Split(body, "\n")
+	cells := []structureCell{}
+	for i := range lines {
+		row := strings.TrimSuffix(lines[len(lines)-1-i], "\r")
+		for j, r := range []rune(row) {
+			cells = append(cells, structureCell{X: j, Y: i, Symbol: string(r)})
+		}
+	}
+	return cells
+}
+
+// structureColumns is getGlyphWidth in cells: the length of the first row.
+func structureColumns(body string) int {
+	first, _, _ := strings.Cut(body, "\n")
+	return len([]rune(strings.TrimSuffix(first, "\r")))
+}
+
+type reflectionAxis string
+
+const (
+	reflectionVertical   reflectionAxis = "vertical"
+	reflectionHorizontal reflectionAxis = "horizontal"
+)
+
+var reflectionOrientationMap = map[reflectionAxis]map[orientation]orientation{
+	reflectionVertical: {
+		orientationNE: orientationNW,
+		orientationNW: orientationNE,
+		orientationSE: orientationSW,
+		orientationSW: orientationSE,
+	},
+	reflectionHorizontal: {
+		orientationNE: orientationSE,
+		orientationSE: orientationNE,
+		orientationNW: orientationSW,
+		orientationSW: orientationNW,
+	},
+}
+
+var clockwiseOrientationMap = map[orientation]orientation{
+	orientationNE: orientationSE,
+	orientationSE: orientationSW,
+	orientationSW: orientationNW,
+	orientationNW: orientationNE,
+}
+
+func directionalOrientation(rule syntaxRule) (orientation, bool) {
+	if rule.Shape.Kind != shapeQuarter && rule.Shape.Kind != shapeTriangle {
+		return "", false
+	}
+	prop, ok := rule.Shape.prop("orientation")
+	if !ok || prop.Value.Kind != valueFixed {
+		return "", false
+	}
+	var value orientation
+	if err := json.Unmarshal(prop.Value.Data, &value); err != nil || value == "" {
+		return "", false
+	}
+	return value, true
+}
+
+func directionalShapeSignature(rule syntaxRule) string {
+	rest := make(map[string]json.RawMessage, len(rule.Shape.Props))
+	for key, value := range rule.Shape.Props {
+		if key != "orientation" {
+			rest[key] = value
+		}
+	}
+	bytes, _ := json.Marshal(struct {
+		Kind  shapeKind                  `json:"kind"`
+		Props map[string]json.RawMessage `json:"props"`
+	}{rule.Shape.Kind, rest})
+	return string(bytes)
+}
+
+// mapDirectionalSymbols maps every quarter/triangle symbol to the symbol of
+// the same shape whose orientation is resolveTarget(orientation).
+func mapDirectionalSymbols(rules []syntaxRule, resolveTarget func(orientation) orientation) map[string]string {
+	type directionalRule struct {
+		symbol      string
+		orientation orientation
+		signature   string
+	}
+	directional := []directionalRule{}
+	seen := map[string]struct{}{}
+	for _, rule := range rules {
+		if len([]rune(rule.Symbol)) != 1 {
+			continue
+		}
+		if _, exists := seen[rule.Symbol]; exists {
+			continue
+		}
+		seen[rule.Symbol] = struct{}{}
+		value, ok := directionalOrientation(rule)
+		if !ok {
+			continue
+		}
+		directional = append(directional, directionalRule{rule.Symbol, value, directionalShapeSignature(rule)})
+	}
+
+	symbolsBySignature := map[string]map[orientation]string{}
+	for _, item := range directional {
+		byOrientation := symbolsBySignature[item.signature]
+		if byOrientation == nil {
+			byOrientation = map[orientation]string{}
+			symbolsBySignature[item.signature] = byOrientation
+		}
+		if _, exists := byOrientation[item.orientation]; !exists {
+			byOrientation[item.orientation] = item.symbol
+		}
+	}
+
+	out := map[string]string{}
+	for _, item := range directional {
+		if target, ok := symbolsBySignature[item.signature][resolveTarget(item.orientation)]; ok {
+			out[item.symbol] = target
+		}
+	}
+	return out
+}
+
+func mapDirectionalSymbolsForReflection(rules []syntaxRule, axis reflectionAxis) map[string]string {
+	return mapDirectionalSymbols(rules, func(value orientation) orientation {
+		return reflectionOrientationMap[axis][value]
+	})
+}
+
+func mapDirectionalSymbolsForRotation(rules []syntaxRule, rotation int) map[string]string {
+	normalized := ((rotation % 360) + 360) % 360
+	if normalized == 0 || normalized%90 != 0 {
+		return map[string]string{}
+	}
+	quarterTurns := normalized / 90
+	return mapDirectionalSymbols(rules, func(value orientation) orientation {
+		for i := 0; i < quarterTurns; i++ {
+			value = clockwiseOrientationMap[value]
+		}
+		return value
+	})
+}
+
+type structureResolveOptions struct {
+	transparentSymbols  map[string]struct{}
+	applySymbolOverride bool
+	// rules enables remapping of directional symbols for rotated or reflected
+	// components; nil leaves symbols untouched.
+	rules []syntaxRule
+}
+
+type structureResolver struct {
+	glyphs         map[string]glyphDocument
+	options        structureResolveOptions
+	cache          map[string]string
+	rotationMaps   map[int]map[string]string
+	reflectionMaps map[reflectionAxis]map[string]string
+}
+
+// resolveGlyphStructures flattens components into every glyph body and
+// returns the resolved bodies by glyph name. The first glyph wins on
+// duplicate names, like resolveGlyphStructures in structure.ts.
+func resolveGlyphStructures(glyphs []glyphDocument, options structureResolveOptions) map[string]string {
+	if options.transparentSymbols == nil {
+		options.transparentSymbols = map[string]struct{}{" ": {}}
+	}
+	resolver := &structureResolver{
+		glyphs:         map[string]glyphDocument{},
+		options:        options,
+		cache:          map[string]string{},
+		rotationMaps:   map[int]map[string]string{},
+		reflectionMaps: map[reflectionAxis]map[string]string{},
+	}
+	for _, glyph := range glyphs {
+		if _, exists := resolver.glyphs[glyph.Name]; !exists {
+			resolver.glyphs[glyph.Name] = glyph
+		}
+	}
+	for _, glyph := range glyphs {
+		resolver.resolve(glyph.Name, map[string]struct{}{}, 0)
+	}
+	return resolver.cache
+}
+
+func (r *structureResolver) resolve(glyphName string, visiting map[string]struct{}, depth int) string {
+	if cached, ok := r.cache[glyphName]; ok {
+		return cached
+	}
+	glyph, ok := r.glyphs[glyphName]
+	if !ok {
+		return ""
+	}
+
+	parsed := parseGlyphStructure(glyph.Structure)
+	if _, cyclic := visiting[glyphName]; cyclic || depth >= maxComponentDepth {
+		return parsed.Body
+	}
+
+	visiting[glyphName] = struct{}{}
+	overlayRows := splitStructureRows(parsed.Body)
+	templateHeight := len(overlayRows)
+	templateWidth := structureRowsWidth(overlayRows)
+
+	componentBodies := make([]string, 0, len(parsed.Components))
+	for _, component := range parsed.Components {
+		componentBody := r.resolve(component.Name, visiting, depth+1)
+		componentRows := splitStructureRows(componentBody)
+		templateHeight = max(templateHeight, len(componentRows))
+		templateWidth = max(templateWidth, structureRowsWidth(componentRows))
+		componentBodies = append(componentBodies, componentBody)
+	}
+
+	rows := createEmptyStructureRows(templateHeight, templateWidth)
+	for i, component := range parsed.Components {
+		r.applyComponent(rows, componentBodies[i], component)
+	}
+	overlayStructureRows(rows, overlayRows)
+
+	resolved := structureRowsToBody(rows)
+	delete(visiting, glyphName)
+	r.cache[glyphName] = resolved
+	return resolved
+}
+
+func overlayStructureRows(rows, overlayRows [][]rune) {
+	for y, overlayRow := range overlayRows {
+		if y >= len(rows) {
+			break
+		}
+		for x, value := range overlayRow {
+			// Space keeps component/background content visible.
+			if value == ' ' || x >= len(rows[y]) {
+				continue
+			}
+			rows[y][x] = value
+		}
+	}
+}
+
+func transformCellInComponent(x, y, componentWidth, componentHeight int, flipped, mirrored bool, rotation int) (int, int) {
+	width := max(1, componentWidth)
+	height := max(1, componentHeight)
+	nextX := x
+	nextY := y
+	if mirrored {
+		nextX = width - 1 - nextX
+	}
+	if flipped {
+		nextY = height - 1 - nextY
+	}
+
+	normalizedRotation := sanitizeComponentRotation(float64(rotation))
+	if normalizedRotation == 0 {
+		return nextX, nextY
+	}
+
+	radians := float64(normalizedRotation) * math.Pi / 180
+	cos := math.Cos(radians)
+	sin := math.Sin(radians)
+	centerX := float64(width-1) / 2
+	centerY := float64(height-1) / 2
+	relativeX := float64(nextX) - centerX
+	relativeY := float64(nextY) - centerY
+	rotatedX := relativeX*cos - relativeY*sin + centerX
+	rotatedY := relativeX*sin + relativeY*cos + centerY
+	return int(jsRound(rotatedX)), int(jsRound(rotatedY))
+}
+
+func (r *structureResolver) mapComponentSymbol(value string, component glyphComponentRef) string {
+	if r.options.rules == nil {
+		return value
+	}
+	next := value
+	if component.Mirrored {
+		if _, ok := r.reflectionMaps[reflectionVertical]; !ok {
+			r.reflectionMaps[reflectionVertical] = mapDirectionalSymbolsForReflection(r.options.rules, reflectionVertical)
+		}
+		if mapped, ok := r.reflectionMaps[reflectionVertical][next]; ok {
+			next = mapped
+		}
+	}
+	if component.Flipped {
+		if _, ok := r.reflectionMaps[reflectionHorizontal]; !ok {
+			r.reflectionMaps[reflectionHorizontal] = mapDirectionalSymbolsForReflection(r.options.rules, reflectionHorizontal)
+		}
+		if mapped, ok := r.reflectionMaps[reflectionHorizontal][next]; ok {
+			next = mapped
+		}
+	}
+	if component.Rotation == 0 || component.Rotation%90 != 0 {
+		return next
+	}
+	if _, ok := r.rotationMaps[component.Rotation]; !ok {
+		r.rotationMaps[component.Rotation] = mapDirectionalSymbolsForRotation(r.options.rules, component.Rotation)
+	}
+	if mapped, ok := r.rotationMaps[component.Rotation][next]; ok {
+		return mapped
+	}
+	return next
+}
+
+func (r *structureResolver) applyComponent(rows [][]rune, componentBody string, component glyphComponentRef) {
+	if len(rows) == 0 {
+		return
+	}
+	componentRows := splitStructureRows(componentBody)
+	if len(componentRows) == 0 {
+		return
+	}
+	componentHeight := len(componentRows)
+	componentWidth := structureRowsWidth(componentRows)
+	offsetX := component.X - 1
+	offsetY := component.Y - 1
+
+	for y, componentRow := range componentRows {
+		for x, value := range componentRow {
+			if _, transparent := r.options.transparentSymbols[string(value)]; transparent {
+				continue
+			}
+			cellX, cellY := transformCellInComponent(x, y, componentWidth, componentHeight, component.Flipped, component.Mirrored, component.Rotation)
+			symbol := string(value)
+			if r.options.applySymbolOverride && component.Symbol != "" {
+				symbol = component.Symbol
+			}
+			symbol = r.mapComponentSymbol(symbol, component)
+
+			targetRow := offsetY + cellY
+			targetCol := offsetX + cellX
+			if targetRow < 0 || targetCol < 0 || targetRow >= len(rows) || targetCol >= len(rows[targetRow]) {
+				continue
+			}
+			rows[targetRow][targetCol] = []rune(symbol)[0]
+		}
+	}
+}
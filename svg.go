@@ -0,0 +1,649 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Minimal SVG import for svg-shaped rules, standing in for paper's
+// importSVG in shapes.ts. It understands inline <svg> markup and
+// data:image/svg+xml URLs; remote sources are not fetched during a build and
+// draw nothing, like a failed fetch on the client.
+
+type svgAffine [6]float64
+
+var svgIdentity = svgAffine{1, 0, 0, 1, 0, 0}
+
+func (m svgAffine) then(n svgAffine) svgAffine {
+	return svgAffine{
+		m[0]*n[0] + m[2]*n[1],
+		m[1]*n[0] + m[3]*n[1],
+		m[0]*n[2] + m[2]*n[3],
+		m[1]*n[2] + m[3]*n[3],
+		m[0]*n[4] + m[2]*n[5] + m[4],
+		m[1]*n[4] + m[3]*n[5] + m[5],
+	}
+}
+
+func (m svgAffine) apply(p outlinePoint) outlinePoint {
+	return outlinePoint{m[0]*p.X + m[2]*p.Y + m[4], m[1]*p.X + m[3]*p.Y + m[5]}
+}
+
+func resolveSVGMarkup(source string) (string, bool) {
+	trimmed := strings.TrimSpace(source)
+	if strings.HasPrefix(trimmed, "<svg") {
+		return trimmed, true
+	}
+	if !strings.HasPrefix(trimmed, "data:image/svg+xml") {
+		return "", false
+	}
+	header, payload, ok := strings.Cut(trimmed, ",")
+	if !ok {
+		return "", false
+	}
+	if strings.HasSuffix(header, ";base64") {
+		decoded, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return "", false
+		}
+		return string(decoded), true
+	}
+	decoded, err := url.PathUnescape(payload)
+	if err != nil {
+		return "", false
+	}
+	return decoded, true
+}
+
+// svgPathItems draws an SVG source fitted into box. Each SVG element becomes
+// one path item; negative sources are cut out of the full cell.
+func svgPathItems(box outlineRect, source string, negative bool) [][]outlineContour {
+	markup, ok := resolveSVGMarkup(source)
+	if !ok {
+		return nil
+	}
+	items, viewBox, err := parseSVGItems(markup)
+	if err != nil || len(items) == 0 {
+		return nil
+	}
+
+	all := []outlineContour{}
+	for _, item := range items {
+		all = append(all, item...)
+	}
+	bounds, ok := viewBox, viewBox.Width > 0 && viewBox.Height > 0
+	if !ok {
+		bounds, ok = outlineBounds(all)
+	}
+	if !ok || bounds.Width <= 0 || bounds.Height <= 0 {
+		return nil
+	}
+
+	scale := math.Min(box.Width/bounds.Width, box.Height/bounds.Height)
+	boxCenter := box.center()
+	boundsCenter := bounds.center()
+	fit := func(p outlinePoint) outlinePoint {
+		return outlinePoint{
+			boxCenter.X + (p.X-boundsCenter.X)*scale,
+			// SVG space points down; flip it around the cell center.
+			boxCenter.Y - (p.Y-boundsCenter.Y)*scale,
+		}
+	}
+	for _, item := range items {
+		for i := range item {
+			item[i].mapPoints(fit)
+		}
+	}
+
+	if !negative {
+		return items
+	}
+	cutout := []outlineContour{rectangleContour(box)}
+	for _, item := range items {
+		cutout = append(cutout, item...)
+	}
+	return [][]outlineContour{cutout}
+}
+
+func parseSVGItems(markup string) ([][]outlineContour, outlineRect, error) {
+	decoder := xml.NewDecoder(strings.NewReader(markup))
+	decoder.Strict = false
+
+	var (
+		items    [][]outlineContour
+		viewBox  outlineRect
+		stack    = []svgAffine{svgIdentity}
+		skipping = 0
+		rootSeen = false
+	)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, outlineRect{}, err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			attrs := map[string]string{}
+			for _, attr := range element.Attr {
+				attrs[attr.Name.Local] = attr.Value
+			}
+			matrix := stack[len(stack)-1].then(parseSVGTransform(attrs["transform"]))
+			stack = append(stack, matrix)
+
+			if skipping > 0 {
+				skipping++
+				continue
+			}
+			switch element.Name.Local {
+			case "defs", "clipPath", "mask", "symbol", "style", "title", "desc", "metadata":
+				skipping = 1
+				continue
+			case "svg":
+				if !rootSeen {
+					rootSeen = true
+					viewBox = parseSVGViewBox(attrs["viewBox"])
+				}
+			}
+			if item := svgElementContours(element.Name.Local, attrs); len(item) > 0 {
+				for i := range item {
+					item[i].mapPoints(matrix.apply)
+				}
+				items = append(items, item)
+			}
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			if skipping > 0 {
+				skipping--
+			}
+		}
+	}
+	return items, viewBox, nil
+}
+
+func parseSVGViewBox(raw string) outlineRect {
+	values := parseSVGNumberList(raw)
+	if len(values) != 4 {
+		return outlineRect{}
+	}
+	return outlineRect{X: values[0], Y: values[1], Width: values[2], Height: values[3]}
+}
+
+func parseSVGNumberList(raw string) []float64 {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+	values := make([]float64, 0, len(fields))
+	for _, field := range fields {
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+func svgNumberAttr(attrs map[string]string, key string) float64 {
+	value, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(attrs[key]), "px"), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+func parseSVGTransform(raw string) svgAffine {
+	matrix := svgIdentity
+	rest := strings.TrimSpace(raw)
+	for rest != "" {
+		open := strings.Index(rest, "(")
+		closeIndex := strings.Index(rest, ")")
+		if open < 0 || closeIndex < open {
+			break
+		}
+		name := strings.Trim(strings.TrimSpace(rest[:open]), ",")
+		name = strings.TrimSpace(name)
+		args := parseSVGNumberList(rest[open+1 : closeIndex])
+		rest = strings.TrimSpace(rest[closeIndex+1:])
+
+		next := svgIdentity
+		switch {
+		case name == "matrix" && len(args) == 6:
+			next = svgAffine{args[0], args[1], args[2], args[3], args[4], args[5]}
+		case name == "translate" && len(args) >= 1:
+			ty := 0.0
+			if len(args) > 1 {
+				ty = args[1]
+			}
+			next = svgAffine{1, 0, 0, 1, args[0], ty}
+		case name == "scale" && len(args) >= 1:
+			sy := args[0]
+			if len(args) > 1 {
+				sy = args[1]
+			}
+			next = svgAffine{args[0], 0, 0, sy, 0, 0}
+		case name == "rotate" && len(args) >= 1:
+			radians := args[0] * math.Pi / 180
+			cos, sin := math.Cos(radians), math.Sin(radians)
+			next = svgAffine{cos, sin, -sin, cos, 0, 0}
+			if len(args) == 3 {
+				next = svgAffine{1, 0, 0, 1, args[1], args[2]}.then(next).then(svgAffine{1, 0, 0, 1, -args[1], -args[2]})
+			}
+		case name == "skewX" && len(args) == 1:
+			next = svgAffine{1, 0, math.Tan(args[0] * math.Pi / 180), 1, 0, 0}
+		case name == "skewY" && len(args) == 1:
+			next = svgAffine{1, math.Tan(args[0] * math.Pi / 180), 0, 1, 0, 0}
+		}
+		matrix = matrix.then(next)
+	}
+	return matrix
+}
+
+func svgElementContours(name string, attrs map[string]string) []outlineContour {
+	switch name {
+	case "path":
+		return parseSVGPathData(attrs["d"])
+	case "rect":
+		width := svgNumberAttr(attrs, "width")
+		height := svgNumberAttr(attrs, "height")
+		if width <= 0 || height <= 0 {
+			return nil
+		}
+		return []outlineContour{rectangleContour(outlineRect{
+			X: svgNumberAttr(attrs, "x"), Y: svgNumberAttr(attrs, "y"), Width: width, Height: height,
+		})}
+	case "circle":
+		r := svgNumberAttr(attrs, "r")
+		if r <= 0 {
+			return nil
+		}
+		return []outlineContour{svgEllipseContour(svgNumberAttr(attrs, "cx"), svgNumberAttr(attrs, "cy"), r, r)}
+	case "ellipse":
+		rx := svgNumberAttr(attrs, "rx")
+		ry := svgNumberAttr(attrs, "ry")
+		if rx <= 0 || ry <= 0 {
+			return nil
+		}
+		return []outlineContour{svgEllipseContour(svgNumberAttr(attrs, "cx"), svgNumberAttr(attrs, "cy"), rx, ry)}
+	case "polygon", "polyline":
+		values := parseSVGNumberList(attrs["points"])
+		if len(values) < 6 {
+			return nil
+		}
+		contour := outlineContour{Start: outlinePoint{values[0], values[1]}}
+		for i := 2; i+1 < len(values); i += 2 {
+			contour.lineTo(outlinePoint{values[i], values[i+1]})
+		}
+		return []outlineContour{contour}
+	default:
+		return nil
+	}
+}
+
+func svgEllipseContour(cx, cy, rx, ry float64) outlineContour {
+	contour := circleContour(outlineRect{X: -1, Y: -1, Width: 2, Height: 2})
+	contour.mapPoints(func(p outlinePoint) outlinePoint {
+		return outlinePoint{cx + p.X*rx, cy + p.Y*ry}
+	})
+	return contour
+}
+
+// svgPathScanner tokenizes path data, including the compact arc flag syntax.
+type svgPathScanner struct {
+	data string
+	pos  int
+}
+
+func (s *svgPathScanner) skipSeparators() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\n', '\r', ',':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *svgPathScanner) command() (byte, bool) {
+	s.skipSeparators()
+	if s.pos >= len(s.data) {
+		return 0, false
+	}
+	c := s.data[s.pos]
+	if strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) >= 0 {
+		s.pos++
+		return c, true
+	}
+	return 0, false
+}
+
+func (s *svgPathScanner) hasNumber() bool {
+	s.skipSeparators()
+	if s.pos >= len(s.data) {
+		return false
+	}
+	c := s.data[s.pos]
+	return c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9')
+}
+
+func (s *svgPathScanner) number() (float64, bool) {
+	if !s.hasNumber() {
+		return 0, false
+	}
+	start := s.pos
+	if s.data[s.pos] == '-' || s.data[s.pos] == '+' {
+		s.pos++
+	}
+	seenDot := false
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		if c >= '0' && c <= '9' {
+			s.pos++
+			continue
+		}
+		if c == '.' && !seenDot {
+			seenDot = true
+			s.pos++
+			continue
+		}
+		break
+	}
+	if s.pos < len(s.data) && (s.data[s.pos] == 'e' || s.data[s.pos] == 'E') {
+		s.pos++
+		if s.pos < len(s.data) && (s.data[s.pos] == '-' || s.data[s.pos] == '+') {
+			s.pos++
+		}
+		for s.pos < len(s.data) && s.data[s.pos] >= '0' && s.data[s.pos] <= '9' {
+			s.pos++
+		}
+	}
+	value, err := strconv.ParseFloat(s.data[start:s.pos], 64)
+	return value, err == nil
+}
+
+func (s *svgPathScanner) flag() (bool, bool) {
+	s.skipSeparators()
+	if s.pos >= len(s.data) {
+		return false, false
+	}
+	switch s.data[s.pos] {
+	case '0':
+		s.pos++
+		return false, true
+	case '1':
+		s.pos++
+		return true, true
+	}
+	return false, false
+}
+
+func (s *svgPathScanner) numbers(n int) ([]float64, bool) {
+	values := make([]float64, n)
+	for i := range values {
+		value, ok := s.number()
+		if !ok {
+			return nil, false
+		}
+		values[i] = value
+	}
+	return values, true
+}
+
+// parseSVGPathData converts path data into closed contours. Open subpaths
+// are closed implicitly, as fills do.
+func parseSVGPathData(data string) []outlineContour {
+	scanner := &svgPathScanner{data: data}
+	contours := []outlineContour{}
+	var (
+		current      *outlineContour
+		point        outlinePoint
+		start        outlinePoint
+		lastControl  outlinePoint
+		lastQuad     outlinePoint
+		previousCmd  byte
+		command      byte
+		hasCommand   bool
+		implicitNext bool
+	)
+	flush := func() {
+		if current != nil && len(current.Segments) > 0 {
+			contours = append(contours, *current)
+		}
+		current = nil
+	}
+	ensure := func() {
+		if current == nil {
+			current = &outlineContour{Start: point}
+			start = point
+		}
+	}
+	quadTo := func(control, to outlinePoint) {
+		ensure()
+		c1 := point.add(control.sub(point).mul(2.0 / 3.0))
+		c2 := to.add(control.sub(to).mul(2.0 / 3.0))
+		current.cubicTo(c1, c2, to)
+	}
+
+	for {
+		if next, ok := scanner.command(); ok {
+			command = next
+			hasCommand = true
+			implicitNext = false
+		} else if !hasCommand || !scanner.hasNumber() || command == 'Z' || command == 'z' {
+			break
+		} else {
+			implicitNext = true
+		}
+		relative := command >= 'a' && command <= 'z'
+		offset := outlinePoint{}
+		if relative {
+			offset = point
+		}
+
+		switch command {
+		case 'M', 'm':
+			values, ok := scanner.numbers(2)
+			if !ok {
+				return contours
+			}
+			if implicitNext {
+				// Extra coordinate pairs after a moveto are linetos.
+				ensure()
+				point = offset.add(outlinePoint{values[0], values[1]})
+				current.lineTo(point)
+				break
+			}
+			flush()
+			point = offset.add(outlinePoint{values[0], values[1]})
+			ensure()
+		case 'L', 'l':
+			values, ok := scanner.numbers(2)
+			if !ok {
+				return contours
+			}
+			ensure()
+			point = offset.add(outlinePoint{values[0], values[1]})
+			current.lineTo(point)
+		case 'H', 'h':
+			value, ok := scanner.number()
+			if !ok {
+				return contours
+			}
+			ensure()
+			point = outlinePoint{offset.X + value, point.Y}
+			current.lineTo(point)
+		case 'V', 'v':
+			value, ok := scanner.number()
+			if !ok {
+				return contours
+			}
+			ensure()
+			point = outlinePoint{point.X, offset.Y + value}
+			current.lineTo(point)
+		case 'C', 'c':
+			values, ok := scanner.numbers(6)
+			if !ok {
+				return contours
+			}
+			ensure()
+			c1 := offset.add(outlinePoint{values[0], values[1]})
+			c2 := offset.add(outlinePoint{values[2], values[3]})
+			point = offset.add(outlinePoint{values[4], values[5]})
+			current.cubicTo(c1, c2, point)
+			lastControl = c2
+		case 'S', 's':
+			values, ok := scanner.numbers(4)
+			if !ok {
+				return contours
+			}
+			ensure()
+			c1 := point
+			if strings.IndexByte("CcSs", previousCmd) >= 0 {
+				c1 = point.add(point.sub(lastControl))
+			}
+			c2 := offset.add(outlinePoint{values[0], values[1]})
+			point = offset.add(outlinePoint{values[2], values[3]})
+			current.cubicTo(c1, c2, point)
+			lastControl = c2
+		case 'Q', 'q':
+			values, ok := scanner.numbers(4)
+			if !ok {
+				return contours
+			}
+			control := offset.add(outlinePoint{values[0], values[1]})
+			to := offset.add(outlinePoint{values[2], values[3]})
+			quadTo(control, to)
+			point = to
+			lastQuad = control
+		case 'T', 't':
+			values, ok := scanner.numbers(2)
+			if !ok {
+				return contours
+			}
+			control := point
+			if strings.IndexByte("QqTt", previousCmd) >= 0 {
+				control = point.add(point.sub(lastQuad))
+			}
+			to := offset.add(outlinePoint{values[0], values[1]})
+			quadTo(control, to)
+			point = to
+			lastQuad = control
+		case 'A', 'a':
+			radii, ok := scanner.numbers(3)
+			if !ok {
+				return contours
+			}
+			largeArc, ok := scanner.flag()
+			if !ok {
+				return contours
+			}
+			sweep, ok := scanner.flag()
+			if !ok {
+				return contours
+			}
+			values, ok := scanner.numbers(2)
+			if !ok {
+				return contours
+			}
+			ensure()
+			to := offset.add(outlinePoint{values[0], values[1]})
+			appendSVGArc(current, point, to, radii[0], radii[1], radii[2], largeArc, sweep)
+			point = to
+		case 'Z', 'z':
+			flush()
+			point = start
+		}
+		previousCmd = command
+	}
+	flush()
+	return contours
+}
+
+// appendSVGArc converts an endpoint-parameterized elliptical arc into cubic
+// segments of at most 90 degrees (SVG 1.1 implementation notes, F.6.5).
+func appendSVGArc(contour *outlineContour, from, to outlinePoint, rx, ry, rotationDegrees float64, largeArc, sweep bool) {
+	if from == to {
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		contour.lineTo(to)
+		return
+	}
+	phi := rotationDegrees * math.Pi / 180
+	cosPhi, sinPhi := math.Cos(phi), math.Sin(phi)
+	dx := (from.X - to.X) / 2
+	dy := (from.Y - to.Y) / 2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	lambda := (x1*x1)/(rx*rx) + (y1*y1)/(ry*ry)
+	if lambda > 1 {
+		scale := math.Sqrt(lambda)
+		rx *= scale
+		ry *= scale
+	}
+	numerator := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	denominator := rx*rx*y1*y1 + ry*ry*x1*x1
+	coefficient := 0.0
+	if denominator != 0 {
+		coefficient = math.Sqrt(math.Max(0, numerator/denominator))
+	}
+	if largeArc == sweep {
+		coefficient = -coefficient
+	}
+	cxp := coefficient * rx * y1 / ry
+	cyp := -coefficient * ry * x1 / rx
+	cx := cosPhi*cxp - sinPhi*cyp + (from.X+to.X)/2
+	cy := sinPhi*cxp + cosPhi*cyp + (from.Y+to.Y)/2
+
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta1 := angle(1, 0, (x1-cxp)/rx, (y1-cyp)/ry)
+	delta := angle((x1-cxp)/rx, (y1-cyp)/ry, (-x1-cxp)/rx, (-y1-cyp)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	steps := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(steps)
+	handle := 4.0 / 3.0 * math.Tan(step/4)
+	ellipsePoint := func(theta float64) (outlinePoint, outlinePoint) {
+		cosT, sinT := math.Cos(theta), math.Sin(theta)
+		p := outlinePoint{
+			cx + rx*cosT*cosPhi - ry*sinT*sinPhi,
+			cy + rx*cosT*sinPhi + ry*sinT*cosPhi,
+		}
+		derivative := outlinePoint{
+			-rx*sinT*cosPhi - ry*cosT*sinPhi,
+			-rx*sinT*sinPhi + ry*cosT*cosPhi,
+		}
+		return p, derivative
+	}
+	theta := theta1
+	p0, d0 := ellipsePoint(theta)
+	for i := 0; i < steps; i++ {
+		theta += step
+		p1, d1 := ellipsePoint(theta)
+		if i == steps-1 {
+			p1 = to
+		}
+		contour.cubicTo(p0.add(d0.mul(handle)), p1.sub(d1.mul(handle)), p1)
+		p0, d0 = p1, d1
+	}
+}
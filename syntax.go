@@ -0,0 +1,294 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+var errSyntaxNotFound = errors.New("syntax not found")
+
+// The types below mirror src/lib/types. Shape props are kept as raw JSON and
+// decoded on access, because void shapes carry arbitrary props.
+
+type shapeKind string
+
+const (
+	shapeVoid      shapeKind = "void"
+	shapeRectangle shapeKind = "rectangle"
+	shapeCircle    shapeKind = "circle"
+	shapeEllipse   shapeKind = "ellipse"
+	shapeQuarter   shapeKind = "quarter"
+	shapeTriangle  shapeKind = "triangle"
+	shapeSVG       shapeKind = "svg"
+)
+
+type propKind string
+
+const (
+	propNumber      propKind = "number"
+	propOrientation propKind = "orientation"
+	propBoolean     propKind = "boolean"
+	propString      propKind = "string"
+)
+
+type valueKind string
+
+const (
+	valueFixed  valueKind = "fixed"
+	valueChoice valueKind = "choice"
+	valueRange  valueKind = "range"
+)
+
+type orientation string
+
+const (
+	orientationNW orientation = "NW"
+	orientationSW orientation = "SW"
+	orientationNE orientation = "NE"
+	orientationSE orientation = "SE"
+)
+
+// orientations keeps the declaration order of Object.values(Orientation).
+var orientations = []orientation{orientationNW, orientationSW, orientationNE, orientationSE}
+
+type valueTemplate struct {
+	Kind valueKind       `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+type propTemplate struct {
+	Kind  propKind      `json:"kind"`
+	Value valueTemplate `json:"value"`
+}
+
+type valueDataChoice[T any] struct {
+	Options []T `json:"options"`
+}
+
+type valueDataRange struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+type ruleShape struct {
+	Kind  shapeKind                  `json:"kind"`
+	Props map[string]json.RawMessage `json:"props"`
+}
+
+type syntaxRule struct {
+	Symbol string    `json:"symbol"`
+	Shape  ruleShape `json:"shape"`
+	Unused bool      `json:"unused,omitempty"`
+}
+
+type syntaxGrid struct {
+	Rows    int `json:"rows"`
+	Columns int `json:"columns"`
+}
+
+type syntaxDocument struct {
+	ID    string       `json:"id"`
+	Name  string       `json:"name"`
+	Rules []syntaxRule `json:"rules"`
+	Grid  syntaxGrid   `json:"grid"`
+}
+
+type glyphDocument struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Structure string `json:"structure"`
+	Set       string `json:"set,omitempty"`
+	// Spacing overrides in grid cells; see glyphDocument.spacing.
+	LeftSidebearing  *int `json:"leftSidebearing,omitempty"`
+	RightSidebearing *int `json:"rightSidebearing,omitempty"`
+	Advance          *int `json:"advance,omitempty"`
+	// Anchors attach marks to the glyph, or the glyph to a base.
+	Anchors []glyphAnchor `json:"anchors,omitempty"`
+}
+
+func decodeSyntaxDocument(raw json.RawMessage) (syntaxDocument, error) {
+	var doc syntaxDocument
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return syntaxDocument{}, fmt.Errorf("invalid syntax: %w", err)
+	}
+	return doc, nil
+}
+
+func decodeGlyphDocument(raw json.RawMessage) (glyphDocument, error) {
+	var doc glyphDocument
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return glyphDocument{}, fmt.Errorf("invalid glyph: %w", err)
+	}
+	return doc, nil
+}
+
+// decodeGlyphDocuments decodes a snapshot glyph array, keeping its order.
+func decodeGlyphDocuments(raw json.RawMessage) ([]glyphDocument, error) {
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("glyphs must be an array")
+	}
+	glyphs := make([]glyphDocument, 0, len(list))
+	for _, item := range list {
+		glyph, err := decodeGlyphDocument(item)
+		if err != nil {
+			return nil, err
+		}
+		glyphs = append(glyphs, glyph)
+	}
+	return glyphs, nil
+}
+
+// decodeSyntaxDocuments decodes a snapshot syntax array, keeping its order.
+func decodeSyntaxDocuments(raw json.RawMessage) ([]syntaxDocument, error) {
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("syntaxes must be an array")
+	}
+	syntaxes := make([]syntaxDocument, 0, len(list))
+	for _, item := range list {
+		syntax, err := decodeSyntaxDocument(item)
+		if err != nil {
+			return nil, err
+		}
+		syntaxes = append(syntaxes, syntax)
+	}
+	return syntaxes, nil
+}
+
+// findSyntaxInSnapshot looks a syntax up by id first and then by name.
+func findSyntaxInSnapshot(raw json.RawMessage, key string) (syntaxDocument, error) {
+	key = strings.TrimSpace(key)
+	syntaxes, err := decodeSyntaxDocuments(raw)
+	if err != nil {
+		return syntaxDocument{}, err
+	}
+	for _, syntax := range syntaxes {
+		if syntax.ID == key {
+			return syntax, nil
+		}
+	}
+	for _, syntax := range syntaxes {
+		if strings.TrimSpace(syntax.Name) == key {
+			return syntax, nil
+		}
+	}
+	return syntaxDocument{}, fmt.Errorf("%w: %q", errSyntaxNotFound, key)
+}
+
+// getRule mirrors getRule in drawGlyph.ts.
+func (s syntaxDocument) getRule(symbol string) (syntaxRule, error) {
+	for _, rule := range s.Rules {
+		if rule.Symbol == symbol {
+			return rule, nil
+		}
+	}
+	return syntaxRule{}, fmt.Errorf("syntax %q has no rule for symbol %q", s.Name, symbol)
+}
+
+// rulesBySymbol keeps the first rule for every single-character symbol.
+func (s syntaxDocument) rulesBySymbol() map[string]syntaxRule {
+	out := map[string]syntaxRule{}
+	for _, rule := range s.Rules {
+		if len([]rune(rule.Symbol)) != 1 {
+			continue
+		}
+		if _, exists := out[rule.Symbol]; !exists {
+			out[rule.Symbol] = rule
+		}
+	}
+	return out
+}
+
+// transparentSymbols lists the symbols that let components show through.
+func (s syntaxDocument) transparentSymbols() map[string]struct{} {
+	out := map[string]struct{}{" ": {}}
+	for _, rule := range s.Rules {
+		if rule.Shape.Kind == shapeVoid && rule.Symbol != "" {
+			out[rule.Symbol] = struct{}{}
+		}
+	}
+	return out
+}
+
+func (shape ruleShape) prop(name string) (propTemplate, bool) {
+	raw, ok := shape.Props[name]
+	if !ok {
+		return propTemplate{}, false
+	}
+	var prop propTemplate
+	if err := json.Unmarshal(raw, &prop); err != nil {
+		return propTemplate{}, false
+	}
+	return prop, true
+}
+
+// propEvaluator resolves prop values the way calc*Prop in types/utils.ts do.
+// Choice and range values are drawn from a seeded source so builds repeat.
+type propEvaluator struct {
+	rng *rand.Rand
+}
+
+func newPropEvaluator(seed int64) *propEvaluator {
+	return &propEvaluator{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (e *propEvaluator) number(shape ruleShape, name string, fallback float64) float64 {
+	prop, ok := shape.prop(name)
+	if !ok {
+		return fallback
+	}
+	switch prop.Value.Kind {
+	case valueFixed:
+		var value float64
+		if err := json.Unmarshal(prop.Value.Data, &value); err == nil {
+			return value
+		}
+	case valueRange:
+		var data valueDataRange
+		if err := json.Unmarshal(prop.Value.Data, &data); err == nil {
+			return data.Min + e.rng.Float64()*(data.Max-data.Min)
+		}
+	case valueChoice:
+		var data valueDataChoice[float64]
+		if err := json.Unmarshal(prop.Value.Data, &data); err == nil && len(data.Options) > 0 {
+			return data.Options[e.rng.Intn(len(data.Options))]
+		}
+	}
+	return fallback
+}
+
+func evaluateChoiceProp[T any](e *propEvaluator, shape ruleShape, name string, fallback T) T {
+	prop, ok := shape.prop(name)
+	if !ok {
+		return fallback
+	}
+	switch prop.Value.Kind {
+	case valueFixed:
+		var value T
+		if err := json.Unmarshal(prop.Value.Data, &value); err == nil {
+			return value
+		}
+	case valueChoice:
+		var data valueDataChoice[T]
+		if err := json.Unmarshal(prop.Value.Data, &data); err == nil && len(data.Options) > 0 {
+			return data.Options[e.rng.Intn(len(data.Options))]
+		}
+	}
+	return fallback
+}
+
+func (e *propEvaluator) boolean(shape ruleShape, name string, fallback bool) bool {
+	return evaluateChoiceProp(e, shape, name, fallback)
+}
+
+func (e *propEvaluator) orientation(shape ruleShape, name string, fallback orientation) orientation {
+	return evaluateChoiceProp(e, shape, name, fallback)
+}
+
+func (e *propEvaluator) string(shape ruleShape, name string, fallback string) string {
+	return evaluateChoiceProp(e, shape, name, fallback)
+}
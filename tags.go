@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Tags give revisions names people remember, like v1.0 or client-review-2.
+// A tag is unique in a project and can be used wherever a revision id is
+// taken. Tagged revisions are milestones: they cannot be deleted until their
+// tags are removed.
+
+// Tags start with a letter, so they never read as the numeric revision ids.
+var revisionTagPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._-]*$`)
+
+type tagRevisionRequest struct {
+	ClientID string `json:"clientId,omitempty"`
+	ID       string `json:"id"`
+	// Tags replace the tags of the revision; an empty list removes them.
+	Tags []string `json:"tags"`
+}
+
+type deleteRevisionRequest struct {
+	ClientID string `json:"clientId,omitempty"`
+	ID       string `json:"id"`
+}
+
+// revisionTagConflictError is a tag already on another revision.
+type revisionTagConflictError struct {
+	Tag      string
+	Revision string
+}
+
+func (e *revisionTagConflictError) Error() string {
+	return fmt.Sprintf("tag %q is already on revision %s", e.Tag, e.Revision)
+}
+
+// revisionProtectedError refuses to delete a tagged revision.
+type revisionProtectedError struct {
+	Revision string
+	Tags     []string
+}
+
+func (e *revisionProtectedError) Error() string {
+	return fmt.Sprintf("revision %s is protected by its tags %s; remove them first", e.Revision, strings.Join(e.Tags, ", "))
+}
+
+func (doc revisionDocument) protected() bool {
+	return len(doc.Tags) > 0
+}
+
+// normalizeRevisionTags checks the tags for the revision revisionID, or for a
+// new revision when it is empty, against the tags of the other revisions.
+func normalizeRevisionTags(tags []string, revisions []revisionDocument, revisionID string) ([]string, error) {
+	taken := map[string]string{}
+	for _, revision := range revisions {
+		if revision.ID == revisionID {
+			continue
+		}
+		// A tag named like a revision id would never be looked up.
+		taken[revision.ID] = revision.ID
+		for _, tag := range revision.Tags {
+			taken[tag] = revision.ID
+		}
+	}
+	out := []string{}
+	seen := map[string]struct{}{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if !revisionTagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("invalid tag %q: use letters, digits, ., - and _, starting with a letter", tag)
+		}
+		if other, ok := taken[tag]; ok {
+			return nil, &revisionTagConflictError{Tag: tag, Revision: other}
+		}
+		if _, duplicate := seen[tag]; duplicate {
+			continue
+		}
+		seen[tag] = struct{}{}
+		out = append(out, tag)
+	}
+	return out, nil
+}
+
+// findTaggedRevision returns the revision carrying tag, or nil when no
+// revision does.
+func (h *hub) findTaggedRevision(projectID, tag string) (*revisionDocument, error) {
+	revisions, err := h.listRevisionDocuments(projectID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range revisions {
+		for _, candidate := range revisions[i].Tags {
+			if candidate == tag {
+				return &revisions[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// tagRevision replaces the tags of a revision. Only the tags of the stored
+// file change; the rest is written back as it was read.
+func (h *hub) tagRevision(projectID string, req tagRevisionRequest) (revisionMeta, error) {
+	projectID = sanitizeProjectID(projectID)
+	unlock := h.lockRevisions(projectID)
+	defer unlock()
+	revision, err := h.loadRevisionDocument(projectID, req.ID)
+	if err != nil {
+		return revisionMeta{}, err
+	}
+	revisions, err := h.listRevisionDocuments(projectID)
+	if err != nil {
+		return revisionMeta{}, err
+	}
+	tags, err := normalizeRevisionTags(req.Tags, revisions, revision.ID)
+	if err != nil {
+		return revisionMeta{}, err
+	}
+
+	path := h.projectRevisionFile(projectID, revision.ID)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return revisionMeta{}, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return revisionMeta{}, err
+	}
+	revision.Tags = nil
+	delete(fields, "tags")
+	if len(tags) > 0 {
+		revision.Tags = tags
+		if fields["tags"], err = json.Marshal(tags); err != nil {
+			return revisionMeta{}, err
+		}
+	}
+
+	bytes, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return revisionMeta{}, err
+	}
+	if err := writeJSONAtomic(path, bytes); err != nil {
+		return revisionMeta{}, err
+	}
+	return revisionMetaFromDocument(*revision), nil
+}
+
+func (h *hub) deleteRevision(projectID, revisionID string) error {
+	projectID = sanitizeProjectID(projectID)
+	unlock := h.lockRevisions(projectID)
+	defer unlock()
+	revision, err := h.loadRevisionDocument(projectID, revisionID)
+	if err != nil {
+		return err
+	}
+	if revision.protected() {
+		return &revisionProtectedError{Revision: revision.ID, Tags: revision.Tags}
+	}
+	return os.Remove(h.projectRevisionFile(projectID, revision.ID))
+}
+
+// writeRevisionError answers a failed revision write.
+func writeRevisionError(w http.ResponseWriter, err error) {
+	var (
+		tagErr       *revisionTagConflictError
+		protectedErr *revisionProtectedError
+	)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		http.Error(w, "revision not found", http.StatusNotFound)
+	case errors.As(err, &tagErr), errors.As(err, &protectedErr):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestRevisionTags(t *testing.T) {
+	h := newHub(t.TempDir())
+	saveTestGlyphs(t, h, "p", glyphDocument{ID: "1", Name: "e", Structure: "##"})
+	first := createTestRevision(t, h, "p", "first", "v1", " v1 ")
+	if len(first.Tags) != 1 || first.Tags[0] != "v1" || !first.Protected {
+		t.Fatalf("revision = %+v, want it protected by v1", first)
+	}
+
+	var tagErr *revisionTagConflictError
+	_, err := h.createRevision("p", createRevisionRequest{Message: "second", Tags: []string{"v1"}})
+	if !errors.As(err, &tagErr) || tagErr.Revision != first.ID {
+		t.Errorf("err = %v, want a conflict with %s", err, first.ID)
+	}
+	// Digits first would read as a revision id.
+	for _, tag := range []string{"-v2", first.ID, "2024.1"} {
+		if _, err := h.createRevision("p", createRevisionRequest{Tags: []string{tag}}); err == nil || errors.As(err, &tagErr) {
+			t.Errorf("invalid tag %q: err = %v", tag, err)
+		}
+	}
+
+	tagged, err := h.loadRevisionDocument("p", "v1")
+	if err != nil || tagged.ID != first.ID {
+		t.Fatalf("revision tagged v1 = %+v, %v; want %s", tagged, err, first.ID)
+	}
+	var protectedErr *revisionProtectedError
+	if err := h.deleteRevision("p", "v1"); !errors.As(err, &protectedErr) {
+		t.Errorf("deleting a tagged revision: err = %v", err)
+	}
+	if _, err := h.tagRevision("p", tagRevisionRequest{ID: "v1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.deleteRevision("p", first.ID); err != nil {
+		t.Errorf("deleting an untagged revision: %v", err)
+	}
+}
+
+// TestTagRevisionKeepsStoredFields tags a revision stored without a message
+// or a creation time, which reading the revision fills in.
+func TestTagRevisionKeepsStoredFields(t *testing.T) {
+	h := newHub(t.TempDir())
+	if err := os.MkdirAll(h.projectRevisionDir("p"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := h.projectRevisionFile("p", "old")
+	if err := os.WriteFile(path, []byte(`{"id": "old", "version": 3, "message": "", "glyphs": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := func() map[string]json.RawMessage {
+		t.Helper()
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			t.Fatal(err)
+		}
+		return fields
+	}
+
+	if _, err := h.tagRevision("p", tagRevisionRequest{ID: "old", Tags: []string{"v0"}}); err != nil {
+		t.Fatal(err)
+	}
+	fields := stored()
+	var tags []string
+	if err := json.Unmarshal(fields["tags"], &tags); err != nil || len(tags) != 1 || tags[0] != "v0" {
+		t.Errorf("stored tags = %s, want v0", fields["tags"])
+	}
+	if string(fields["message"]) != `""` || string(fields["version"]) != "3" {
+		t.Errorf("stored revision = %v", fields)
+	}
+	if _, ok := fields["createdAt"]; ok {
+		t.Errorf("tagging stored createdAt %s", fields["createdAt"])
+	}
+
+	// Revision ids stored by older servers may read as tags.
+	var tagErr *revisionTagConflictError
+	if _, err := h.createRevision("p", createRevisionRequest{Tags: []string{"old"}}); !errors.As(err, &tagErr) || tagErr.Revision != "old" {
+		t.Errorf("tag named like a revision id: err = %v", err)
+	}
+
+	if _, err := h.tagRevision("p", tagRevisionRequest{ID: "v0"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := stored()["tags"]; ok {
+		t.Error("removing the tags left them stored")
+	}
+}
+
+func TestConcurrentRevisionTags(t *testing.T) {
+	h := newHub(t.TempDir())
+	saveTestGlyphs(t, h, "p", glyphDocument{ID: "1", Name: "e", Structure: "##"})
+
+	const writers = 8
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		created int
+	)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := h.createRevision("p", createRevisionRequest{Message: fmt.Sprintf("writer %d", i), Tags: []string{"release"}})
+			var tagErr *revisionTagConflictError
+			if err != nil && !errors.As(err, &tagErr) {
+				t.Errorf("writer %d: %v", i, err)
+			}
+			if err == nil {
+				mu.Lock()
+				created++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	if created != 1 {
+		t.Errorf("%d revisions created with the same tag, want 1", created)
+	}
+}
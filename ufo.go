@@ -0,0 +1,521 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// UFO 3 sources for finishing a design in Glyphs, FontForge or fontmake. The
+// outlines are the compiled ones; lib.plist also keeps the syntax and the
+// grid structures, which is all the reader needs to restore the project.
+
+const (
+	ufoCreator        = "io.sssuper.chirone"
+	ufoFormatVersion  = 3
+	ufoGlyphsDir      = "glyphs"
+	ufoLibGlyphOrder  = "public.glyphOrder"
+	ufoLibSyntax      = "io.sssuper.chirone.syntax"
+	ufoLibGlyphs      = "io.sssuper.chirone.glyphs"
+	ufoLibMetrics     = "io.sssuper.chirone.metrics"
+	ufoLibKerning     = "io.sssuper.chirone.kerning"
+	ufoLibMetadata    = "io.sssuper.chirone.metadata"
+	ufoMaxFileNameLen = 255
+	ufoClashDigits    = 15
+)
+
+var errNotChironeUFO = errors.New("UFO was not written by Chirone")
+
+// ufoIllegalFileNameChars and ufoReservedFileNames follow the user name to
+// file name convention of the UFO 3 specification.
+const ufoIllegalFileNameChars = "\"*+/:<>?[\\]|"
+
+var ufoReservedFileNames = func() map[string]struct{} {
+	names := map[string]struct{}{"CON": {}, "PRN": {}, "AUX": {}, "CLOCK$": {}, "NUL": {}}
+	for i := 1; i <= 9; i++ {
+		names["COM"+strconv.Itoa(i)] = struct{}{}
+		names["LPT"+strconv.Itoa(i)] = struct{}{}
+	}
+	return names
+}()
+
+type ufoFile struct {
+	// Path is relative to the .ufo directory, with forward slashes.
+	Path string
+	Data []byte
+}
+
+type ufoFont struct {
+	Names  fontNames
+	Glyphs int
+	Files  []ufoFile
+	// Created dates the archive entries, so rebuilds are byte-identical.
+	Created time.Time
+}
+
+// ufoProject is what a Chirone UFO restores: one syntax and the glyphs,
+// metrics and kerning of the project it was exported from.
+type ufoProject struct {
+	Syntax  json.RawMessage
+	Glyphs  []json.RawMessage
+	Metrics json.RawMessage
+	// Kerning is empty for UFOs written before kerning was synced.
+	Kerning json.RawMessage
+	// Metadata is empty for UFOs of projects without synced metadata.
+	Metadata json.RawMessage
+}
+
+// compileProjectUFO writes one syntax of a snapshot as a UFO.
+func compileProjectUFO(snapshot projectSnapshot, syntaxKey string, metadata json.RawMessage) (*ufoFont, error) {
+	syntax, err := findSyntaxInSnapshot(snapshot.Syntaxes, syntaxKey)
+	if err != nil {
+		return nil, err
+	}
+	glyphs, err := decodeGlyphDocuments(snapshot.Glyphs)
+	if err != nil {
+		return nil, err
+	}
+	kerning, err := decodeKerningDocuments(snapshot.Kerning)
+	if err != nil {
+		return nil, err
+	}
+	font, err := compileFont(syntax, glyphs, kerning, normalizeFontMetrics(snapshot.Metrics), normalizeFontMetadata(metadata))
+	if err != nil {
+		return nil, err
+	}
+	syntaxes, err := parseEntityArrayByID(snapshot.Syntaxes, "syntaxes")
+	if err != nil {
+		return nil, err
+	}
+	var rawGlyphs []json.RawMessage
+	if err := json.Unmarshal(snapshot.Glyphs, &rawGlyphs); err != nil {
+		return nil, fmt.Errorf("glyphs must be an array")
+	}
+	return buildUFO(font, ufoProject{Syntax: syntaxes[syntax.ID], Glyphs: rawGlyphs, Metrics: snapshot.Metrics, Kerning: snapshot.Kerning, Metadata: snapshot.Metadata})
+}
+
+func buildUFO(font *compiledFont, source ufoProject) (*ufoFont, error) {
+	ufo := &ufoFont{Names: font.Names, Created: font.Created}
+	add := func(name string, value any) error {
+		data, err := encodePlist(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		ufo.Files = append(ufo.Files, ufoFile{Path: name, Data: data})
+		return nil
+	}
+
+	if err := add("metainfo.plist", plistDict{
+		{"creator", ufoCreator},
+		{"formatVersion", ufoFormatVersion},
+	}); err != nil {
+		return nil, err
+	}
+	if err := add("fontinfo.plist", font.ufoFontInfo()); err != nil {
+		return nil, err
+	}
+
+	glyphOrder := []string{}
+	contents := plistDict{}
+	glifs := []ufoFile{}
+	usedNames := map[string]struct{}{}
+	usedFiles := map[string]struct{}{}
+	for _, glyph := range font.Glyphs[1:] {
+		if _, exists := usedNames[glyph.Name]; exists || glyph.Name == "" {
+			continue
+		}
+		usedNames[glyph.Name] = struct{}{}
+		fileName := ufoGlyphFileName(glyph.Name, usedFiles)
+		glyphOrder = append(glyphOrder, glyph.Name)
+		contents = append(contents, plistEntry{glyph.Name, fileName})
+		glifs = append(glifs, ufoFile{Path: ufoGlyphsDir + "/" + fileName, Data: encodeGlif(glyph)})
+	}
+	ufo.Glyphs = len(glifs)
+
+	lib := plistDict{{ufoLibGlyphOrder, glyphOrder}}
+	if len(source.Syntax) > 0 {
+		syntax, err := jsonToPlist(source.Syntax)
+		if err != nil {
+			return nil, err
+		}
+		lib = append(lib, plistEntry{ufoLibSyntax, syntax})
+	}
+	glyphs := make([]any, 0, len(source.Glyphs))
+	for _, raw := range source.Glyphs {
+		glyph, err := jsonToPlist(raw)
+		if err != nil {
+			return nil, err
+		}
+		glyphs = append(glyphs, glyph)
+	}
+	lib = append(lib, plistEntry{ufoLibGlyphs, glyphs})
+	if len(source.Metrics) > 0 {
+		metrics, err := jsonToPlist(source.Metrics)
+		if err != nil {
+			return nil, err
+		}
+		lib = append(lib, plistEntry{ufoLibMetrics, metrics})
+	}
+	if len(source.Kerning) > 0 {
+		kerning, err := jsonToPlist(source.Kerning)
+		if err != nil {
+			return nil, err
+		}
+		lib = append(lib, plistEntry{ufoLibKerning, kerning})
+	}
+	if len(source.Metadata) > 0 {
+		metadata, err := jsonToPlist(source.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		lib = append(lib, plistEntry{ufoLibMetadata, metadata})
+	}
+	if err := add("lib.plist", lib); err != nil {
+		return nil, err
+	}
+
+	fea, _ := font.encodeFeatureFile()
+	ufo.Files = append(ufo.Files, ufoFile{Path: "features.fea", Data: []byte(fea)})
+	if err := add("layercontents.plist", []any{[]string{"public.default", ufoGlyphsDir}}); err != nil {
+		return nil, err
+	}
+	if err := add(ufoGlyphsDir+"/contents.plist", contents); err != nil {
+		return nil, err
+	}
+	ufo.Files = append(ufo.Files, glifs...)
+	return ufo, nil
+}
+
+func (f *compiledFont) ufoFontInfo() plistDict {
+	info := plistDict{
+		{"familyName", f.Names.FamilyName},
+		{"styleName", f.Names.StyleName},
+	}
+	if match := fontRevisionPattern.FindString(f.Names.Version); match != "" {
+		major, minor, _ := strings.Cut(match, ".")
+		if value, err := strconv.Atoi(major); err == nil {
+			info = append(info, plistEntry{"versionMajor", value})
+		}
+		minorValue, _ := strconv.Atoi(minor)
+		info = append(info, plistEntry{"versionMinor", minorValue})
+	}
+	info = append(info,
+		plistEntry{"unitsPerEm", f.UPM},
+		plistEntry{"ascender", f.Ascender},
+		plistEntry{"descender", f.Descender},
+		plistEntry{"capHeight", f.CapHeight},
+		plistEntry{"xHeight", f.XHeight},
+		plistEntry{"openTypeHeadCreated", f.Created.UTC().Format("2006/01/02 15:04:05")},
+	)
+	optional := []plistEntry{
+		{"openTypeNameDesigner", f.Names.Designer},
+		{"openTypeNameDesignerURL", f.Names.DesignerURL},
+		{"openTypeNameManufacturer", f.Names.Manufacturer},
+		{"openTypeNameManufacturerURL", f.Names.ManufacturerURL},
+		{"openTypeNameLicense", f.Names.License},
+		{"openTypeNameVersion", f.Names.Version},
+		{"openTypeOS2VendorID", f.VendorID},
+		{"postscriptFontName", f.Names.PostScriptName},
+		{"postscriptFullName", f.Names.FullName},
+	}
+	for _, entry := range optional {
+		if entry.Value != "" {
+			info = append(info, entry)
+		}
+	}
+	return info
+}
+
+// encodeGlif writes a glyph in GLIF format 2. Contours keep the compiler
+// orientation, outer contours counter-clockwise, as UFO expects for cubics.
+func encodeGlif(glyph fontGlyph) []byte {
+	var out bytes.Buffer
+	out.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	out.WriteString("<glyph name=\"")
+	_ = xml.EscapeText(&out, []byte(glyph.Name))
+	out.WriteString("\" format=\"2\">\n")
+	fmt.Fprintf(&out, "\t<advance width=\"%d\"/>\n", glyph.Advance)
+	if glyph.HasUnicode {
+		fmt.Fprintf(&out, "\t<unicode hex=\"%04X\"/>\n", glyph.Unicode)
+	}
+	for _, anchor := range glyph.Anchors {
+		fmt.Fprintf(&out, "\t<anchor x=\"%d\" y=\"%d\" name=\"%s\"/>\n", anchor.X, anchor.Y, anchor.Name)
+	}
+	contours := roundFontContours(glyph.Contours)
+	if len(contours) > 0 {
+		out.WriteString("\t<outline>\n")
+		for _, contour := range contours {
+			out.WriteString("\t\t<contour>\n")
+			writeGlifContour(&out, contour)
+			out.WriteString("\t\t</contour>\n")
+		}
+		out.WriteString("\t</outline>\n")
+	}
+	out.WriteString("</glyph>\n")
+	return out.Bytes()
+}
+
+// writeGlifContour lists the points of a closed contour. When the last
+// segment returns to the start, its end point stands for the start point;
+// otherwise the start opens the contour and the closing line is implied.
+func writeGlifContour(out *bytes.Buffer, contour outlineContour) {
+	point := func(p outlinePoint, kind string) {
+		fmt.Fprintf(out, "\t\t\t<point x=\"%d\" y=\"%d\"", int(p.X), int(p.Y))
+		if kind != "" {
+			fmt.Fprintf(out, " type=\"%s\"", kind)
+		}
+		out.WriteString("/>\n")
+	}
+	segments := contour.Segments
+	if n := len(segments); n == 0 || segments[n-1].To != contour.Start {
+		point(contour.Start, "line")
+	}
+	for _, segment := range segments {
+		if segment.Cubic {
+			point(segment.C1, "")
+			point(segment.C2, "")
+			point(segment.To, "curve")
+			continue
+		}
+		point(segment.To, "line")
+	}
+}
+
+// ufoGlyphFileName maps a glyph name to a .glif file name that is unique
+// among used, compared case-insensitively.
+func ufoGlyphFileName(name string, used map[string]struct{}) string {
+	var builder strings.Builder
+	for i, r := range name {
+		switch {
+		case r < 0x20 || r == 0x7f || strings.ContainsRune(ufoIllegalFileNameChars, r):
+			builder.WriteByte('_')
+		case i == 0 && r == '.':
+			builder.WriteByte('_')
+		case unicode.IsUpper(r):
+			builder.WriteRune(r)
+			builder.WriteByte('_')
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	parts := strings.Split(builder.String(), ".")
+	for i, part := range parts {
+		if _, reserved := ufoReservedFileNames[strings.ToUpper(part)]; reserved {
+			parts[i] = "_" + part
+		}
+	}
+	const suffix = ".glif"
+	base := []rune(strings.Join(parts, "."))
+	base = base[:min(len(base), ufoMaxFileNameLen-len(suffix))]
+
+	fileName := string(base) + suffix
+	for counter := 1; ; counter++ {
+		if _, taken := used[strings.ToLower(fileName)]; !taken {
+			break
+		}
+		prefix := base[:min(len(base), ufoMaxFileNameLen-len(suffix)-ufoClashDigits)]
+		fileName = fmt.Sprintf("%s%0*d%s", string(prefix), ufoClashDigits, counter, suffix)
+	}
+	used[strings.ToLower(fileName)] = struct{}{}
+	return fileName
+}
+
+// encodeZip packs the UFO as a .ufoz archive, whose single top-level
+// directory is the .ufo itself.
+func (u *ufoFont) encodeZip() ([]byte, error) {
+	var out bytes.Buffer
+	archive := zip.NewWriter(&out)
+	root := u.Names.PostScriptName + ".ufo"
+	for _, file := range u.Files {
+		writer, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     root + "/" + file.Path,
+			Method:   zip.Deflate,
+			Modified: u.Created,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(file.Data); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// writeUFODirectory replaces target with the given UFO files, so glyphs
+// removed from the project do not linger as stale .glif files.
+func writeUFODirectory(target string, files []ufoFile) error {
+	temp := target + ".tmp"
+	if err := os.RemoveAll(temp); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := writeFileAtomic(filepath.Join(temp, filepath.FromSlash(file.Path)), file.Data); err != nil {
+			return err
+		}
+	}
+	if err := os.RemoveAll(target); err != nil {
+		return err
+	}
+	return os.Rename(temp, target)
+}
+
+// openUFO opens a .ufo directory or a .ufoz archive.
+func openUFO(name string) (fs.FS, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return os.DirFS(name), nil
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return openUFOArchive(data)
+}
+
+// openUFOArchive finds the UFO inside a .ufoz archive: either at the root or
+// as its only top-level directory.
+func openUFOArchive(data []byte) (fs.FS, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid UFO archive: %w", err)
+	}
+	if _, err := fs.Stat(archive, "metainfo.plist"); err == nil {
+		return archive, nil
+	}
+	entries, err := fs.ReadDir(archive, ".")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if _, err := fs.Stat(archive, path.Join(entry.Name(), "metainfo.plist")); entry.IsDir() && err == nil {
+			return fs.Sub(archive, entry.Name())
+		}
+	}
+	return nil, errors.New("invalid UFO archive: metainfo.plist not found")
+}
+
+func readPlistFile(fsys fs.FS, name string) (any, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodePlist(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return value, nil
+}
+
+// readUFO restores the project data a Chirone UFO carries in lib.plist.
+// Outlines are derived from the structures, so edits made to them in other
+// editors are not read back.
+func readUFO(fsys fs.FS) (ufoProject, error) {
+	metainfo, err := readPlistFile(fsys, "metainfo.plist")
+	if err != nil {
+		return ufoProject{}, err
+	}
+	metainfoDict, _ := metainfo.(map[string]any)
+	if version, _ := metainfoDict["formatVersion"].(int64); version != ufoFormatVersion {
+		return ufoProject{}, fmt.Errorf("unsupported UFO format version %v, expected %d", metainfoDict["formatVersion"], ufoFormatVersion)
+	}
+
+	lib, err := readPlistFile(fsys, "lib.plist")
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ufoProject{}, errNotChironeUFO
+		}
+		return ufoProject{}, err
+	}
+	libDict, _ := lib.(map[string]any)
+	syntax, okSyntax := libDict[ufoLibSyntax].(map[string]any)
+	glyphs, okGlyphs := libDict[ufoLibGlyphs].([]any)
+	if !okSyntax || !okGlyphs {
+		return ufoProject{}, errNotChironeUFO
+	}
+
+	project := ufoProject{Metrics: json.RawMessage(`{}`)}
+	if project.Syntax, err = json.Marshal(syntax); err != nil {
+		return ufoProject{}, err
+	}
+	for _, glyph := range glyphs {
+		if _, ok := glyph.(map[string]any); !ok {
+			return ufoProject{}, fmt.Errorf("%s must hold dicts", ufoLibGlyphs)
+		}
+		raw, err := json.Marshal(glyph)
+		if err != nil {
+			return ufoProject{}, err
+		}
+		project.Glyphs = append(project.Glyphs, raw)
+	}
+	if metrics, ok := libDict[ufoLibMetrics].(map[string]any); ok {
+		if project.Metrics, err = json.Marshal(metrics); err != nil {
+			return ufoProject{}, err
+		}
+	}
+	if kerning, ok := libDict[ufoLibKerning].([]any); ok {
+		if project.Kerning, err = json.Marshal(kerning); err != nil {
+			return ufoProject{}, err
+		}
+	}
+	if metadata, ok := libDict[ufoLibMetadata].(map[string]any); ok {
+		if project.Metadata, err = json.Marshal(metadata); err != nil {
+			return ufoProject{}, err
+		}
+	}
+	return project, nil
+}
+
+// mergeUFOProjects combines the UFOs of one family, one per syntax, into a
+// project snapshot. Glyphs are shared, so a later UFO replaces glyphs with
+// the same id; metrics, kerning and metadata come from the first UFO.
+func mergeUFOProjects(ufos []ufoProject) (projectSnapshot, error) {
+	glyphs := map[string]json.RawMessage{}
+	syntaxes := map[string]json.RawMessage{}
+	for _, ufo := range ufos {
+		id, syntax, err := parseEntityItem(ufo.Syntax, "syntax")
+		if err != nil {
+			return projectSnapshot{}, err
+		}
+		syntaxes[id] = syntax
+		for _, raw := range ufo.Glyphs {
+			id, glyph, err := parseEntityItem(raw, "glyph")
+			if err != nil {
+				return projectSnapshot{}, err
+			}
+			glyphs[id] = glyph
+		}
+	}
+
+	snapshot := projectSnapshot{Metrics: json.RawMessage(`{}`)}
+	if len(ufos) > 0 {
+		snapshot.Metrics = ufos[0].Metrics
+		snapshot.Kerning = ufos[0].Kerning
+		snapshot.Metadata = ufos[0].Metadata
+	}
+	var err error
+	if snapshot.Glyphs, err = serializeEntityMap(glyphs); err != nil {
+		return projectSnapshot{}, err
+	}
+	if snapshot.Syntaxes, err = serializeEntityMap(syntaxes); err != nil {
+		return projectSnapshot{}, err
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestUFORoundTrip(t *testing.T) {
+	snapshot := testSnapshot(t,
+		glyphDocument{ID: "1", Name: "A", Structure: "###\n# #\n###\n# #", Advance: intPointer(4)},
+		glyphDocument{ID: "2", Name: "eacute", Structure: "#"},
+	)
+	snapshot.Metadata = json.RawMessage(`{"familyName": "Round", "designer": "Ada"}`)
+	ufo, err := compileProjectUFO(snapshot, "regular", snapshot.Metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ufo.encodeZip()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys, err := openUFOArchive(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := readPlistFile(fsys, "fontinfo.plist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	infoDict, _ := info.(map[string]any)
+	if infoDict["familyName"] != "Round" || infoDict["openTypeNameDesigner"] != "Ada" {
+		t.Errorf("fontinfo = %v, want the metadata names", infoDict)
+	}
+	contents, err := readPlistFile(fsys, ufoGlyphsDir+"/contents.plist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	contentsDict, _ := contents.(map[string]any)
+	// Cells are 200 units wide.
+	for name, want := range map[string][]string{
+		"A":      {`<advance width="800"/>`, `<unicode hex="0041"/>`},
+		"eacute": {`<advance width="200"/>`, `<unicode hex="00E9"/>`},
+	} {
+		fileName, _ := contentsDict[name].(string)
+		glif, err := fs.ReadFile(fsys, ufoGlyphsDir+"/"+fileName)
+		if err != nil {
+			t.Fatalf("glif of %s: %v", name, err)
+		}
+		for _, line := range want {
+			if !strings.Contains(string(glif), line) {
+				t.Errorf("glif of %s has no %s:\n%s", name, line, glif)
+			}
+		}
+	}
+
+	project, err := readUFO(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := newHub(t.TempDir())
+	doc, err := h.importUFOs("q", []ufoProject{project}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	glyphs, err := decodeGlyphDocuments(doc.Glyphs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(glyphs) != 2 || glyphs[0].Name != "A" || glyphs[1].Name != "eacute" {
+		t.Fatalf("restored glyphs = %+v", glyphs)
+	}
+	if glyphs[0].Advance == nil || *glyphs[0].Advance != 4 || glyphs[0].Structure != "###\n# #\n###\n# #" {
+		t.Errorf("restored A = %+v", glyphs[0])
+	}
+	if normalizeFontMetrics(doc.Metrics) != normalizeFontMetrics(snapshot.Metrics) {
+		t.Errorf("restored metrics = %s, want %s", doc.Metrics, snapshot.Metrics)
+	}
+	if normalizeFontMetadata(doc.Metadata) != normalizeFontMetadata(snapshot.Metadata) {
+		t.Errorf("restored metadata = %s, want %s", doc.Metadata, snapshot.Metadata)
+	}
+}
@@ -0,0 +1,551 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Write validation keeps documents every client can render: a syntax must
+// match the types in src/lib/types, or calc*Prop and drawPath throw for all
+// collaborators once it is broadcast.
+
+type validationProblem struct {
+	// Path locates the offending value, e.g. rules[2].shape.props.rotation.
+	Path string `json:"path"`
+	// Line and Column are 1-based positions inside a glyph structure.
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// validationError is answered with 422 and lists every problem found in one
+// entity.
+type validationError struct {
+	Entity   string              `json:"entity"`
+	EntityID string              `json:"entityId,omitempty"`
+	Problems []validationProblem `json:"problems"`
+}
+
+func (e *validationError) Error() string {
+	messages := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		location := problem.Path
+		if problem.Line > 0 {
+			location = fmt.Sprintf("%s:%d:%d", problem.Path, problem.Line, problem.Column)
+		}
+		if location == "" {
+			messages = append(messages, problem.Message)
+			continue
+		}
+		messages = append(messages, location+": "+problem.Message)
+	}
+	if e.EntityID == "" {
+		return fmt.Sprintf("invalid %s: %s", e.Entity, strings.Join(messages, "; "))
+	}
+	return fmt.Sprintf("invalid %s %q: %s", e.Entity, e.EntityID, strings.Join(messages, "; "))
+}
+
+func (e *validationError) add(path, format string, args ...any) {
+	e.Problems = append(e.Problems, validationProblem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// result returns nil when nothing was added, so callers can return it as is.
+func (e *validationError) result() error {
+	if len(e.Problems) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *validationError) addAt(path string, line, column int, format string, args ...any) {
+	e.Problems = append(e.Problems, validationProblem{Path: path, Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (e *validationError) addDecodeError(err error) {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		e.add(typeErr.Field, "must be %s, got %s", jsonTypeName(typeErr.Type.Kind().String()), typeErr.Value)
+		return
+	}
+	e.add("", "%v", err)
+}
+
+func writeValidationError(w http.ResponseWriter, err *validationError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	_ = json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+		*validationError
+	}{err.Error(), err})
+}
+
+// isDryRun reports whether a write asks for validation only (?dryRun=1), so
+// the editor can show problems before saving.
+func isDryRun(r *http.Request) bool {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+	return dryRun
+}
+
+// shapePropSpec is a prop a shape kind cannot be drawn without.
+type shapePropSpec struct {
+	Name string
+	Kind propKind
+	// Optional props are checked only when present.
+	Optional bool
+}
+
+var baseShapeProps = []shapePropSpec{
+	{Name: "scale_x", Kind: propNumber},
+	{Name: "scale_y", Kind: propNumber},
+	{Name: "rotation", Kind: propNumber},
+}
+
+// shapeProps mirrors the *Props types of shapes.ts. Void shapes take any
+// props; the SVG path may be missing because drawPath falls back to a
+// rectangle.
+var shapeProps = map[shapeKind][]shapePropSpec{
+	shapeVoid:      nil,
+	shapeRectangle: baseShapeProps,
+	shapeCircle:    baseShapeProps,
+	shapeEllipse: append(slices.Clone(baseShapeProps),
+		shapePropSpec{Name: "squaring", Kind: propNumber},
+		shapePropSpec{Name: "negative", Kind: propBoolean},
+	),
+	shapeQuarter: append(slices.Clone(baseShapeProps),
+		shapePropSpec{Name: "squaring", Kind: propNumber},
+		shapePropSpec{Name: "negative", Kind: propBoolean},
+		shapePropSpec{Name: "orientation", Kind: propOrientation},
+	),
+	shapeTriangle: append(slices.Clone(baseShapeProps),
+		shapePropSpec{Name: "orientation", Kind: propOrientation},
+	),
+	shapeSVG: append(slices.Clone(baseShapeProps),
+		shapePropSpec{Name: "path", Kind: propString, Optional: true},
+		shapePropSpec{Name: "negative", Kind: propBoolean},
+	),
+}
+
+// validateSyntax checks a syntax entity before it is stored.
+func validateSyntax(id string, raw json.RawMessage) error {
+	problems := &validationError{Entity: "syntax", EntityID: id}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		problems.add("", "must be a JSON object")
+		return problems
+	}
+	var syntax syntaxDocument
+	if err := json.Unmarshal(raw, &syntax); err != nil {
+		problems.addDecodeError(err)
+		return problems
+	}
+
+	if _, ok := fields["name"]; !ok {
+		problems.add("name", "is required")
+	}
+	if _, ok := fields["rules"]; !ok || syntax.Rules == nil {
+		problems.add("rules", "must be an array")
+	}
+	if syntax.Grid.Rows < 1 {
+		problems.add("grid.rows", "must be a positive integer")
+	}
+	if syntax.Grid.Columns < 1 {
+		problems.add("grid.columns", "must be a positive integer")
+	}
+	for i, rule := range syntax.Rules {
+		path := fmt.Sprintf("rules[%d]", i)
+		if rule.Symbol == "" {
+			problems.add(path+".symbol", "is required")
+		}
+		validateShape(problems, path+".shape", rule.Shape)
+	}
+	return problems.result()
+}
+
+func validateShape(problems *validationError, path string, shape ruleShape) {
+	specs, ok := shapeProps[shape.Kind]
+	if !ok {
+		problems.add(path+".kind", "unknown shape kind %q", shape.Kind)
+		return
+	}
+	for _, spec := range specs {
+		raw, ok := shape.Props[spec.Name]
+		if !ok || isJSONNull(raw) {
+			if !spec.Optional {
+				problems.add(path+".props."+spec.Name, "is required for %s shapes", shape.Kind)
+			}
+			continue
+		}
+		validateProp(problems, path+".props."+spec.Name, spec.Kind, raw)
+	}
+}
+
+func validateProp(problems *validationError, path string, kind propKind, raw json.RawMessage) {
+	var prop propTemplate
+	if err := json.Unmarshal(raw, &prop); err != nil {
+		problems.add(path, "must be a %s prop", kind)
+		return
+	}
+	if prop.Kind != kind {
+		problems.add(path+".kind", "must be %q, got %q", kind, prop.Kind)
+		return
+	}
+
+	data := prop.Value.Data
+	switch prop.Value.Kind {
+	case valueFixed:
+		if err := validatePropValue(kind, data); err != nil {
+			problems.add(path+".value.data", "%v", err)
+		}
+	case valueChoice:
+		var choice struct {
+			Options []json.RawMessage `json:"options"`
+		}
+		if err := json.Unmarshal(data, &choice); err != nil || choice.Options == nil {
+			problems.add(path+".value.data.options", "must be an array")
+			return
+		}
+		// String choices start empty in the editor until files are picked.
+		if len(choice.Options) == 0 && kind != propString {
+			problems.add(path+".value.data.options", "must not be empty")
+		}
+		for i, option := range choice.Options {
+			if err := validatePropValue(kind, option); err != nil {
+				problems.add(fmt.Sprintf("%s.value.data.options[%d]", path, i), "%v", err)
+			}
+		}
+	case valueRange:
+		if kind != propNumber {
+			problems.add(path+".value.kind", "range is only allowed for number props")
+			return
+		}
+		var bounds struct {
+			Min *float64 `json:"min"`
+			Max *float64 `json:"max"`
+		}
+		if err := json.Unmarshal(data, &bounds); err != nil || bounds.Min == nil || bounds.Max == nil {
+			problems.add(path+".value.data", "must have numeric min and max")
+			return
+		}
+		if *bounds.Min > *bounds.Max {
+			problems.add(path+".value.data", "min %v is greater than max %v", *bounds.Min, *bounds.Max)
+		}
+	default:
+		problems.add(path+".value.kind", "unknown value kind %q", prop.Value.Kind)
+	}
+}
+
+// validatePropValue checks one fixed value or choice option.
+func validatePropValue(kind propKind, raw json.RawMessage) error {
+	if isJSONNull(raw) {
+		return fmt.Errorf("must be %s", jsonTypeName(string(kind)))
+	}
+	var err error
+	switch kind {
+	case propNumber:
+		var value float64
+		err = json.Unmarshal(raw, &value)
+	case propBoolean:
+		var value bool
+		err = json.Unmarshal(raw, &value)
+	case propString:
+		var value string
+		err = json.Unmarshal(raw, &value)
+	case propOrientation:
+		var value orientation
+		if err = json.Unmarshal(raw, &value); err == nil && !slices.Contains(orientations, value) {
+			return fmt.Errorf("unknown orientation %q", value)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("must be %s", jsonTypeName(string(kind)))
+	}
+	return nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(raw) == "null"
+}
+
+// jsonTypeName names Go kinds and prop kinds the way a client sees them.
+func jsonTypeName(kind string) string {
+	switch kind {
+	case "int", "int64", "float64", "number":
+		return "a number"
+	case "bool", "boolean":
+		return "a boolean"
+	case "string":
+		return "a string"
+	case "orientation":
+		return "an orientation (NW, SW, NE, SE)"
+	case "slice":
+		return "an array"
+	case "map", "struct":
+		return "an object"
+	default:
+		return kind
+	}
+}
+
+// validateSyntaxMap checks the syntaxes of a project snapshot that differ
+// from the stored ones, in id order, and stops at the first invalid one.
+func validateSyntaxMap(syntaxes, stored map[string]json.RawMessage) error {
+	for _, id := range changedEntityIDs(syntaxes, stored) {
+		if err := validateSyntax(id, syntaxes[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateGlyph checks the structure of a glyph against the glyphs, keyed by
+// id, it will be stored with. Only the glyph and the components it reaches
+// are decoded.
+func validateGlyph(id string, raw json.RawMessage, glyphs map[string]json.RawMessage) error {
+	return checkGlyphStructure(id, raw, &glyphLookup{glyphs: glyphs, editedID: id, edited: raw})
+}
+
+// validateGlyphMap checks the glyphs of a project snapshot that differ from
+// the stored ones, in id order, and stops at the first invalid one. Stored
+// glyphs are not checked again, so rules added later do not block saves of
+// projects that predate them.
+func validateGlyphMap(glyphs, stored map[string]json.RawMessage) error {
+	lookup := &glyphLookup{glyphs: glyphs}
+	for _, id := range changedEntityIDs(glyphs, stored) {
+		if err := checkGlyphStructure(id, glyphs[id], lookup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkGlyphStructure reports problems with line and column in the raw
+// structure; lookup resolves the components it names.
+func checkGlyphStructure(id string, raw json.RawMessage, lookup *glyphLookup) error {
+	problems := &validationError{Entity: "glyph", EntityID: id}
+	validateGlyphSpacing(problems, raw)
+	validateGlyphAnchors(problems, raw)
+	if len(problems.Problems) > 0 {
+		return problems
+	}
+	var glyph glyphDocument
+	if err := json.Unmarshal(raw, &glyph); err != nil {
+		problems.addDecodeError(err)
+		return problems
+	}
+
+	lines := strings.Split(normalizeLineEndings(glyph.Structure), "\n")
+	open, closing, hasFrontmatter := frontmatterBounds(lines)
+	if !hasFrontmatter {
+		validateStructureRows(problems, lines, 1)
+		return problems.result()
+	}
+
+	graph := &componentGraph{lookup: lookup, depths: map[string]int{}}
+	components := 0
+	for _, partial := range scanStructureFrontmatter(lines[open+1:closing], open+2) {
+		if value, ok := partial.values["rotation"]; ok {
+			if partial.rotation == nil {
+				problems.addAt("structure", value.Line, value.Column, "rotation %q is not a number", value.Raw)
+			} else if *partial.rotation%componentRotationStep != 0 {
+				problems.addAt("structure", value.Line, value.Column, "rotation %d is not a multiple of %d degrees", *partial.rotation, componentRotationStep)
+			}
+		}
+		component, ok := partial.sanitize()
+		if !ok {
+			continue
+		}
+		components++
+		name := partial.values["name"]
+		if _, exists := lookup.find(component.Name); !exists {
+			problems.addAt("structure", name.Line, name.Column, "no glyph named %q", component.Name)
+			continue
+		}
+		graph.cycle = nil
+		depth := 1 + graph.depth(component.Name, []string{glyph.Name})
+		if graph.cycle != nil {
+			problems.addAt("structure", name.Line, name.Column, "component cycle %s", strings.Join(graph.cycle, " -> "))
+		} else if depth > maxComponentDepth {
+			problems.addAt("structure", name.Line, name.Column, "components nest %d levels deep, clients stop at %d", depth, maxComponentDepth)
+		}
+	}
+	if components == 0 {
+		validateStructureRows(problems, lines[closing+1:], closing+2)
+		return problems.result()
+	}
+	// Components may widen the first row, so a composite is checked once
+	// they are laid under its body. An advance override replaces the width
+	// of the first row, and ink past it is left to lint.
+	if len(problems.Problems) == 0 && glyph.Advance == nil {
+		resolved := resolveGlyphStructures(lookup.closure(glyph), structureResolveOptions{})[glyph.Name]
+		raggedRows(strings.Split(resolved, "\n"), func(row, _, width int) {
+			problems.add("structure", "row %d of the resolved glyph is wider than its first row (%d columns), which sets the advance width", row+1, width)
+		})
+	}
+	return problems.result()
+}
+
+// validateStructureRows reports ink past the end of the first row: the
+// advance width of a glyph is the length of its first row, so such cells
+// overlap the next glyph.
+func validateStructureRows(problems *validationError, rows []string, firstLine int) {
+	raggedRows(rows, func(row, column, width int) {
+		problems.addAt("structure", firstLine+row, column+1, "row is wider than the first row (%d columns), which sets the advance width", width)
+	})
+}
+
+// raggedRows calls report with the index of every row that has ink past
+// the end of the first row, the first such column and the width of the
+// first row.
+func raggedRows(rows []string, report func(row, column, width int)) {
+	if len(rows) == 0 {
+		return
+	}
+	width := utf8.RuneCountInString(rows[0])
+	for i, row := range rows[1:] {
+		for column, r := range []rune(row) {
+			if column >= width && !unicode.IsSpace(r) {
+				report(i+1, column, width)
+				break
+			}
+		}
+	}
+}
+
+// glyphLookup finds glyphs by name the way clients resolve components: the
+// first glyph in id order wins on duplicate names. Names are indexed the
+// first time one is looked up, and glyphs are decoded as they are found.
+type glyphLookup struct {
+	glyphs map[string]json.RawMessage
+	// edited stands in for the glyph editedID, which may be new.
+	editedID string
+	edited   json.RawMessage
+
+	ids       map[string]string
+	documents map[string]glyphDocument
+}
+
+func (l *glyphLookup) raw(id string) json.RawMessage {
+	if l.edited != nil && id == l.editedID {
+		return l.edited
+	}
+	return l.glyphs[id]
+}
+
+func (l *glyphLookup) index() {
+	ids := sortedEntityIDs(l.glyphs)
+	if _, exists := l.glyphs[l.editedID]; l.edited != nil && !exists {
+		ids = append(ids, l.editedID)
+		sort.Strings(ids)
+	}
+	l.ids = map[string]string{}
+	l.documents = map[string]glyphDocument{}
+	for _, id := range ids {
+		var named struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(l.raw(id), &named) != nil {
+			continue
+		}
+		if _, exists := l.ids[named.Name]; !exists {
+			l.ids[named.Name] = id
+		}
+	}
+}
+
+func (l *glyphLookup) find(name string) (glyphDocument, bool) {
+	if l.ids == nil {
+		l.index()
+	}
+	if glyph, ok := l.documents[name]; ok {
+		return glyph, true
+	}
+	id, ok := l.ids[name]
+	if !ok {
+		return glyphDocument{}, false
+	}
+	var glyph glyphDocument
+	if json.Unmarshal(l.raw(id), &glyph) != nil {
+		return glyphDocument{}, false
+	}
+	l.documents[name] = glyph
+	return glyph, true
+}
+
+// closure lists glyph followed by every glyph its components reach.
+func (l *glyphLookup) closure(glyph glyphDocument) []glyphDocument {
+	out := []glyphDocument{glyph}
+	seen := map[string]struct{}{glyph.Name: {}}
+	for i := 0; i < len(out); i++ {
+		for _, component := range parseGlyphStructure(out[i].Structure).Components {
+			if _, ok := seen[component.Name]; ok {
+				continue
+			}
+			seen[component.Name] = struct{}{}
+			if found, ok := l.find(component.Name); ok {
+				out = append(out, found)
+			}
+		}
+	}
+	return out
+}
+
+// componentGraph measures how deeply components nest below a glyph.
+type componentGraph struct {
+	lookup *glyphLookup
+	depths map[string]int
+	// cycle is set to the first path leading back to the glyph being
+	// validated, which is the first entry of every path.
+	cycle []string
+}
+
+func (g *componentGraph) depth(name string, path []string) int {
+	if depth, ok := g.depths[name]; ok {
+		return depth
+	}
+	glyph, ok := g.lookup.find(name)
+	if !ok {
+		return 0
+	}
+	if slices.Contains(path, name) {
+		if name == path[0] && g.cycle == nil {
+			g.cycle = append(slices.Clone(path), name)
+		}
+		return 0
+	}
+	path = append(path, name)
+	depth := 0
+	for _, component := range parseGlyphStructure(glyph.Structure).Components {
+		depth = max(depth, 1+g.depth(component.Name, path))
+	}
+	g.depths[name] = depth
+	return depth
+}
+
+func sortedEntityIDs(entities map[string]json.RawMessage) []string {
+	ids := make([]string, 0, len(entities))
+	for id := range entities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// changedEntityIDs lists, in id order, the entities that are new or differ
+// from stored.
+func changedEntityIDs(entities, stored map[string]json.RawMessage) []string {
+	ids := make([]string, 0, len(entities))
+	for _, id := range sortedEntityIDs(entities) {
+		if previous, ok := stored[id]; !ok || string(previous) != string(entities[id]) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testGlyphRaw(t *testing.T, glyph glyphDocument) json.RawMessage {
+	t.Helper()
+	raw, err := json.Marshal(glyph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	normalized, err := normalizedRawObject(raw, "glyph")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return normalized
+}
+
+func testGlyphMap(t *testing.T, glyphs ...glyphDocument) map[string]json.RawMessage {
+	t.Helper()
+	out := map[string]json.RawMessage{}
+	for _, glyph := range glyphs {
+		out[glyph.ID] = testGlyphRaw(t, glyph)
+	}
+	return out
+}
+
+func validationMessages(t *testing.T, err error) string {
+	t.Helper()
+	if err == nil {
+		return ""
+	}
+	var problems *validationError
+	if !errors.As(err, &problems) {
+		t.Fatalf("error %v is not a validation error", err)
+	}
+	return problems.Error()
+}
+
+func TestValidateGlyph(t *testing.T) {
+	advance := 3
+	glyphs := testGlyphMap(t,
+		glyphDocument{ID: "1", Name: "e", Structure: "###\n#\n###"},
+		glyphDocument{ID: "2", Name: "acute", Structure: "  #"},
+		glyphDocument{ID: "3", Name: "loop", Structure: "---\ncomponents:\n  - name: eacute\n---\n"},
+		// Unrelated glyphs are never decoded as structures.
+		glyphDocument{ID: "4", Name: "broken", Structure: "---\ncomponents:\n  - name: nowhere\n---\n"},
+	)
+	tests := []struct {
+		name  string
+		glyph glyphDocument
+		want  string
+	}{
+		{"plain", glyphDocument{Name: "o", Structure: "###\n# #\n###"}, ""},
+		{"ragged plain", glyphDocument{Name: "o", Structure: "##\n# #"}, "structure:2:3: row is wider than the first row (2 columns)"},
+		{"component widens the first row", glyphDocument{Name: "eacute", Structure: "---\ncomponents:\n  - name: e\n---\n#"}, ""},
+		{"ragged composite", glyphDocument{Name: "eacute", Structure: "---\ncomponents:\n  - name: e\n    y: 2\n---\n\n  #"}, "row 2 of the resolved glyph is wider than its first row (0 columns)"},
+		{"advance override", glyphDocument{Name: "eacute", Advance: &advance, Structure: "---\ncomponents:\n  - name: e\n    y: 2\n---\n\n  #"}, ""},
+		{"missing component", glyphDocument{Name: "x", Structure: "---\ncomponents:\n  - name: nowhere\n---\n"}, `no glyph named "nowhere"`},
+		{"cycle", glyphDocument{Name: "eacute", Structure: "---\ncomponents:\n  - name: loop\n---\n"}, "component cycle eacute -> loop -> eacute"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.glyph.ID = "9"
+			got := validationMessages(t, validateGlyph("9", testGlyphRaw(t, test.glyph), glyphs))
+			if test.want == "" && got != "" {
+				t.Fatalf("unexpected problems: %s", got)
+			}
+			if !strings.Contains(got, test.want) {
+				t.Fatalf("problems %q do not mention %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateGlyphMapChecksChangedGlyphs(t *testing.T) {
+	stored := testGlyphMap(t,
+		glyphDocument{ID: "1", Name: "a", Structure: "#\n##"},
+		glyphDocument{ID: "2", Name: "b", Structure: "##"},
+	)
+	if err := validateGlyphMap(stored, stored); err != nil {
+		t.Fatalf("stored glyphs were checked again: %v", err)
+	}
+
+	next := testGlyphMap(t,
+		glyphDocument{ID: "1", Name: "a", Structure: "#\n##"},
+		glyphDocument{ID: "2", Name: "b", Structure: "#\n##"},
+	)
+	got := validationMessages(t, validateGlyphMap(next, stored))
+	if !strings.Contains(got, `glyph "2"`) || strings.Contains(got, `glyph "1"`) {
+		t.Fatalf("problems = %q, want only glyph 2", got)
+	}
+	if err := validateGlyphMap(next, nil); err == nil {
+		t.Fatal("new glyphs were not checked")
+	}
+}
+
+func TestUpdateProjectKeepsStoredGlyphs(t *testing.T) {
+	h := newHub(t.TempDir())
+	state, err := newEmptyProjectState("legacy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A glyph stored before ragged rows were rejected.
+	state.Entities[glyphKind.Name].Items = testGlyphMap(t, glyphDocument{ID: "1", Name: "a", Structure: "#\n##"})
+	h.projects["legacy"] = state
+
+	save := func(glyphs ...glyphDocument) error {
+		list := make([]json.RawMessage, 0, len(glyphs))
+		for _, glyph := range glyphs {
+			list = append(list, testGlyphRaw(t, glyph))
+		}
+		raw, err := json.Marshal(list)
+		if err != nil {
+			t.Fatal(err)
+		}
+		base := h.projects["legacy"].Doc.Version
+		_, err = h.updateProject("legacy", updateProjectRequest{
+			BaseVersion:     &base,
+			projectSnapshot: projectSnapshot{Glyphs: raw},
+		})
+		return err
+	}
+
+	legacy := glyphDocument{ID: "1", Name: "a", Structure: "#\n##"}
+	if err := save(legacy, glyphDocument{ID: "2", Name: "b", Structure: "##"}); err != nil {
+		t.Fatalf("save with a stored legacy glyph: %v", err)
+	}
+	if err := save(legacy, glyphDocument{ID: "2", Name: "b", Structure: "#\n##"}); err == nil {
+		t.Fatal("save with a new ragged glyph was accepted")
+	}
+}
+
+// serveTestRequest sends a request through the server routes.
+func serveTestRequest(t *testing.T, h *hub, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	(&server{hub: h}).routes().ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return recorder
+}
+
+func TestSyntaxWriteValidationError(t *testing.T) {
+	rectangle := func(scaleX string) string {
+		return `{"kind": "rectangle", "props": {
+			"scale_x": ` + scaleX + `,
+			"scale_y": {"kind": "number", "value": {"kind": "fixed", "data": 1}},
+			"rotation": {"kind": "number", "value": {"kind": "fixed", "data": 0}}
+		}}`
+	}
+	tests := []struct {
+		name  string
+		shape string
+		want  validationProblem
+	}{
+		{
+			"range min above max",
+			rectangle(`{"kind": "number", "value": {"kind": "range", "data": {"min": 2, "max": 1}}}`),
+			validationProblem{Path: "rules[0].shape.props.scale_x.value.data", Message: "min 2 is greater than max 1"},
+		},
+		{
+			"unknown shape kind",
+			`{"kind": "hexagon", "props": {}}`,
+			validationProblem{Path: "rules[0].shape.kind", Message: `unknown shape kind "hexagon"`},
+		},
+		{
+			"unknown prop kind",
+			rectangle(`{"kind": "color", "value": {"kind": "fixed", "data": 1}}`),
+			validationProblem{Path: "rules[0].shape.props.scale_x.kind", Message: `must be "number", got "color"`},
+		},
+		{
+			"wrong value type",
+			rectangle(`{"kind": "number", "value": {"kind": "fixed", "data": "wide"}}`),
+			validationProblem{Path: "rules[0].shape.props.scale_x.value.data", Message: "must be a number"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := newHub(t.TempDir())
+			body := `{"baseVersion": 0, "syntax": {"id": "s", "name": "S", "grid": {"rows": 1, "columns": 1},
+				"rules": [{"symbol": "#", "shape": ` + test.shape + `}]}}`
+			recorder := serveTestRequest(t, h, http.MethodPut, "/api/syntax?project=p", body)
+			if recorder.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("status = %d: %s", recorder.Code, recorder.Body)
+			}
+			var response struct {
+				Error    string              `json:"error"`
+				Entity   string              `json:"entity"`
+				EntityID string              `json:"entityId"`
+				Problems []validationProblem `json:"problems"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("body %s: %v", recorder.Body, err)
+			}
+			if response.Entity != "syntax" || response.EntityID != "s" || response.Error == "" {
+				t.Errorf("response = %+v", response)
+			}
+			if len(response.Problems) != 1 || response.Problems[0] != test.want {
+				t.Errorf("problems = %+v, want %+v", response.Problems, test.want)
+			}
+		})
+	}
+}